@@ -0,0 +1,20 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"ms-parcel-core/internal/infrastructure/persistence"
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	paymentcashboxposting "ms-parcel-core/internal/parcel/parcel_payment/infrastructure/cashboxposting"
+	paymentusecase "ms-parcel-core/internal/parcel/parcel_payment/usecase"
+)
+
+// startCashboxPostingRetry arranca el reintento de movimientos de caja que fallaron al registrarse.
+func startCashboxPostingRetry(ctx context.Context, workers *sync.WaitGroup, repos persistence.Repositories, cashbox coreport.CashboxClient, logger *slog.Logger) {
+	uc := paymentusecase.NewRetryCashboxPostingsUseCase(repos.Payments, cashbox, logger)
+	worker := paymentcashboxposting.NewRetryWorker(uc, 30*time.Second, 100, logger)
+	workers.Go(func() { worker.Run(ctx) })
+}
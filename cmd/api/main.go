@@ -1,27 +1,140 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
+	"ms-parcel-core/internal/config"
+	"ms-parcel-core/internal/infrastructure/health"
 	"ms-parcel-core/internal/infrastructure/http/middleware"
 	httpRouter "ms-parcel-core/internal/infrastructure/http/router"
+	appmetrics "ms-parcel-core/internal/infrastructure/metrics"
+	"ms-parcel-core/internal/infrastructure/persistence"
+	"ms-parcel-core/internal/infrastructure/persistence/database"
+	"ms-parcel-core/internal/infrastructure/telemetry"
+	"ms-parcel-core/internal/pkg/util/logging"
 )
 
 func main() {
 
 	_ = godotenv.Load(".env")
+
+	// JSON en producción (APP_ENV=prod), texto en desarrollo
+	logger := logging.NewFromEnv()
+	slog.SetDefault(logger)
+
+	// api migrate up|down|status administra el esquema sin levantar el servidor.
+	// Los logs van a stderr para no mezclarse con el SQL de --dry-run.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		logger = logging.New(os.Stderr, os.Getenv("APP_ENV"), logging.LevelFromEnv())
+		slog.SetDefault(logger)
+		if err := runMigrate(logger, os.Args[2:]); err != nil {
+			logger.Error("migrate failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := run(logger); err != nil {
+		logger.Error("server stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(logger *slog.Logger) error {
+	// SIGTERM (orquestador) o SIGINT (Ctrl+C) inician el apagado ordenado
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	metrics := appmetrics.NewPrometheusMetrics(appmetrics.ConfigFromEnv())
+
+	// Trazas OpenTelemetry: OTEL_TRACES_EXPORTER=none|stdout|otlp (default none)
+	tracingCfg := telemetry.TracingConfigFromEnv()
+	shutdownTracing, err := telemetry.SetupTracing(ctx, tracingCfg, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Warn("error al cerrar el exporter de trazas", "error", err)
+		}
+	}()
+
+	checks := health.NewService(envDurationMs("HEALTH_CHECK_TIMEOUT_MS", 2*time.Second))
+
+	// Sin DB_HOST los repositorios son en memoria (desarrollo); con base configurada es crítica para readiness
+	repos := persistence.NewInMemoryRepositories()
+	if dbCfg, ok := config.DBConfigFromEnv(); ok {
+		db, err := database.Connect(dbCfg, logger)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := database.Close(db); err != nil {
+				logger.Warn("error al cerrar la conexión a la base", "error", err)
+			}
+		}()
+		// Varias instancias pueden arrancar a la vez: el migrador toma un advisory lock
+		if os.Getenv("DB_AUTO_MIGRATE") == "true" {
+			if err := database.Migrate(ctx, db, logger); err != nil {
+				return err
+			}
+		}
+		checks.Register("database", health.CheckerFunc(func(ctx context.Context) error { return database.Ping(ctx, db) }), true)
+		checks.Register("migrations", health.CheckerFunc(func(ctx context.Context) error { return database.CheckMigrations(ctx, db) }), true)
+		repos = persistence.NewPostgresRepositories(db)
+	}
+
+	// Workers en segundo plano: al salir se cancelan y se esperan antes de cerrar el publisher y la
+	// base (los defers corren en orden inverso, así que este va después de registrar esos cierres)
+	var workers sync.WaitGroup
+	defer func() {
+		stop()
+		waitWorkers(&workers, envDurationMs("SHUTDOWN_TIMEOUT_MS", 15*time.Second), logger)
+	}()
+
+	// Outbox: OUTBOX_PUBLISHER=none|stdout|file|http (default none)
+	closeOutbox, err := startOutboxDispatcher(ctx, &workers, repos, logger)
+	if err != nil {
+		return err
+	}
+	defer closeOutbox()
+	startWebhookDispatcher(ctx, &workers, repos, logger)
+	cashbox := httpRouter.NewCashboxClient(checks)
+	startCashboxPostingRetry(ctx, &workers, repos, cashbox, logger)
+
 	// Gin base (manténlo simple por ahora)
 	r := gin.New()
-	r.Use(gin.Recovery())
+	// Solo se confía en X-Forwarded-For si viene de un proxy conocido (TRUSTED_PROXIES)
+	if err := r.SetTrustedProxies(trustedProxiesFromEnv()); err != nil {
+		return fmt.Errorf("TRUSTED_PROXIES inválido: %w", err)
+	}
+	r.Use(middleware.RequestIDMiddleware())
+	r.Use(otelgin.Middleware(tracingCfg.ServiceName, otelgin.WithGinFilter(traceRequest)))
+	r.Use(middleware.AccessLogMiddleware(logger))
+	r.Use(middleware.MetricsMiddleware(metrics))
+	r.Use(middleware.RecoveryMiddleware(logger))
 	r.Use(middleware.AuthMiddleware())
-	r.Use(middleware.ErrorMiddleware())
+	r.Use(middleware.RequestContextMiddleware())
+	r.Use(middleware.ErrorMiddleware(logger))
+	r.Use(middleware.EventSourceMiddleware())
 
-	// Registrar rutas del monolito
-	httpRouter.RegisterRoutes(r)
+	// Registrar rutas del monolito; workers en segundo plano viven hasta el apagado
+	httpRouter.RegisterRoutes(ctx, r, repos, cashbox, checks, logger, metrics)
 
 	// Puerto
 	port := os.Getenv("PORT")
@@ -29,8 +142,82 @@ func main() {
 		port = "8080"
 	}
 
-	log.Println("listening on :" + port)
-	if err := r.Run(":" + port); err != nil {
-		log.Fatal(err)
+	srv := &http.Server{
+		Addr:              ":" + port,
+		Handler:           r,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Info("listening", "port", port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+		close(serveErr)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	stop()
+
+	// Readiness pasa a 503 y se espera a que el balanceador lo note antes de dejar de aceptar conexiones
+	checks.SetDraining()
+	drainDelay := envDurationMs("SHUTDOWN_DRAIN_DELAY_MS", 0)
+	timeout := envDurationMs("SHUTDOWN_TIMEOUT_MS", 15*time.Second)
+	logger.Info("apagando servidor", "drain_delay_ms", drainDelay.Milliseconds(), "timeout_ms", timeout.Milliseconds())
+	time.Sleep(drainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	logger.Info("servidor detenido")
+	return nil
+}
+
+// traceRequest excluye de las trazas los endpoints de probes y métricas.
+func traceRequest(c *gin.Context) bool {
+	path := c.FullPath()
+	return path != "/metrics" && !strings.HasPrefix(path, "/health")
+}
+
+// trustedProxiesFromEnv lee TRUSTED_PROXIES: IPs o CIDRs separados por coma. Vacío (default) = no
+// se confía en ningún proxy y la IP del cliente es la de la conexión.
+func trustedProxiesFromEnv() []string {
+	var proxies []string
+	for _, p := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
+}
+
+// waitWorkers espera a que terminen los workers en segundo plano, como máximo timeout: un envío
+// colgado no debe impedir que el proceso termine.
+func waitWorkers(workers *sync.WaitGroup, timeout time.Duration, logger *slog.Logger) {
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		logger.Info("workers en segundo plano detenidos")
+	case <-time.After(timeout):
+		logger.Warn("workers en segundo plano sin terminar al vencer el timeout", "timeout_ms", timeout.Milliseconds())
+	}
+}
+
+func envDurationMs(key string, def time.Duration) time.Duration {
+	v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || v < 0 {
+		return def
 	}
+	return time.Duration(v) * time.Millisecond
 }
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"ms-parcel-core/internal/config"
+	"ms-parcel-core/internal/infrastructure/cli"
+	"ms-parcel-core/internal/infrastructure/persistence/database"
+)
+
+// runMigrate implementa el subcomando migrate; usa la misma configuración DB_* que el servidor.
+func runMigrate(logger *slog.Logger, args []string) error {
+	cmd, err := cli.ParseMigrateArgs(args, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	dbCfg, ok := config.DBConfigFromEnv()
+	if !ok {
+		return errors.New("DB_HOST no está definido")
+	}
+	db, err := database.Connect(dbCfg, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := database.Close(db); err != nil {
+			logger.Warn("error al cerrar la conexión a la base", "error", err)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	m, err := database.NewMigrator(db, logger)
+	if err != nil {
+		return err
+	}
+	return cli.RunMigrate(ctx, m, cmd, os.Stdout, logger)
+}
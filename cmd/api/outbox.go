@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+
+	"ms-parcel-core/internal/infrastructure/persistence"
+	outboxdispatcher "ms-parcel-core/internal/parcel/parcel_outbox/infrastructure/dispatcher"
+	outboxpublisher "ms-parcel-core/internal/parcel/parcel_outbox/infrastructure/publisher"
+	outboxusecase "ms-parcel-core/internal/parcel/parcel_outbox/usecase"
+)
+
+// startOutboxDispatcher arranca la publicación de eventos del outbox según OUTBOX_PUBLISHER. Con none no
+// arranca: los eventos se siguen guardando y se publican cuando se configure un publisher. Devuelve la
+// función que libera el publisher (p.ej. cierra el archivo) al apagar.
+func startOutboxDispatcher(ctx context.Context, workers *sync.WaitGroup, repos persistence.Repositories, logger *slog.Logger) (func(), error) {
+	cfg := outboxpublisher.ConfigFromEnv()
+	publisher, err := outboxpublisher.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if publisher == nil {
+		logger.Info("outbox sin publisher configurado, los eventos quedan pendientes", "publisher", cfg.Kind)
+		return func() {}, nil
+	}
+
+	uc := outboxusecase.NewDispatchOutboxEventsUseCase(repos.Outbox, publisher, cfg.HTTPTimeout, logger)
+	worker := outboxdispatcher.NewDispatchWorker(uc, cfg.Interval, cfg.Batch, logger)
+	workers.Go(func() { worker.Run(ctx) })
+	logger.Info("dispatcher de outbox iniciado", "publisher", cfg.Kind, "interval_ms", cfg.Interval.Milliseconds(), "batch", cfg.Batch)
+
+	return func() {
+		if c, ok := publisher.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				logger.Warn("error al cerrar el publisher de outbox", "error", err)
+			}
+		}
+	}, nil
+}
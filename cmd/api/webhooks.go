@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"ms-parcel-core/internal/infrastructure/persistence"
+	webhookdispatcher "ms-parcel-core/internal/parcel/parcel_webhook/infrastructure/dispatcher"
+	webhooksender "ms-parcel-core/internal/parcel/parcel_webhook/infrastructure/sender"
+	webhookusecase "ms-parcel-core/internal/parcel/parcel_webhook/usecase"
+)
+
+// startWebhookDispatcher arranca el envío de webhooks salvo WEBHOOK_DISPATCH_ENABLED=false. Apagado, las
+// entregas se siguen encolando y las envía la réplica que tenga el dispatcher activo.
+func startWebhookDispatcher(ctx context.Context, workers *sync.WaitGroup, repos persistence.Repositories, logger *slog.Logger) {
+	cfg := webhookdispatcher.ConfigFromEnv()
+	if !cfg.Enabled {
+		logger.Info("dispatcher de webhooks deshabilitado, las entregas quedan pendientes")
+		return
+	}
+
+	sender := webhooksender.NewHTTPWebhookSender(cfg.HTTPTimeout, nil, cfg.AllowInsecureURLs)
+	uc := webhookusecase.NewDispatchWebhookDeliveriesUseCase(repos.WebhookSubscriptions, repos.WebhookDeliveries, sender, cfg.HTTPTimeout, logger)
+	worker := webhookdispatcher.NewDispatchWorker(uc, cfg.Interval, cfg.Batch, logger)
+	workers.Go(func() { worker.Run(ctx) })
+	logger.Info("dispatcher de webhooks iniciado", "interval_ms", cfg.Interval.Milliseconds(), "batch", cfg.Batch, "timeout_ms", cfg.HTTPTimeout.Milliseconds())
+}
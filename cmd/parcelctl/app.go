@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"ms-parcel-core/internal/infrastructure/cli"
+	"ms-parcel-core/internal/infrastructure/persistence"
+	"ms-parcel-core/internal/parcel/parcel_core/usecase"
+	manifestusecase "ms-parcel-core/internal/parcel/parcel_manifest/usecase"
+	outboxrecorder "ms-parcel-core/internal/parcel/parcel_outbox/infrastructure/recorder"
+	paymentbalance "ms-parcel-core/internal/parcel/parcel_payment/infrastructure/balance"
+	paymentusecase "ms-parcel-core/internal/parcel/parcel_payment/usecase"
+	pricingusecase "ms-parcel-core/internal/parcel/parcel_pricing/usecase"
+	trackingrecorder "ms-parcel-core/internal/parcel/parcel_tracking/infrastructure/recorder"
+	trackingusecase "ms-parcel-core/internal/parcel/parcel_tracking/usecase"
+	webhookrecorder "ms-parcel-core/internal/parcel/parcel_webhook/infrastructure/recorder"
+)
+
+// app arma los casos de uso con los mismos adaptadores que el composition root de la API.
+// Sin métricas: parcelctl es un proceso corto y no expone /metrics.
+type app struct {
+	repos  persistence.Repositories
+	out    io.Writer
+	logger *slog.Logger
+}
+
+func newApp(repos persistence.Repositories, out io.Writer, logger *slog.Logger) *app {
+	return &app{repos: repos, out: out, logger: logger}
+}
+
+// tracking también encola las entregas de webhooks; las envía el dispatcher de la API.
+func (a *app) tracking() *webhookrecorder.WebhookTrackingRecorder {
+	return webhookrecorder.NewWebhookTrackingRecorder(trackingrecorder.NewTrackingRecorderAdapter(a.repos.Tracking), a.repos.WebhookSubscriptions, a.repos.WebhookDeliveries, a.repos.Parcels, a.logger)
+}
+
+// events encola en el outbox los eventos de las transiciones; los publica el dispatcher de la API.
+func (a *app) events() *outboxrecorder.OutboxRecorderAdapter {
+	return outboxrecorder.NewOutboxRecorderAdapter(a.repos.Outbox)
+}
+
+func (a *app) listParcels() *usecase.ListParcelsUseCase {
+	return usecase.NewListParcelsUseCase(a.repos.Parcels)
+}
+
+func (a *app) parcelSummary() *usecase.GetParcelSummaryUseCase {
+	return usecase.NewGetParcelSummaryUseCase(a.repos.Parcels, a.repos.Items, a.repos.Payments, a.repos.Tracking)
+}
+
+func (a *app) listTracking() *trackingusecase.ListTrackingUseCase {
+	return trackingusecase.NewListTrackingUseCase(a.repos.Tracking)
+}
+
+func (a *app) importPriceRules() *pricingusecase.ImportPriceRulesUseCase {
+	return pricingusecase.NewImportPriceRulesUseCase(a.repos.PriceRules, a.repos.UnitOfWork)
+}
+
+func (a *app) recalculatePayment() *paymentusecase.RecalculateParcelPaymentUseCase {
+	return paymentusecase.NewRecalculateParcelPaymentUseCase(a.repos.Payments, a.repos.Items, a.repos.UnitOfWork)
+}
+
+func (a *app) buildManifest() *manifestusecase.BuildManifestPreviewUseCase {
+	return manifestusecase.NewBuildManifestPreviewUseCase(a.repos.Parcels)
+}
+
+func (a *app) transitions() transitionUseCases {
+	trk, evs := a.tracking(), a.events()
+	return transitionUseCases{
+		register: usecase.NewRegisterParcelUseCase(a.repos.Parcels, trk, a.repos.UnitOfWork, evs, nil, a.logger),
+		board:    usecase.NewBoardParcelUseCase(a.repos.Parcels, trk, a.repos.UnitOfWork, evs, nil, a.logger),
+		depart:   usecase.NewDepartParcelUseCase(a.repos.Parcels, trk, a.repos.UnitOfWork, evs, nil, a.logger),
+		arrive:   usecase.NewArriveParcelUseCase(a.repos.Parcels, trk, a.repos.UnitOfWork, evs, nil, a.logger),
+		deliver:  usecase.NewDeliverParcelUseCase(a.repos.Parcels, trk, paymentbalance.NewPaymentBalanceAdapter(a.repos.Payments), a.repos.UnitOfWork, evs, nil, a.logger),
+	}
+}
+
+// commonFlags son los flags que aceptan todos los comandos de datos.
+type commonFlags struct {
+	tenant string
+	output string
+}
+
+func newFlagSet(name string, common *commonFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet("parcelctl "+name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.StringVar(&common.tenant, "tenant", "", "tenant_id (requerido)")
+	fs.StringVar(&common.output, "output", cli.OutputTable, "formato de salida: table|json")
+	return fs
+}
+
+// parse valida los flags comunes y devuelve el printer configurado.
+func (a *app) parse(fs *flag.FlagSet, common *commonFlags, args []string) (*cli.Printer, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("argumentos inesperados: %s", strings.Join(fs.Args(), " "))
+	}
+	if strings.TrimSpace(common.tenant) == "" {
+		fs.Usage()
+		return nil, errors.New("--tenant es requerido")
+	}
+	return cli.NewPrinter(a.out, common.output)
+}
+
+// parseDay acepta YYYY-MM-DD (UTC) o RFC3339. endOfDay lleva una fecha sin hora al último instante del día.
+func parseDay(name string, v string, endOfDay bool) (*time.Time, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return nil, fmt.Errorf("--%s inválido (YYYY-MM-DD o RFC3339): %s", name, v)
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
@@ -0,0 +1,146 @@
+// parcelctl es la herramienta de operaciones: trabaja directo contra PostgreSQL (DB_*) usando los
+// mismos casos de uso que la API, sin pasar por HTTP ni por la autenticación del gateway.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+
+	"ms-parcel-core/internal/config"
+	"ms-parcel-core/internal/infrastructure/cli"
+	"ms-parcel-core/internal/infrastructure/persistence"
+	"ms-parcel-core/internal/infrastructure/persistence/database"
+	"ms-parcel-core/internal/pkg/util/logging"
+)
+
+const usage = `uso: parcelctl <comando> [flags]
+
+comandos:
+  migrate up|down|status          administra el esquema (ver parcelctl migrate)
+  price-rules seed                carga reglas de precios de un tenant desde CSV/XLSX
+  parcels find                    busca un envío por tracking code (con items, pago y tracking)
+  parcels replay                  vuelve a ejecutar una transición de estado trabada
+  parcels export                  exporta los envíos de un rango de fechas
+  rebuild payments                recalcula montos y saldos de pagos desde items y ledger
+  manifest preview                arma el manifiesto de un vehículo con sus totales
+                                  (se calculan al leer, no hay totales guardados que reconstruir)
+
+flags comunes: --tenant ID (requerido salvo migrate), --output table|json (default table).
+La conexión se configura con DB_HOST, DB_PORT, DB_USER, DB_PASSWORD y DB_NAME.`
+
+// errUsage indica que ya se mostró la ayuda; main sale con código 2 sin loguear el error.
+var errUsage = errors.New("uso inválido")
+
+type command func(ctx context.Context, app *app, args []string) error
+
+var commands = map[string]command{
+	"price-rules seed": seedPriceRules,
+	"parcels find":     findParcel,
+	"parcels replay":   replayTransition,
+	"parcels export":   exportParcels,
+	"rebuild payments": rebuildPayments,
+	"manifest preview": previewManifest,
+}
+
+func main() {
+	_ = godotenv.Load(".env")
+
+	// Los logs van a stderr para no mezclarse con la salida (tabla o JSON) en stdout
+	logger := logging.New(os.Stderr, os.Getenv("APP_ENV"), logging.LevelFromEnv())
+	slog.SetDefault(logger)
+
+	err := run(os.Args[1:], os.Stdout, logger)
+	switch {
+	case err == nil:
+	case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+		if err != errUsage && err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(2)
+	default:
+		logger.Error("parcelctl", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer, logger *slog.Logger) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprintln(os.Stderr, usage)
+		return errUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if args[0] == "migrate" {
+		cmd, err := cli.ParseMigrateArgs(args[1:], os.Stderr)
+		if err != nil {
+			return err
+		}
+		db, closeDB, err := connect(logger)
+		if err != nil {
+			return err
+		}
+		defer closeDB()
+		m, err := database.NewMigrator(db, logger)
+		if err != nil {
+			return err
+		}
+		return cli.RunMigrate(ctx, m, cmd, out, logger)
+	}
+
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		return errUsage
+	}
+	cmd, ok := commands[args[0]+" "+args[1]]
+	if !ok {
+		fmt.Fprintln(os.Stderr, usage)
+		return fmt.Errorf("%w: comando desconocido %q", errUsage, args[0]+" "+args[1])
+	}
+
+	db, closeDB, err := connect(logger)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	// parcelctl no aplica migraciones implícitamente (para eso está parcelctl migrate up)
+	m, err := database.NewMigrator(db, logger)
+	if err != nil {
+		return err
+	}
+	if err := m.Check(ctx); err != nil {
+		return err
+	}
+
+	return cmd(ctx, newApp(persistence.NewPostgresRepositories(db), out, logger), args[2:])
+}
+
+// connect abre la base con la configuración DB_* del entorno.
+func connect(logger *slog.Logger) (*gorm.DB, func(), error) {
+	dbCfg, ok := config.DBConfigFromEnv()
+	if !ok {
+		return nil, nil, errors.New("DB_HOST no está definido")
+	}
+	db, err := database.Connect(dbCfg, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	closeDB := func() {
+		if err := database.Close(db); err != nil {
+			logger.Warn("error al cerrar la conexión a la base", "error", err)
+		}
+	}
+	return db, closeDB, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ms-parcel-core/internal/infrastructure/cli"
+	manifestusecase "ms-parcel-core/internal/parcel/parcel_manifest/usecase"
+)
+
+type manifestView struct {
+	VehicleID           string               `json:"vehicle_id"`
+	OriginOfficeID      string               `json:"origin_office_id"`
+	DestinationOfficeID string               `json:"destination_office_id"`
+	Parcels             []manifestParcelView `json:"parcels"`
+	Totals              manifestTotalsView   `json:"totals"`
+}
+
+type manifestParcelView struct {
+	ParcelID          string  `json:"parcel_id"`
+	Status            string  `json:"status"`
+	SenderPersonID    string  `json:"sender_person_id"`
+	RecipientPersonID string  `json:"recipient_person_id"`
+	Notes             *string `json:"notes,omitempty"`
+}
+
+type manifestTotalsView struct {
+	CountParcels int `json:"count_parcels"`
+}
+
+// previewManifest: manifest preview --tenant T --vehicle V --origin O --destination D
+func previewManifest(ctx context.Context, a *app, args []string) error {
+	var common commonFlags
+	fs := newFlagSet("manifest preview", &common)
+	vehicleID := fs.String("vehicle", "", "vehicle_id (requerido)")
+	originID := fs.String("origin", "", "oficina de origen (requerido)")
+	destinationID := fs.String("destination", "", "oficina de destino (requerido)")
+	printer, err := a.parse(fs, &common, args)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(*vehicleID) == "" || strings.TrimSpace(*originID) == "" || strings.TrimSpace(*destinationID) == "" {
+		fs.Usage()
+		return errors.New("--vehicle, --origin y --destination son requeridos")
+	}
+
+	prev, err := a.buildManifest().Execute(ctx, manifestusecase.BuildManifestPreviewInput{
+		TenantID:            common.tenant,
+		VehicleID:           *vehicleID,
+		OriginOfficeID:      *originID,
+		DestinationOfficeID: *destinationID,
+	})
+	if err != nil {
+		return err
+	}
+
+	view := manifestView{
+		VehicleID:           prev.VehicleID,
+		OriginOfficeID:      prev.OriginOfficeID,
+		DestinationOfficeID: prev.DestinationOfficeID,
+		Parcels:             make([]manifestParcelView, 0, len(prev.Parcels)),
+		Totals:              manifestTotalsView{CountParcels: prev.Totals.CountParcels},
+	}
+	t := cli.Table{Headers: []string{"ID", "ESTADO", "REMITENTE", "DESTINATARIO", "NOTAS"}}
+	for _, p := range prev.Parcels {
+		view.Parcels = append(view.Parcels, manifestParcelView(p))
+		t.Rows = append(t.Rows, []string{p.ParcelID, p.Status, p.SenderPersonID, p.RecipientPersonID, cli.Cell(p.Notes)})
+	}
+	t.Rows = append(t.Rows, []string{"TOTAL", fmt.Sprint(prev.Totals.CountParcels), "", "", ""})
+	return printer.Print(view, t)
+}
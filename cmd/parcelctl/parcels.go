@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/infrastructure/cli"
+	"ms-parcel-core/internal/parcel/parcel_core/domain"
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_core/usecase"
+	"ms-parcel-core/internal/pkg/util/apperror"
+)
+
+const exportPageSize = 200
+
+type parcelDetailView struct {
+	Parcel   parcelView          `json:"parcel"`
+	Items    []itemView          `json:"items"`
+	Payment  *paymentView        `json:"payment,omitempty"`
+	Tracking []trackingEventView `json:"tracking"`
+}
+
+// findParcel: parcels find --tenant T --code QB25XXXXX
+func findParcel(ctx context.Context, a *app, args []string) error {
+	var common commonFlags
+	fs := newFlagSet("parcels find", &common)
+	code := fs.String("code", "", "tracking code (o id del envío)")
+	printer, err := a.parse(fs, &common, args)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(*code) == "" {
+		return errors.New("--code es requerido")
+	}
+
+	// Mismo criterio que el filtro q de GET /parcels: id exacto o tracking code sin distinguir mayúsculas
+	q := strings.TrimSpace(*code)
+	found, err := a.listParcels().Execute(ctx, usecase.ListParcelsInput{TenantID: common.tenant, Filters: port.ListParcelFilters{Query: &q, Limit: 1}})
+	if err != nil {
+		return err
+	}
+	if len(found.Items) == 0 {
+		return apperror.New("not_found", "parcel no encontrado", map[string]any{"code": q}, 404)
+	}
+	parcelID, err := uuid.Parse(found.Items[0].ID)
+	if err != nil {
+		return err
+	}
+
+	summary, err := a.parcelSummary().Execute(ctx, common.tenant, parcelID)
+	if err != nil {
+		return err
+	}
+	// El resumen recorta el tracking; para operaciones interesa la historia completa
+	events, err := a.listTracking().Execute(ctx, common.tenant, parcelID.String())
+	if err != nil {
+		return err
+	}
+
+	p := found.Items[0]
+	view := parcelDetailView{
+		Parcel:   toParcelView(p),
+		Items:    toItemViews(summary.Items),
+		Payment:  toPaymentView(summary.Payment),
+		Tracking: toTrackingViews(events),
+	}
+
+	t := cli.Table{Headers: []string{"CAMPO", "VALOR"}, Rows: [][]string{
+		{"id", p.ID},
+		{"tracking_code", p.TrackingCode},
+		{"status", string(p.Status)},
+		{"shipment_type", string(p.ShipmentType)},
+		{"origin_office_id", p.OriginOfficeID},
+		{"destination_office_id", p.DestinationOfficeID},
+		{"created_at", cli.TimeCell(&p.CreatedAt)},
+		{"boarded_vehicle_id", cli.Cell(p.BoardedVehicleID)},
+		{"items", fmt.Sprint(len(summary.Items))},
+	}}
+	if pay := summary.Payment; pay != nil {
+		t.Rows = append(t.Rows,
+			[]string{"payment", fmt.Sprintf("%s %s %s (pagado %s, saldo %s)", pay.Status, pay.Currency, amount(pay.Amount), amount(pay.PaidAmount), amount(pay.Balance))},
+		)
+	} else {
+		t.Rows = append(t.Rows, []string{"payment", "-"})
+	}
+	for _, ev := range events {
+		t.Rows = append(t.Rows, []string{"tracking", fmt.Sprintf("%s  %s  %s  %s", cli.TimeCell(&ev.OccurredAt), ev.EventType, ev.Source, ev.UserID)})
+	}
+	return printer.Print(view, t)
+}
+
+type transitionUseCases struct {
+	register *usecase.RegisterParcelUseCase
+	board    *usecase.BoardParcelUseCase
+	depart   *usecase.DepartParcelUseCase
+	arrive   *usecase.ArriveParcelUseCase
+	deliver  *usecase.DeliverParcelUseCase
+}
+
+// replayTransition: parcels replay --tenant T --id ID --transition board --user U --vehicle-id V
+// Ejecuta el mismo caso de uso que el endpoint (validaciones de estado y evento de tracking incluidos),
+// p.ej. cuando el cliente no recibió la respuesta o la operación falló a mitad de camino.
+func replayTransition(ctx context.Context, a *app, args []string) error {
+	var common commonFlags
+	fs := newFlagSet("parcels replay", &common)
+	id := fs.String("id", "", "id del envío")
+	transition := fs.String("transition", "", "register|board|depart|arrive|deliver")
+	userID := fs.String("user", "", "user_id que queda registrado en la transición (requerido)")
+	userName := fs.String("user-name", "parcelctl", "nombre del usuario para el tracking")
+	vehicleID := fs.String("vehicle-id", "", "vehículo (board requerido, depart opcional)")
+	tripID := fs.String("trip-id", "", "viaje (board, opcional)")
+	officeID := fs.String("office-id", "", "oficina de salida (depart) o destino (arrive)")
+	packageKey := fs.String("package-key", "", "clave del paquete (deliver, si el envío la tiene)")
+	printer, err := a.parse(fs, &common, args)
+	if err != nil {
+		return err
+	}
+
+	parcelID, err := uuid.Parse(strings.TrimSpace(*id))
+	if err != nil {
+		return errors.New("--id inválido")
+	}
+	if strings.TrimSpace(*userID) == "" {
+		return errors.New("--user es requerido")
+	}
+	vehicle, err := optionalUUID("vehicle-id", *vehicleID)
+	if err != nil {
+		return err
+	}
+	trip, err := optionalUUID("trip-id", *tripID)
+	if err != nil {
+		return err
+	}
+
+	uc := a.transitions()
+	var updated *domain.Parcel
+	switch strings.ToLower(strings.TrimSpace(*transition)) {
+	case "register":
+		updated, err = uc.register.Execute(ctx, usecase.RegisterParcelInput{TenantID: common.tenant, UserID: *userID, UserName: *userName, ParcelID: parcelID})
+	case "board":
+		if vehicle == nil {
+			return errors.New("--vehicle-id es requerido para board")
+		}
+		updated, err = uc.board.Execute(ctx, usecase.BoardParcelInput{TenantID: common.tenant, UserID: *userID, UserName: *userName, ParcelID: parcelID, VehicleID: *vehicle, TripID: trip})
+	case "depart":
+		updated, err = uc.depart.Execute(ctx, usecase.DepartParcelInput{TenantID: common.tenant, UserID: *userID, UserName: *userName, ParcelID: parcelID, DepartureOfficeID: *officeID, VehicleID: vehicle})
+	case "arrive":
+		updated, err = uc.arrive.Execute(ctx, usecase.ArriveParcelInput{TenantID: common.tenant, UserID: *userID, UserName: *userName, ParcelID: parcelID, DestinationOfficeID: *officeID})
+	case "deliver":
+		updated, err = uc.deliver.Execute(ctx, usecase.DeliverParcelInput{TenantID: common.tenant, UserID: *userID, UserName: *userName, ParcelID: parcelID, PackageKey: *packageKey})
+	default:
+		fs.Usage()
+		return errors.New("--transition debe ser register, board, depart, arrive o deliver")
+	}
+	if err != nil {
+		return err
+	}
+
+	return printer.Print(toParcelView(*updated), cli.Table{Headers: parcelTableHeaders, Rows: [][]string{parcelRow(*updated)}})
+}
+
+// exportParcels: parcels export --tenant T --from 2025-01-01 --to 2025-01-31 [--status S]
+func exportParcels(ctx context.Context, a *app, args []string) error {
+	var common commonFlags
+	fs := newFlagSet("parcels export", &common)
+	from := fs.String("from", "", "desde (YYYY-MM-DD o RFC3339, por created_at)")
+	to := fs.String("to", "", "hasta inclusive (YYYY-MM-DD o RFC3339)")
+	status := fs.String("status", "", "filtra por estado")
+	printer, err := a.parse(fs, &common, args)
+	if err != nil {
+		return err
+	}
+
+	filters, err := dateRangeFilters(*from, *to)
+	if err != nil {
+		return err
+	}
+	if s := strings.TrimSpace(*status); s != "" {
+		st := domain.ParcelStatus(strings.ToUpper(s))
+		filters.Status = &st
+	}
+
+	parcels, err := a.collectParcels(ctx, common.tenant, filters)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d envíos\n", len(parcels))
+
+	views := make([]parcelView, 0, len(parcels))
+	t := cli.Table{Headers: parcelTableHeaders}
+	for _, p := range parcels {
+		views = append(views, toParcelView(p))
+		t.Rows = append(t.Rows, parcelRow(p))
+	}
+	return printer.Print(views, t)
+}
+
+// collectParcels recorre todas las páginas de ListParcels (orden created_at desc).
+func (a *app) collectParcels(ctx context.Context, tenantID string, filters port.ListParcelFilters) ([]domain.Parcel, error) {
+	list := a.listParcels()
+	out := make([]domain.Parcel, 0)
+	filters.Limit = exportPageSize
+	for filters.Offset = 0; ; filters.Offset += exportPageSize {
+		page, err := list.Execute(ctx, usecase.ListParcelsInput{TenantID: tenantID, Filters: filters})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, page.Items...)
+		if len(page.Items) < exportPageSize || len(out) >= page.Count {
+			return out, nil
+		}
+	}
+}
+
+func dateRangeFilters(from, to string) (port.ListParcelFilters, error) {
+	var f port.ListParcelFilters
+	fromAt, err := parseDay("from", from, false)
+	if err != nil {
+		return f, err
+	}
+	toAt, err := parseDay("to", to, true)
+	if err != nil {
+		return f, err
+	}
+	if fromAt != nil && toAt != nil && toAt.Before(*fromAt) {
+		return f, errors.New("--to es anterior a --from")
+	}
+	f.FromCreatedAt = fromAt
+	f.ToCreatedAt = toAt
+	return f, nil
+}
+
+func optionalUUID(name, v string) (*uuid.UUID, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(v)
+	if err != nil {
+		return nil, fmt.Errorf("--%s inválido", name)
+	}
+	return &id, nil
+}
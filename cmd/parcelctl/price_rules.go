@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ms-parcel-core/internal/infrastructure/cli"
+	"ms-parcel-core/internal/parcel/parcel_pricing/infrastructure/pricetable"
+	pricingusecase "ms-parcel-core/internal/parcel/parcel_pricing/usecase"
+)
+
+// seedPriceRules: price-rules seed --tenant T --file reglas.csv [--dry-run]
+// Usa el mismo formato y la misma semántica (upsert por ruta y tipo) que POST /price-rules/import.
+func seedPriceRules(ctx context.Context, a *app, args []string) error {
+	var common commonFlags
+	fs := newFlagSet("price-rules seed", &common)
+	file := fs.String("file", "", "archivo .csv o .xlsx con las columnas de GET /price-rules/export")
+	dryRun := fs.Bool("dry-run", false, "valida y muestra el resultado sin escribir")
+	printer, err := a.parse(fs, &common, args)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(*file) == "" {
+		fs.Usage()
+		return errors.New("--file es requerido")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var records [][]string
+	if strings.EqualFold(filepath.Ext(*file), ".xlsx") {
+		records, err = pricetable.ReadXLSX(f)
+	} else {
+		records, err = pricetable.ReadCSV(f)
+	}
+	if err != nil {
+		return err
+	}
+	rows, err := pricetable.Decode(records)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.importPriceRules().Execute(ctx, pricingusecase.ImportPriceRulesInput{TenantID: common.tenant, DryRun: *dryRun, Rows: rows})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "total %d: %d nuevas, %d actualizadas, %d con error (aplicado: %t)\n", res.Total, res.Created, res.Updated, res.Failed, res.Applied)
+
+	t := cli.Table{Headers: []string{"LINEA", "ACCION", "REGLA", "TIPO", "ORIGEN", "DESTINO", "ERRORES"}}
+	for _, r := range res.Rows {
+		msgs := make([]string, 0, len(r.Errors))
+		for _, e := range r.Errors {
+			msgs = append(msgs, e.Message)
+		}
+		t.Rows = append(t.Rows, []string{fmt.Sprint(r.Line), r.Action, cli.Cell(r.RuleID), r.ShipmentType, r.OriginOfficeID, r.DestinationOfficeID, strings.Join(msgs, "; ")})
+	}
+	if err := printer.Print(res, t); err != nil {
+		return err
+	}
+	if res.Failed > 0 && !res.Applied {
+		return fmt.Errorf("%d filas con error, no se aplicó ningún cambio", res.Failed)
+	}
+	return nil
+}
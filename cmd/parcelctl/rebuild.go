@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/infrastructure/cli"
+	"ms-parcel-core/internal/parcel/parcel_core/domain"
+)
+
+type paymentRebuildView struct {
+	ParcelID     string       `json:"parcel_id"`
+	TrackingCode string       `json:"tracking_code"`
+	Before       *paymentView `json:"before"`
+	After        *paymentView `json:"after"`
+	Changed      bool         `json:"changed"`
+}
+
+// rebuildPayments: rebuild payments --tenant T [--id ID | --from D --to D]
+// Recalcula los campos derivados del pago (monto desde items y saldo/estado desde el ledger) con
+// RecalculateParcelPayment. Los totales del manifiesto no se guardan: se calculan al leer
+// (ver manifest preview), así que no requieren rebuild.
+func rebuildPayments(ctx context.Context, a *app, args []string) error {
+	var common commonFlags
+	fs := newFlagSet("rebuild payments", &common)
+	id := fs.String("id", "", "solo este envío")
+	from := fs.String("from", "", "envíos creados desde (YYYY-MM-DD o RFC3339)")
+	to := fs.String("to", "", "envíos creados hasta inclusive (YYYY-MM-DD o RFC3339)")
+	printer, err := a.parse(fs, &common, args)
+	if err != nil {
+		return err
+	}
+
+	var parcels []domain.Parcel
+	if parcelID, err := optionalUUID("id", *id); err != nil {
+		return err
+	} else if parcelID != nil {
+		p, err := a.repos.Parcels.GetByID(ctx, common.tenant, *parcelID)
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return fmt.Errorf("parcel %s no encontrado", parcelID)
+		}
+		parcels = []domain.Parcel{*p}
+	} else {
+		filters, err := dateRangeFilters(*from, *to)
+		if err != nil {
+			return err
+		}
+		if parcels, err = a.collectParcels(ctx, common.tenant, filters); err != nil {
+			return err
+		}
+	}
+
+	recalc := a.recalculatePayment()
+	views := make([]paymentRebuildView, 0, len(parcels))
+	t := cli.Table{Headers: []string{"ID", "TRACKING", "MONTO", "SALDO", "ESTADO", "STALE", "CAMBIO"}}
+	changed := 0
+	for _, p := range parcels {
+		parcelID, err := uuid.Parse(p.ID)
+		if err != nil {
+			return err
+		}
+		before, err := a.repos.Payments.GetByParcelID(ctx, common.tenant, parcelID)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			continue
+		}
+		after, err := recalc.Execute(ctx, common.tenant, parcelID)
+		if err != nil {
+			return fmt.Errorf("parcel %s: %w", p.ID, err)
+		}
+
+		v := paymentRebuildView{ParcelID: p.ID, TrackingCode: p.TrackingCode, Before: toPaymentView(before), After: toPaymentView(after)}
+		v.Changed = *v.Before != *v.After
+		if v.Changed {
+			changed++
+		}
+		views = append(views, v)
+		t.Rows = append(t.Rows, []string{
+			p.ID, p.TrackingCode,
+			amount(before.Amount) + " -> " + amount(after.Amount),
+			amount(before.Balance) + " -> " + amount(after.Balance),
+			string(before.Status) + " -> " + string(after.Status),
+			fmt.Sprint(after.AmountStale),
+			fmt.Sprint(v.Changed),
+		})
+	}
+	fmt.Fprintf(os.Stderr, "%d envíos, %d pagos recalculados, %d con cambios\n", len(parcels), len(views), changed)
+
+	return printer.Print(views, t)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"ms-parcel-core/internal/infrastructure/cli"
+	"ms-parcel-core/internal/parcel/parcel_core/domain"
+	itemdomain "ms-parcel-core/internal/parcel/parcel_item/domain"
+	paymentdomain "ms-parcel-core/internal/parcel/parcel_payment/domain"
+	trackingdomain "ms-parcel-core/internal/parcel/parcel_tracking/domain"
+)
+
+// Vistas JSON de parcelctl: mismos nombres de campo (snake_case) que las respuestas de la API.
+
+type parcelView struct {
+	ID                  string     `json:"id"`
+	TrackingCode        string     `json:"tracking_code"`
+	Status              string     `json:"status"`
+	ShipmentType        string     `json:"shipment_type"`
+	OriginOfficeID      string     `json:"origin_office_id"`
+	DestinationOfficeID string     `json:"destination_office_id"`
+	SenderPersonID      string     `json:"sender_person_id"`
+	RecipientPersonID   string     `json:"recipient_person_id"`
+	Notes               *string    `json:"notes,omitempty"`
+	CreatedByUserID     string     `json:"created_by_user_id"`
+	CreatedAt           time.Time  `json:"created_at"`
+	RegisteredAt        *time.Time `json:"registered_at,omitempty"`
+	BoardedVehicleID    *string    `json:"boarded_vehicle_id,omitempty"`
+	BoardedTripID       *string    `json:"boarded_trip_id,omitempty"`
+	BoardedAt           *time.Time `json:"boarded_at,omitempty"`
+	DepartedAt          *time.Time `json:"departed_at,omitempty"`
+	ArrivedAt           *time.Time `json:"arrived_at,omitempty"`
+	DeliveredAt         *time.Time `json:"delivered_at,omitempty"`
+}
+
+type itemView struct {
+	ID             string  `json:"id"`
+	Description    string  `json:"description"`
+	Quantity       int     `json:"quantity"`
+	BillableWeight float64 `json:"billable_weight"`
+	LineTotal      float64 `json:"line_total"`
+}
+
+type paymentView struct {
+	PaymentType    string  `json:"payment_type"`
+	Status         string  `json:"status"`
+	Currency       string  `json:"currency"`
+	Amount         float64 `json:"amount"`
+	ItemsAmount    float64 `json:"items_amount"`
+	AmountSource   string  `json:"amount_source"`
+	AmountStale    bool    `json:"amount_stale"`
+	PaidAmount     float64 `json:"paid_amount"`
+	RefundedAmount float64 `json:"refunded_amount"`
+	Balance        float64 `json:"balance"`
+}
+
+type trackingEventView struct {
+	EventType  string         `json:"event_type"`
+	OccurredAt time.Time      `json:"occurred_at"`
+	UserID     string         `json:"user_id"`
+	UserName   string         `json:"user_name,omitempty"`
+	Source     string         `json:"source,omitempty"`
+	OfficeID   *string        `json:"office_id,omitempty"`
+	VehicleID  *string        `json:"vehicle_id,omitempty"`
+	Metadata   map[string]any `json:"metadata,omitempty"`
+}
+
+var parcelTableHeaders = []string{"ID", "TRACKING", "ESTADO", "TIPO", "ORIGEN", "DESTINO", "CREADO"}
+
+func toParcelView(p domain.Parcel) parcelView {
+	return parcelView{
+		ID:                  p.ID,
+		TrackingCode:        p.TrackingCode,
+		Status:              string(p.Status),
+		ShipmentType:        string(p.ShipmentType),
+		OriginOfficeID:      p.OriginOfficeID,
+		DestinationOfficeID: p.DestinationOfficeID,
+		SenderPersonID:      p.SenderPersonID,
+		RecipientPersonID:   p.RecipientPersonID,
+		Notes:               p.Notes,
+		CreatedByUserID:     p.CreatedByUserID,
+		CreatedAt:           p.CreatedAt,
+		RegisteredAt:        p.RegisteredAt,
+		BoardedVehicleID:    p.BoardedVehicleID,
+		BoardedTripID:       p.BoardedTripID,
+		BoardedAt:           p.BoardedAt,
+		DepartedAt:          p.DepartedAt,
+		ArrivedAt:           p.ArrivedAt,
+		DeliveredAt:         p.DeliveredAt,
+	}
+}
+
+func parcelRow(p domain.Parcel) []string {
+	return []string{p.ID, p.TrackingCode, string(p.Status), string(p.ShipmentType), p.OriginOfficeID, p.DestinationOfficeID, cli.TimeCell(&p.CreatedAt)}
+}
+
+func toItemViews(items []itemdomain.ParcelItem) []itemView {
+	out := make([]itemView, 0, len(items))
+	for _, it := range items {
+		out = append(out, itemView{ID: it.ID, Description: it.Description, Quantity: it.Quantity, BillableWeight: it.BillableWeight, LineTotal: it.LineTotal()})
+	}
+	return out
+}
+
+func toPaymentView(p *paymentdomain.ParcelPayment) *paymentView {
+	if p == nil {
+		return nil
+	}
+	return &paymentView{
+		PaymentType:    string(p.PaymentType),
+		Status:         string(p.Status),
+		Currency:       string(p.Currency),
+		Amount:         p.Amount,
+		ItemsAmount:    p.ItemsAmount,
+		AmountSource:   string(p.AmountSource),
+		AmountStale:    p.AmountStale,
+		PaidAmount:     p.PaidAmount,
+		RefundedAmount: p.RefundedAmount,
+		Balance:        p.Balance,
+	}
+}
+
+func toTrackingViews(events []trackingdomain.TrackingEvent) []trackingEventView {
+	out := make([]trackingEventView, 0, len(events))
+	for _, ev := range events {
+		out = append(out, trackingEventView{EventType: ev.EventType, OccurredAt: ev.OccurredAt, UserID: ev.UserID, UserName: ev.UserName, Source: string(ev.Source), OfficeID: ev.OfficeID, VehicleID: ev.VehicleID, Metadata: ev.Metadata})
+	}
+	return out
+}
+
+func amount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
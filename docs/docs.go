@@ -15,6 +15,111 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/cache/tenant-options/invalidate": {
+            "post": {
+                "description": "Descarta las opciones cacheadas de un tenant (o de todos con all=true) para que la próxima consulta vaya a ms-tenant-config. Pensado para llamarse tras un cambio de configuración. Requiere X-Admin-Token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Invalidar cache de opciones de tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token de administración",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Tenant a invalidar",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InvalidateTenantOptionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cache invalidado; devuelve estadísticas",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Validación fallida: payload malformado o tenant faltante",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administración inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Administración deshabilitada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/cache/tenant-options/stats": {
+            "get": {
+                "description": "Devuelve aciertos (hits), aciertos con valor vencido (stale_hits), fallos (misses), refrescos en segundo plano, desalojos y entradas actuales del cache. Requiere X-Admin-Token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Estadísticas del cache de opciones de tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token de administración",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Estadísticas del cache",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administración inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Administración deshabilitada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/manifests/preview": {
             "get": {
                 "security": [
@@ -163,6 +268,64 @@ const docTemplate = `{
                 }
             }
         },
+        "/parcel-options/effective": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve las opciones de envío que aplican al tenant (y a la oficina si se indica office_id), resultado de combinar defaults, configuración del tenant y overrides de la oficina. sources indica de qué nivel sale cada campo (DEFAULT, TENANT, OFFICE) y fallbacks lista los niveles ignorados por no estar disponibles o ser inválidos (tenant_options_unavailable, tenant_options_invalid, office_options_unavailable, office_options_invalid).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ParcelOptions"
+                ],
+                "summary": "Opciones efectivas de envíos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID de la oficina",
+                        "name": "office_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Opciones efectivas",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Validación fallida: office_id inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/parcels": {
             "get": {
                 "security": [
@@ -303,6 +466,18 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header"
                     },
+                    {
+                        "type": "string",
+                        "description": "Origen del evento de tracking: counter, scanner_app o api (default api)",
+                        "name": "X-Event-Source",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posición del dispositivo: lat,lon[,accuracy_m]",
+                        "name": "X-Device-Location",
+                        "in": "header"
+                    },
                     {
                         "description": "Solicitud con datos del envío (shipment_type, offices, personas requeridas)",
                         "name": "payload",
@@ -332,6 +507,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Funcionalidad deshabilitada para el tenant (feature_disabled)",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "409": {
                         "description": "Conflicto: offices o personas no válidas, shipment_type no soportado",
                         "schema": {
@@ -437,6 +618,18 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header"
                     },
+                    {
+                        "type": "string",
+                        "description": "Origen del evento de tracking: counter, scanner_app o api (default api)",
+                        "name": "X-Event-Source",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posición del dispositivo: lat,lon[,accuracy_m]",
+                        "name": "X-Device-Location",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "format": "uuid",
@@ -520,6 +713,18 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header"
                     },
+                    {
+                        "type": "string",
+                        "description": "Origen del evento de tracking: counter, scanner_app o api (default api)",
+                        "name": "X-Event-Source",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posición del dispositivo: lat,lon[,accuracy_m]",
+                        "name": "X-Device-Location",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "format": "uuid",
@@ -585,7 +790,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Transiciona el envío de estado ARRIVED a DELIVERED. Marca la finalización de la cadena de custodia. Requiere confirmación de package_key para seguridad. Captura usuario responsable de la entrega.",
+                "description": "Transiciona el envío de estado ARRIVED a DELIVERED. Marca la finalización de la cadena de custodia. Requiere confirmación de package_key para seguridad. Captura usuario responsable de la entrega. Se rechaza (outstanding_balance) si el pago del envío tiene saldo pendiente.",
                 "consumes": [
                     "application/json"
                 ],
@@ -603,6 +808,18 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header"
                     },
+                    {
+                        "type": "string",
+                        "description": "Origen del evento de tracking: counter, scanner_app o api (default api)",
+                        "name": "X-Event-Source",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posición del dispositivo: lat,lon[,accuracy_m]",
+                        "name": "X-Device-Location",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "format": "uuid",
@@ -647,7 +864,7 @@ const docTemplate = `{
                         }
                     },
                     "409": {
-                        "description": "Conflicto: transición no permitida, package_key inválido o estado incompatible",
+                        "description": "Conflicto: transición no permitida, package_key inválido , estado incompatible o saldo de pago pendiente",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -686,6 +903,18 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header"
                     },
+                    {
+                        "type": "string",
+                        "description": "Origen del evento de tracking: counter, scanner_app o api (default api)",
+                        "name": "X-Event-Source",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posición del dispositivo: lat,lon[,accuracy_m]",
+                        "name": "X-Device-Location",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "format": "uuid",
@@ -795,7 +1024,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Validación fallida: id inválido, payload malformado o tipo de documento no permitido",
+                        "description": "Validación fallida: id inválido, payload malformado, tipo de documento no permitido u office_id fuera del recorrido del envío",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -806,6 +1035,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Funcionalidad deshabilitada para el tenant (feature_disabled)",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Envío no encontrado",
                         "schema": {
@@ -1118,7 +1353,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve los detalles completos del pago registrado para un envío. Incluye tipo de pago, monto, estado (PENDING/PAID), moneda, canal, oficina y datos de caja.",
+                "description": "Devuelve los detalles completos del pago registrado para un envío. Incluye tipo de pago, monto, monto cobrado, saldo, monto devuelto, estado (UNPAID/PARTIAL/PAID/OVERPAID/PARTIALLY_REFUNDED/REFUNDED), moneda, canal, oficina y datos de caja.",
                 "produces": [
                     "application/json"
                 ],
@@ -1181,7 +1416,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Crea o actualiza la información de pago del envío. Incluye tipo de pago, monto, moneda, canal, oficina y datos de caja. Soporta múltiples formas de pago (CASH, FOB, CARD, TRANSFER, EWALLET, FREE, COLLECT_ON_DELIVERY). El estado inicial es PENDING.",
+                "description": "Crea o actualiza la información de pago del envío. Incluye tipo de pago, moneda, canal, oficina y datos de caja. Soporta múltiples formas de pago (CASH, FOB, CARD, TRANSFER, EWALLET, FREE, COLLECT_ON_DELIVERY). El estado inicial es UNPAID (luego PARTIAL, PAID u OVERPAID según los cobros registrados). El monto se calcula como la suma de los items más los recargos (surcharges); enviar amount distinto al calculado es un override manual que requiere AllowManualPrice y manual_amount_reason. Si luego se agregan o eliminan items, el monto AUTO se recalcula y el manual queda marcado amount_stale.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1208,7 +1443,7 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Solicitud con datos de pago (monto requerido, type de pago, moneda por defecto PEN)",
+                        "description": "Solicitud con datos de pago (type de pago, moneda por defecto PEN, amount opcional)",
                         "name": "payload",
                         "in": "body",
                         "required": true,
@@ -1236,6 +1471,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Funcionalidad deshabilitada para el tenant (feature_disabled)",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Envío no encontrado",
                         "schema": {
@@ -1253,6 +1494,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "No se pudo verificar la caja y el tenant opera en modo fail closed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -1264,7 +1511,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Transiciona el pago a estado PAID. Intégrase con el servicio de caja (CASHBOX) para registrar la transacción y confirmar la recaudación. Captura el user_id del operador que marca el pago.",
+                "description": "Cobra el saldo pendiente en una sola transacción con el medio de pago, canal y caja del pago, dejándolo en estado PAID. Si no hay saldo pendiente no registra nada y devuelve el pago actual. Captura el user_id del operador que marca el pago. Si el pago tiene caja, el cobro se registra como ingreso en ms-cashbox (cashbox_posting_status en el ledger); si el registro falla se reintenta en segundo plano sin duplicar el movimiento.",
                 "produces": [
                     "application/json"
                 ],
@@ -1307,6 +1554,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Funcionalidad deshabilitada para el tenant (feature_disabled)",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Envío o pago no encontrado",
                         "schema": {
@@ -1328,21 +1581,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/parcels/{id}/register": {
+        "/parcels/{id}/payment/refunds": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Transiciona el envío de estado CREATED a REGISTERED. Marca el envío como listo para ser embarcado en un vehículo. Requiere confirmación de package_key si fue definido al crear.",
+                "description": "Devuelve total o parcialmente lo cobrado del envío. Requiere motivo, un aprobador distinto del operador y una caja abierta (si la caja no se puede verificar se rechaza). Sin amount se devuelve todo lo cobrado. El pago queda REFUNDED si no queda nada cobrado, PARTIALLY_REFUNDED si vuelve a tener saldo pendiente o PAID si solo se devolvió el vuelto, y se registra el evento PAYMENT_REFUNDED en el tracking. La devolución se registra como egreso (REFUND) en ms-cashbox.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Parcels"
+                    "ParcelPayments"
                 ],
-                "summary": "Registrar envío",
+                "summary": "Registrar devolución",
                 "parameters": [
                     {
                         "type": "string",
@@ -1357,17 +1613,26 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Datos de la devolución",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RefundParcelPaymentRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Envío registrado exitosamente (estado: REGISTERED)",
+                    "201": {
+                        "description": "Devolución registrada; devuelve transacción y pago actualizado",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateParcelResponseEnvelope"
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
                         }
                     },
                     "400": {
-                        "description": "Validación fallida: id inválido",
+                        "description": "Validación fallida: id inválido, payload malformado, motivo o aprobador faltante",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1378,14 +1643,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Funcionalidad deshabilitada para el tenant (feature_disabled)",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Envío no encontrado",
+                        "description": "Pago no encontrado",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Conflicto: transición de estado no permitida (estado actual no es CREATED)",
+                        "description": "Conflicto: caja cerrada, nada cobrado o monto mayor a lo cobrado",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1395,25 +1666,31 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "No se pudo verificar la caja",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/parcels/{id}/summary": {
+        "/parcels/{id}/payment/transactions": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve una vista consolidada 360° con detalles del envío (parcel), artículos (items), información de pago (payment) e historial de tracking (últimas 20 operaciones). Ideal para dashboards y seguimiento en tiempo real.",
+                "description": "Devuelve el ledger de transacciones del pago del envío en orden de registro, con el estado de registro en caja de cada una (cashbox_posting_status: PENDING, POSTING, POSTED, FAILED) y el ID del movimiento en ms-cashbox.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Parcels"
+                    "ParcelPayments"
                 ],
-                "summary": "Resumen operativo completo del envío",
+                "summary": "Listar cobros del envío",
                 "parameters": [
                     {
                         "type": "string",
@@ -1432,7 +1709,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Resumen del envío con todos los detalles",
+                        "description": "Transacciones del pago",
                         "schema": {
                             "$ref": "#/definitions/handler.AnyDataEnvelope"
                         }
@@ -1449,12 +1726,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Envío no encontrado",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
@@ -1462,23 +1733,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/parcels/{id}/tracking": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Lista todos los eventos y cambios de estado registrados para un envío en orden cronológico. Incluye tipo de evento, timestamp, usuario responsable y metadata adicional. Útil para auditoría, debugging y reconstrucción de la cadena de custodia.",
+                "description": "Registra una transacción de cobro en el ledger del pago del envío (medio de pago, canal, monto, caja). Permite pagos divididos: parte en origen y el resto en destino, o con varios medios. El estado del pago se recalcula: UNPAID, PARTIAL, PAID u OVERPAID. Cobrar después del registro en origen requiere AllowPayInDestination. La entrega se bloquea mientras haya saldo pendiente. Los cobros con caja se registran como ingreso en ms-cashbox y se reintentan si falla.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ParcelTracking"
+                    "ParcelPayments"
                 ],
-                "summary": "Listar historial completo de tracking del envío",
+                "summary": "Registrar cobro (parcial o total)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1493,11 +1765,1134 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Datos del cobro",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AddPaymentTransactionRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Historial de tracking del envío",
+                    "201": {
+                        "description": "Cobro registrado; devuelve transacción y pago actualizado",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Validación fallida: id inválido, payload malformado o valores inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Funcionalidad deshabilitada para el tenant (feature_disabled)",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Envío o pago no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflicto: caja cerrada, pago en destino deshabilitado o pago FREE",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "No se pudo verificar la caja y el tenant opera en modo fail closed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/parcels/{id}/payment/transactions/{txId}/void": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Anula un cobro (CHARGE) registrado por error agregando una transacción VOID por el mismo monto. Requiere motivo y un aprobador distinto del operador; si el cobro se hizo en una caja, esta debe estar abierta. No se puede anular un cobro ya anulado ni si las devoluciones quedarían por encima de lo cobrado. Registra el evento PAYMENT_VOIDED en el tracking.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ParcelPayments"
+                ],
+                "summary": "Anular cobro",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID del envío",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID de la transacción de cobro",
+                        "name": "txId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motivo y aprobador",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.VoidPaymentTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Anulación registrada; devuelve transacción y pago actualizado",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Validación fallida: ids inválidos, motivo o aprobador faltante",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Funcionalidad deshabilitada para el tenant (feature_disabled)",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pago o transacción no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflicto: no es un cobro, ya anulado, caja cerrada o devoluciones superarían lo cobrado",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "No se pudo verificar la caja",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/parcels/{id}/register": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Transiciona el envío de estado CREATED a REGISTERED. Marca el envío como listo para ser embarcado en un vehículo. Requiere confirmación de package_key si fue definido al crear.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Parcels"
+                ],
+                "summary": "Registrar envío",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Origen del evento de tracking: counter, scanner_app o api (default api)",
+                        "name": "X-Event-Source",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posición del dispositivo: lat,lon[,accuracy_m]",
+                        "name": "X-Device-Location",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID del envío",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Envío registrado exitosamente (estado: REGISTERED)",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateParcelResponseEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Validación fallida: id inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Envío no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflicto: transición de estado no permitida (estado actual no es CREATED)",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/parcels/{id}/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve una vista consolidada 360° con detalles del envío (parcel), artículos (items), información de pago (payment) e historial de tracking (últimas 20 operaciones). Ideal para dashboards y seguimiento en tiempo real.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Parcels"
+                ],
+                "summary": "Resumen operativo completo del envío",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID del envío",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Resumen del envío con todos los detalles",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Validación fallida: id inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Envío no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/parcels/{id}/tracking": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista todos los eventos y cambios de estado registrados para un envío en orden cronológico. Incluye tipo de evento, timestamp, usuario responsable y metadata adicional. Útil para auditoría, debugging y reconstrucción de la cadena de custodia.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ParcelTracking"
+                ],
+                "summary": "Listar historial completo de tracking del envío",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID del envío",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Historial de tracking del envío",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Validación fallida: id inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Envío no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing/rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista todas las reglas de precios activas del tenant actual. Incluye reglas específicas y comodines. Útil para auditoría, depuración y validación de cadenas de precios.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pricing"
+                ],
+                "summary": "Listar reglas de precios",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lista de reglas de precios del tenant",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crea una nueva regla de precios para el tenant. Soporta comodines (*) en ShipmentType, OriginOfficeID y DestinationOfficeID. La prioridad (0-100) determina el orden de evaluación en búsquedas jerárquicas: específicas primero, luego comodines. Ejemplos: \"STANDARD\", \"*\" para cualquier tipo; \"12345\" (UUID), \"*\" para cualquier oficina.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pricing"
+                ],
+                "summary": "Crear regla de precios",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Solicitud de creación de regla con campos de envío, precio y prioridad",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PriceRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Regla de precios creada exitosamente",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Validación fallida: payload malformado, valores inválidos o formato incorrecto",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflicto: regla duplicada o combinación de parámetros duplicada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing/rules/analysis": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Evalúa todas las rutas origen-\u003edestino entre las oficinas presentes en las reglas (más office_ids opcionales) por tipo de envío. Reporta empates ambiguos (mismo puntaje y priority), reglas activas inalcanzables (SHADOWED: siempre superadas por otra; NO_ROUTE: no aplican a ninguna ruta evaluada) y rutas sin ninguna regla que las cubra.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pricing"
+                ],
+                "summary": "Analizar conflictos de la tabla de precios",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Oficinas adicionales a evaluar, separadas por coma",
+                        "name": "office_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipos de envío a evaluar, separados por coma (default: los presentes en reglas)",
+                        "name": "shipment_types",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reporte de análisis",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing/rules/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exporta las reglas de precios activas del tenant en CSV (default) o XLSX, con las mismas columnas que acepta POST /pricing/rules/import.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Pricing"
+                ],
+                "summary": "Exportar tabla de precios activa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Formato de salida (csv, xlsx)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Archivo con la tabla de precios activa",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Formato no soportado",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing/rules/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Importa reglas de precios de forma masiva desde un archivo CSV o XLSX (campo multipart \"file\" o body crudo). Columnas: shipment_type, origin_office_id, destination_office_id, unit, price, currency, priority (opcional, default 0), active (opcional, default true). Cada fila se valida con las mismas reglas que POST /pricing/rules. Upsert por clave shipment_type + origin_office_id + destination_office_id. Con dry_run=true solo devuelve el reporte por fila sin persistir. Si alguna fila es inválida no se aplica ningún cambio.",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pricing"
+                ],
+                "summary": "Importar reglas de precios (CSV/XLSX)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Archivo CSV o XLSX",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Solo validar, sin persistir (default: false)",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Formato del archivo si no se puede inferir (csv, xlsx)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reporte de importación por fila",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Archivo inválido o filas con errores (details contiene el reporte)",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing/rules/simulate": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Para un tipo de envío y una ruta origen/destino devuelve todas las reglas candidatas con su puntaje de especificidad (exacto=10, comodín=1 por lado) y la regla ganadora, con el mismo criterio que se usa al cotizar ítems (especificidad y luego priority). ambiguous=true indica empate entre las mejores reglas. Las reglas inactivas que coinciden se listan al final sin poder ganar.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pricing"
+                ],
+                "summary": "Simular selección de regla de precios",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de envío (BUS, CARGUERO)",
+                        "name": "shipment_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Oficina origen",
+                        "name": "origin_office_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Oficina destino",
+                        "name": "destination_office_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Candidatas y regla ganadora",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Parámetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing/rules/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza una regla de precios existente. Permite modificar tipo de envío, oficinas, unidad de precio, precio, moneda y prioridad. Los comodines (*) siguen siendo soportados en campos de rutas. La prioridad define el orden en búsquedas jerárquicas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pricing"
+                ],
+                "summary": "Actualizar regla de precios",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID de la regla",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Solicitud de actualización con nuevos valores",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PriceRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Regla de precios actualizada exitosamente",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Validación fallida: id inválido, payload malformado o valores inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Regla no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflicto: nueva combinación duplicada u estado incompatible",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/track/{code}": {
+            "get": {
+                "description": "Endpoint sin autenticación para que el remitente o destinatario consulte su envío. Devuelve estado, oficinas de origen/destino y la línea de tiempo (sin usuarios, ids internos ni metadata). Con los últimos 4 dígitos del documento del destinatario (header X-Recipient-Document-Last4 o query document_last4) agrega detalle: tipo de envío, salida, items, peso y saldo. Limitado por IP; los intentos fallidos de verificación se limitan por tracking code.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Seguimiento público por tracking code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tracking code (p.ej. QBA7K2MX)",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Últimos 4 dígitos del documento del destinatario",
+                        "name": "X-Recipient-Document-Last4",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternativa al header X-Recipient-Document-Last4",
+                        "name": "document_last4",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Seguimiento del envío",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicTrackingEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "document_last4 inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "No se pudo verificar al destinatario",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Envío no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Demasiadas solicitudes o intentos de verificación",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "No se pudo consultar el documento del destinatario",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tracking/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events con los eventos de tracking del tenant a medida que se registran. Filtros opcionales por envío, oficina (origen/destino del envío u oficina del evento) o vehículo. Cada evento lleva ` + "`" + `id: \u003cseq\u003e` + "`" + `; al reconectar, el header Last-Event-ID (o el query last_event_id) reenvía lo ocurrido desde ese id. Sin Last-Event-ID empieza desde el evento más reciente. Envía un comentario ` + "`" + `: ping` + "`" + ` en cada heartbeat.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "ParcelTracking"
+                ],
+                "summary": "Stream de eventos de tracking (SSE)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seq del último evento recibido",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternativa al header Last-Event-ID",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID del envío",
+                        "name": "parcel_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID de la oficina",
+                        "name": "office_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID del vehículo",
+                        "name": "vehicle_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream text/event-stream con eventos ` + "`" + `tracking` + "`" + `",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Validación fallida: filtro o Last-Event-ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista las suscripciones de webhook del tenant. El secreto no se incluye (solo secret_hint).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Listar suscripciones de webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Suscripciones del tenant",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra un endpoint del tenant que recibe los eventos de tracking de sus envíos (PARCEL_CREATED … PARCEL_DELIVERED, PARCEL_ITEM_ADDED/REMOVED). event_types vacío = todos. Cada entrega es un POST JSON firmado en el header X-Webhook-Signature (\"t=\u003cunix\u003e,v1=\u003chex\u003e\", HMAC-SHA256 del secreto sobre \"\u003cunix\u003e.\u003cbody\u003e\"). El secreto se devuelve solo en esta respuesta.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Crear suscripción de webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "URL, tipos de evento y estado de la suscripción",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Suscripción creada (incluye secret)",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Validación fallida: url inválida (debe ser https hacia un host público) o tipo de evento no soportado",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Obtener suscripción de webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID de la suscripción",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Suscripción",
                         "schema": {
                             "$ref": "#/definitions/handler.AnyDataEnvelope"
                         }
@@ -1515,7 +2910,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Envío no encontrado",
+                        "description": "Suscripción no encontrada",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1527,44 +2922,62 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/pricing/rules": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Lista todas las reglas de precios activas del tenant actual. Incluye reglas específicas y comodines. Útil para auditoría, depuración y validación de cadenas de precios.",
+                "description": "Elimina la suscripción. Las entregas pendientes pasan a DEAD; el log de entregas se conserva.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pricing"
+                    "Webhooks"
                 ],
-                "summary": "Listar reglas de precios",
+                "summary": "Eliminar suscripción de webhook",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bearer token",
                         "name": "Authorization",
                         "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID de la suscripción",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Lista de reglas de precios del tenant",
+                        "description": "Suscripción eliminada",
                         "schema": {
                             "$ref": "#/definitions/handler.AnyDataEnvelope"
                         }
                     },
+                    "400": {
+                        "description": "Validación fallida: id inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "No autorizado: token inválido o credenciales faltantes",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Suscripción no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
@@ -1573,13 +2986,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Crea una nueva regla de precios para el tenant. Soporta comodines (*) en ShipmentType, OriginOfficeID y DestinationOfficeID. La prioridad (0-100) determina el orden de evaluación en búsquedas jerárquicas: específicas primero, luego comodines. Ejemplos: \"STANDARD\", \"*\" para cualquier tipo; \"12345\" (UUID), \"*\" para cualquier oficina.",
+                "description": "Actualización parcial: url, event_types, active y description. Con rotate_secret=true se genera un secreto nuevo, que se devuelve solo en esta respuesta; las entregas pendientes se firman con el secreto nuevo.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1587,9 +3000,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pricing"
+                    "Webhooks"
                 ],
-                "summary": "Crear regla de precios",
+                "summary": "Actualizar suscripción de webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -1598,24 +3011,32 @@ const docTemplate = `{
                         "in": "header"
                     },
                     {
-                        "description": "Solicitud de creación de regla con campos de envío, precio y prioridad",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID de la suscripción",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos a modificar",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.PriceRuleRequest"
+                            "$ref": "#/definitions/handler.UpdateWebhookRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Regla de precios creada exitosamente",
+                        "description": "Suscripción actualizada",
                         "schema": {
                             "$ref": "#/definitions/handler.AnyDataEnvelope"
                         }
                     },
                     "400": {
-                        "description": "Validación fallida: payload malformado, valores inválidos o formato incorrecto",
+                        "description": "Validación fallida: id, url (debe ser https hacia un host público) o tipo de evento inválido",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1626,8 +3047,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflicto: regla duplicada o combinación de parámetros duplicada",
+                    "404": {
+                        "description": "Suscripción no encontrada",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1641,24 +3062,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/pricing/rules/{id}": {
-            "put": {
+        "/webhooks/{id}/deliveries": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Actualiza una regla de precios existente. Permite modificar tipo de envío, oficinas, unidad de precio, precio, moneda y prioridad. Los comodines (*) siguen siendo soportados en campos de rutas. La prioridad define el orden en búsquedas jerárquicas.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lista las entregas de la suscripción (más recientes primero) con estado, intentos, último status HTTP y error, y el payload enviado.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pricing"
+                    "Webhooks"
                 ],
-                "summary": "Actualizar regla de precios",
+                "summary": "Log de entregas de un webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -1669,30 +3087,125 @@ const docTemplate = `{
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "UUID de la regla",
+                        "description": "UUID de la suscripción",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Solicitud de actualización con nuevos valores",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
+                        "enum": [
+                            "PENDING",
+                            "DELIVERING",
+                            "DELIVERED",
+                            "DEAD"
+                        ],
+                        "type": "string",
+                        "description": "Filtra por estado",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Filtra por envío",
+                        "name": "parcel_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Límite de resultados (default: 50, max: 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Desplazamiento (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Entregas paginadas",
                         "schema": {
-                            "$ref": "#/definitions/handler.PriceRuleRequest"
+                            "$ref": "#/definitions/handler.AnyDataEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Validación fallida: id, status, parcel_id, limit u offset inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado: token inválido o credenciales faltantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Suscripción no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery_id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reencola una entrega en estado DEAD con el ciclo completo de reintentos. La suscripción debe estar activa.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Reintentar entrega de webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID de la suscripción",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "UUID de la entrega",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Regla de precios actualizada exitosamente",
+                        "description": "Entrega reencolada (estado: PENDING)",
                         "schema": {
                             "$ref": "#/definitions/handler.AnyDataEnvelope"
                         }
                     },
                     "400": {
-                        "description": "Validación fallida: id inválido, payload malformado o valores inválidos",
+                        "description": "Validación fallida: id o delivery_id inválido",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1704,13 +3217,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Regla no encontrada",
+                        "description": "Suscripción o entrega no encontrada",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Conflicto: nueva combinación duplicada u estado incompatible",
+                        "description": "La entrega no está en DEAD o la suscripción está inactiva",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1735,6 +3248,10 @@ const docTemplate = `{
                 "details": {},
                 "message": {
                     "type": "string"
+                },
+                "request_id": {
+                    "description": "RequestID correlates the response with server logs; set when the error is rendered.",
+                    "type": "string"
                 }
             }
         },
@@ -1870,6 +3387,9 @@ const docTemplate = `{
                 },
                 "status": {
                     "type": "string"
+                },
+                "tracking_code": {
+                    "type": "string"
                 }
             }
         },
@@ -1891,36 +3411,87 @@ const docTemplate = `{
                 "departure_office_id": {
                     "type": "string"
                 },
-                "vehicle_id": {
+                "vehicle_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ParcelListPagination": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ParcelListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CreateParcelResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.ParcelListPagination"
+                }
+            }
+        },
+        "handler.AddPaymentTransactionRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "payment_type"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "cashbox_id": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "channel": {
+                    "type": "string",
+                    "enum": [
+                        "COUNTER",
+                        "WEB"
+                    ]
+                },
+                "currency": {
+                    "type": "string",
+                    "enum": [
+                        "PEN",
+                        "USD"
+                    ]
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "office_id": {
                     "type": "string"
-                }
-            }
-        },
-        "dto.ParcelListPagination": {
-            "type": "object",
-            "properties": {
-                "count": {
-                    "type": "integer"
-                },
-                "limit": {
-                    "type": "integer"
                 },
-                "offset": {
-                    "type": "integer"
-                }
-            }
-        },
-        "dto.ParcelListResponse": {
-            "type": "object",
-            "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.CreateParcelResponse"
-                    }
+                "payment_type": {
+                    "type": "string",
+                    "enum": [
+                        "CASH",
+                        "FOB",
+                        "CARD",
+                        "TRANSFER",
+                        "EWALLET",
+                        "COLLECT_ON_DELIVERY"
+                    ]
                 },
-                "pagination": {
-                    "$ref": "#/definitions/dto.ParcelListPagination"
+                "seller_user_id": {
+                    "type": "string"
                 }
             }
         },
@@ -1998,6 +3569,31 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
         "handler.ErrorResponse": {
             "type": "object",
             "properties": {
@@ -2010,6 +3606,19 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.InvalidateTenantOptionsRequest": {
+            "type": "object",
+            "properties": {
+                "all": {
+                    "type": "boolean"
+                },
+                "tenant_id": {
+                    "description": "Sin tenant_id se usa el tenant del token; all=true vacía el cache completo",
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
         "handler.ManifestPreviewRequest": {
             "type": "object",
             "required": [
@@ -2041,6 +3650,26 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.PaymentSurchargeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 200
+                }
+            }
+        },
         "handler.PriceRuleRequest": {
             "type": "object",
             "required": [
@@ -2088,6 +3717,164 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.PublicTrackingBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.PublicTrackingDetailResponse": {
+            "type": "object",
+            "properties": {
+                "delivered_at": {
+                    "type": "string"
+                },
+                "departure_at": {
+                    "type": "string"
+                },
+                "items_count": {
+                    "type": "integer"
+                },
+                "payment": {
+                    "$ref": "#/definitions/handler.PublicTrackingBalanceResponse"
+                },
+                "shipment_type": {
+                    "type": "string"
+                },
+                "total_weight_kg": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.PublicTrackingEnvelope": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.PublicTrackingResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "handler.PublicTrackingEventResponse": {
+            "type": "object",
+            "properties": {
+                "event_type": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "office": {
+                    "$ref": "#/definitions/handler.PublicTrackingOfficeResponse"
+                }
+            }
+        },
+        "handler.PublicTrackingOfficeResponse": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.PublicTrackingResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "destination": {
+                    "$ref": "#/definitions/handler.PublicTrackingOfficeResponse"
+                },
+                "detail": {
+                    "$ref": "#/definitions/handler.PublicTrackingDetailResponse"
+                },
+                "origin": {
+                    "$ref": "#/definitions/handler.PublicTrackingOfficeResponse"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timeline": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.PublicTrackingEventResponse"
+                    }
+                },
+                "tracking_code": {
+                    "type": "string"
+                },
+                "verified": {
+                    "description": "Verified indica si se validó el documento del destinatario; solo entonces se incluye Detail",
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.RefundParcelPaymentRequest": {
+            "type": "object",
+            "required": [
+                "approved_by_user_id",
+                "cashbox_id",
+                "reason"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "Sin amount se devuelve todo lo cobrado",
+                    "type": "number"
+                },
+                "approved_by_user_id": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "cashbox_id": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "channel": {
+                    "type": "string",
+                    "enum": [
+                        "COUNTER",
+                        "WEB"
+                    ]
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "office_id": {
+                    "type": "string"
+                },
+                "payment_type": {
+                    "type": "string",
+                    "enum": [
+                        "CASH",
+                        "FOB",
+                        "CARD",
+                        "TRANSFER",
+                        "EWALLET",
+                        "COLLECT_ON_DELIVERY"
+                    ]
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 200
+                }
+            }
+        },
         "handler.RegisterPrintRequest": {
             "type": "object",
             "required": [
@@ -2102,18 +3889,47 @@ const docTemplate = `{
                         "MANIFEST",
                         "GUIDE"
                     ]
+                },
+                "office_id": {
+                    "description": "OfficeID: oficina que imprime (define límites de impresión), origen o destino del envío; por defecto la de origen",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UpdateWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rotate_secret": {
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 500
                 }
             }
         },
         "handler.UpsertParcelPaymentRequest": {
             "type": "object",
             "required": [
-                "amount",
                 "payment_type"
             ],
             "properties": {
                 "amount": {
-                    "type": "number"
+                    "type": "number",
+                    "minimum": 0
                 },
                 "cashbox_id": {
                     "type": "string",
@@ -2133,6 +3949,11 @@ const docTemplate = `{
                         "USD"
                     ]
                 },
+                "manual_amount_reason": {
+                    "description": "Motivo obligatorio si amount difiere del monto calculado (items + recargos)",
+                    "type": "string",
+                    "maxLength": 200
+                },
                 "notes": {
                     "type": "string",
                     "maxLength": 200
@@ -2154,6 +3975,30 @@ const docTemplate = `{
                 },
                 "seller_user_id": {
                     "type": "string"
+                },
+                "surcharges": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/handler.PaymentSurchargeRequest"
+                    }
+                }
+            }
+        },
+        "handler.VoidPaymentTransactionRequest": {
+            "type": "object",
+            "required": [
+                "approved_by_user_id",
+                "reason"
+            ],
+            "properties": {
+                "approved_by_user_id": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 200
                 }
             }
         }
@@ -1,5 +1,10 @@
 package config
 
+import (
+	"os"
+	"strings"
+)
+
 // DBConfig contiene la configuración de conexión a PostgreSQL
 type DBConfig struct {
 	Host     string
@@ -15,3 +20,23 @@ type Config struct {
 	ServerPort  string
 	Environment string
 }
+
+// DBConfigFromEnv lee DB_HOST, DB_PORT, DB_USER, DB_PASSWORD y DB_NAME.
+// ok es false si DB_HOST no está definido (el servicio corre solo con repositorios en memoria).
+func DBConfigFromEnv() (cfg DBConfig, ok bool) {
+	cfg = DBConfig{
+		Host:     strings.TrimSpace(os.Getenv("DB_HOST")),
+		Port:     envOr("DB_PORT", "5432"),
+		User:     envOr("DB_USER", "postgres"),
+		Password: os.Getenv("DB_PASSWORD"),
+		Name:     envOr("DB_NAME", "parcel_db"),
+	}
+	return cfg, cfg.Host != ""
+}
+
+func envOr(key string, def string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+
+	"ms-parcel-core/internal/infrastructure/persistence/database"
+)
+
+const MigrateUsage = `comandos de migrate:
+  up      [--dry-run]             aplica las migraciones pendientes
+  down    [--steps N] [--dry-run] revierte las últimas N migraciones (default 1)
+  status  [--output table|json]   lista las migraciones y su estado
+
+--dry-run imprime el SQL sin ejecutarlo.`
+
+type MigrateCommand struct {
+	Action string
+	DryRun bool
+	Steps  int
+	Output string
+}
+
+// ParseMigrateArgs valida args (sin el "migrate" inicial) antes de abrir la conexión a la base.
+func ParseMigrateArgs(args []string, stderr io.Writer) (MigrateCommand, error) {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, MigrateUsage)
+		return MigrateCommand{}, errors.New("falta el comando de migrate")
+	}
+	cmd := MigrateCommand{Action: args[0]}
+	switch cmd.Action {
+	case "up", "down", "status":
+	default:
+		fmt.Fprintln(stderr, MigrateUsage)
+		return MigrateCommand{}, fmt.Errorf("comando de migrate desconocido: %s", cmd.Action)
+	}
+
+	fs := flag.NewFlagSet("migrate "+cmd.Action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprintln(stderr, MigrateUsage) }
+	fs.BoolVar(&cmd.DryRun, "dry-run", false, "imprime el SQL sin ejecutarlo")
+	fs.IntVar(&cmd.Steps, "steps", 1, "cantidad de migraciones a revertir (solo down)")
+	fs.StringVar(&cmd.Output, "output", OutputTable, "formato de salida de status: table|json")
+	if err := fs.Parse(args[1:]); err != nil {
+		return MigrateCommand{}, err
+	}
+	if cmd.Steps <= 0 {
+		return MigrateCommand{}, errors.New("--steps debe ser mayor a 0")
+	}
+	return cmd, nil
+}
+
+func RunMigrate(ctx context.Context, m *database.Migrator, cmd MigrateCommand, out io.Writer, logger *slog.Logger) error {
+	switch cmd.Action {
+	case "up":
+		if cmd.DryRun {
+			return m.PlanUp(ctx, out)
+		}
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		logger.Info("migraciones aplicadas", "count", len(applied))
+		return nil
+	case "down":
+		if cmd.DryRun {
+			return m.PlanDown(ctx, out, cmd.Steps)
+		}
+		reverted, err := m.Down(ctx, cmd.Steps)
+		if err != nil {
+			return err
+		}
+		logger.Info("migraciones revertidas", "count", len(reverted))
+		return nil
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printer, err := NewPrinter(out, cmd.Output)
+		if err != nil {
+			return err
+		}
+		t := Table{Headers: []string{"VERSION", "NOMBRE", "ESTADO", "APLICADA"}}
+		for _, s := range status {
+			t.Rows = append(t.Rows, []string{strconv.FormatInt(s.Version, 10), s.Name, s.State, TimeCell(s.AppliedAt)})
+		}
+		return printer.Print(status, t)
+	}
+	return fmt.Errorf("comando de migrate desconocido: %s", cmd.Action)
+}
@@ -0,0 +1,71 @@
+// Package cli reúne lo que comparten los comandos de línea (api migrate y parcelctl):
+// salida en tabla o JSON y el subcomando migrate.
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+// Table es la vista tabular de un resultado; JSON usa el valor original para no perder campos.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+type Printer struct {
+	w      io.Writer
+	format string
+}
+
+func NewPrinter(w io.Writer, format string) (*Printer, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = OutputTable
+	}
+	if format != OutputTable && format != OutputJSON {
+		return nil, fmt.Errorf("formato de salida no soportado: %s (table|json)", format)
+	}
+	return &Printer{w: w, format: format}, nil
+}
+
+// Print escribe v como JSON indentado o t como tabla alineada según el formato elegido.
+func (p *Printer) Print(v any, t Table) error {
+	if p.format == OutputJSON {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.Headers, "\t"))
+	for _, row := range t.Rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// Cell formatea valores opcionales para una celda de tabla ("-" si no hay valor).
+func Cell[T any](v *T) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprint(*v)
+}
+
+// TimeCell formatea instantes en RFC3339 UTC ("-" si no hay valor).
+func TimeCell(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
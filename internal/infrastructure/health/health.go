@@ -0,0 +1,143 @@
+// Package health arma las respuestas de /health/live y /health/ready a partir de los chequeos
+// registrados por el composition root (base de datos, migraciones, servicios externos).
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+
+	CheckUp   = "up"
+	CheckDown = "down"
+)
+
+// Checker verifica una dependencia; nil significa disponible.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapta una función a Checker.
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error { return f(ctx) }
+
+// CheckResult es el estado de una dependencia en el body de /health/ready.
+type CheckResult struct {
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status   string                 `json:"status"`
+	Draining bool                   `json:"draining,omitempty"`
+	Uptime   string                 `json:"uptime,omitempty"`
+	Checks   map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Healthy indica si el reporte debe responder 200 (ok o degraded) o 503.
+func (r Report) Healthy() bool {
+	return r.Status != StatusUnavailable
+}
+
+type dependency struct {
+	name     string
+	checker  Checker
+	critical bool
+}
+
+// Service ejecuta los chequeos en paralelo, cada uno con su propio timeout.
+// Una dependencia crítica caída deja el servicio unavailable (503); una no crítica lo deja degraded (200),
+// p.ej. servicios externos con fallback propio que no justifican sacar la instancia del balanceador.
+type Service struct {
+	timeout time.Duration
+	started time.Time
+
+	mu       sync.RWMutex
+	deps     []dependency
+	draining atomic.Bool
+}
+
+func NewService(timeout time.Duration) *Service {
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	return &Service{timeout: timeout, started: time.Now()}
+}
+
+// Register agrega un chequeo de readiness.
+func (s *Service) Register(name string, checker Checker, critical bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deps = append(s.deps, dependency{name: name, checker: checker, critical: critical})
+}
+
+// SetDraining marca el inicio del apagado: readiness pasa a 503 para que el balanceador deje de enviar tráfico.
+func (s *Service) SetDraining() {
+	s.draining.Store(true)
+}
+
+// Live solo refleja el proceso: fallar por una dependencia externa haría reiniciar instancias sanas.
+func (s *Service) Live(ctx context.Context) Report {
+	_ = ctx
+	return Report{Status: StatusOK, Draining: s.draining.Load(), Uptime: time.Since(s.started).Round(time.Second).String()}
+}
+
+// Ready ejecuta todos los chequeos registrados.
+func (s *Service) Ready(ctx context.Context) Report {
+	s.mu.RLock()
+	deps := append([]dependency(nil), s.deps...)
+	s.mu.RUnlock()
+
+	results := make([]CheckResult, len(deps))
+	var wg sync.WaitGroup
+	for i, d := range deps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = s.run(ctx, d)
+		}()
+	}
+	wg.Wait()
+
+	rep := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(deps))}
+	for i, d := range deps {
+		r := results[i]
+		rep.Checks[d.name] = r
+		if r.Status == CheckUp {
+			continue
+		}
+		if d.critical {
+			rep.Status = StatusUnavailable
+		} else if rep.Status == StatusOK {
+			rep.Status = StatusDegraded
+		}
+	}
+	if s.draining.Load() {
+		rep.Status = StatusUnavailable
+		rep.Draining = true
+	}
+	return rep
+}
+
+func (s *Service) run(ctx context.Context, d dependency) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := d.checker.Check(ctx)
+	res := CheckResult{Status: CheckUp, Critical: d.critical, LatencyMs: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		res.Status = CheckDown
+		res.Error = err.Error()
+	}
+	return res
+}
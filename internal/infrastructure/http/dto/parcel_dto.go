@@ -13,6 +13,7 @@ type CreateParcelRequest struct {
 
 type CreateParcelResponse struct {
 	ID                  string  `json:"id"`
+	TrackingCode        string  `json:"tracking_code,omitempty"`
 	Status              string  `json:"status"`
 	ShipmentType        string  `json:"shipment_type"`
 	OriginOfficeID      string  `json:"origin_office_id"`
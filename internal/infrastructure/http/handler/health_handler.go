@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"ms-parcel-core/internal/infrastructure/health"
+)
+
+// HealthHandler expone las probes de liveness y readiness. El body es el reporte tal cual
+// (sin envelope) para que lo lean directamente los orquestadores y balanceadores.
+type HealthHandler struct {
+	health *health.Service
+}
+
+func NewHealthHandler(h *health.Service) *HealthHandler {
+	return &HealthHandler{health: h}
+}
+
+// Live responde 200 mientras el proceso atienda requests.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, h.health.Live(c.Request.Context()))
+}
+
+// Ready responde 200 (ok o degraded) o 503 con el estado de cada dependencia.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	rep := h.health.Ready(c.Request.Context())
+	status := http.StatusOK
+	if !rep.Healthy() {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, rep)
+}
@@ -16,6 +16,8 @@ import (
 
 type RegisterPrintRequest struct {
 	DocumentType string `json:"document_type" binding:"required,oneof=LABEL RECEIPT MANIFEST GUIDE"`
+	// OfficeID: oficina que imprime (define límites de impresión), origen o destino del envío; por defecto la de origen
+	OfficeID *string `json:"office_id,omitempty"`
 }
 
 type PrintRecordResponse struct {
@@ -51,8 +53,9 @@ func NewParcelDocumentsHandler(registerUC *docusecase.RegisterPrintUseCase, prin
 // @Param id path string true "UUID del envío" Format(uuid)
 // @Param payload body RegisterPrintRequest true "Solicitud de impresión con tipo de documento"
 // @Success 200 {object} handler.AnyDataEnvelope "Impresión registrada exitosamente"
-// @Failure 400 {object} handler.ErrorResponse "Validación fallida: id inválido, payload malformado o tipo de documento no permitido"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: id inválido, payload malformado, tipo de documento no permitido u office_id fuera del recorrido del envío"
 // @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 403 {object} handler.ErrorResponse "Funcionalidad deshabilitada para el tenant (feature_disabled)"
 // @Failure 404 {object} handler.ErrorResponse "Envío no encontrado"
 // @Failure 409 {object} handler.ErrorResponse "Conflicto: estado incompatible o límite de impresiones alcanzado"
 // @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
@@ -90,6 +93,7 @@ func (h *ParcelDocumentsHandler) RegisterPrint(c *gin.Context) {
 		ParcelID: parcelID,
 		DocType:  docdomain.DocumentType(strings.TrimSpace(req.DocumentType)),
 		UserID:   uidPtr,
+		OfficeID: req.OfficeID,
 	})
 	if err != nil {
 		_ = c.Error(err)
@@ -56,10 +56,13 @@ func NewParcelHandler(
 // @Produce json
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer token"
+// @Param X-Event-Source header string false "Origen del evento de tracking: counter, scanner_app o api (default api)"
+// @Param X-Device-Location header string false "Posición del dispositivo: lat,lon[,accuracy_m]"
 // @Param payload body dto.CreateParcelRequest true "Solicitud con datos del envío (shipment_type, offices, personas requeridas)"
 // @Success 201 {object} handler.CreateParcelResponseEnvelope "Envío creado exitosamente en estado CREATED"
 // @Failure 400 {object} handler.ErrorResponse "Validación fallida: payload malformado o valores inválidos"
 // @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 403 {object} handler.ErrorResponse "Funcionalidad deshabilitada para el tenant (feature_disabled)"
 // @Failure 409 {object} handler.ErrorResponse "Conflicto: offices o personas no válidas, shipment_type no soportado"
 // @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
 // @Router /parcels [post]
@@ -305,6 +308,7 @@ func (h *ParcelHandler) List(c *gin.Context) {
 
 		items = append(items, dto.CreateParcelResponse{
 			ID:                  p.ID,
+			TrackingCode:        p.TrackingCode,
 			Status:              string(p.Status),
 			ShipmentType:        string(p.ShipmentType),
 			OriginOfficeID:      p.OriginOfficeID,
@@ -409,6 +413,7 @@ func (h *ParcelHandler) GetByID(c *gin.Context) {
 		"success": true,
 		"data": dto.CreateParcelResponse{
 			ID:                  p.ID,
+			TrackingCode:        p.TrackingCode,
 			Status:              string(p.Status),
 			ShipmentType:        string(p.ShipmentType),
 			OriginOfficeID:      p.OriginOfficeID,
@@ -440,6 +445,8 @@ func (h *ParcelHandler) GetByID(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer token"
+// @Param X-Event-Source header string false "Origen del evento de tracking: counter, scanner_app o api (default api)"
+// @Param X-Device-Location header string false "Posición del dispositivo: lat,lon[,accuracy_m]"
 // @Param id path string true "UUID del envío" Format(uuid)
 // @Success 200 {object} handler.CreateParcelResponseEnvelope "Envío registrado exitosamente (estado: REGISTERED)"
 // @Failure 400 {object} handler.ErrorResponse "Validación fallida: id inválido"
@@ -506,6 +513,7 @@ func (h *ParcelHandler) Register(c *gin.Context) {
 		"success": true,
 		"data": dto.CreateParcelResponse{
 			ID:                  p.ID,
+			TrackingCode:        p.TrackingCode,
 			Status:              string(p.Status),
 			ShipmentType:        string(p.ShipmentType),
 			OriginOfficeID:      p.OriginOfficeID,
@@ -538,6 +546,8 @@ func (h *ParcelHandler) Register(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer token"
+// @Param X-Event-Source header string false "Origen del evento de tracking: counter, scanner_app o api (default api)"
+// @Param X-Device-Location header string false "Posición del dispositivo: lat,lon[,accuracy_m]"
 // @Param id path string true "UUID del envío" Format(uuid)
 // @Param payload body dto.BoardParcelRequest true "Solicitud con UUID de vehículo (requerido), trip_id y departure_at (opcionales)"
 // @Success 200 {object} handler.CreateParcelResponseEnvelope "Envío embarcado exitosamente (estado: BOARDED)"
@@ -649,6 +659,7 @@ func (h *ParcelHandler) Board(c *gin.Context) {
 		"success": true,
 		"data": dto.CreateParcelResponse{
 			ID:                  p.ID,
+			TrackingCode:        p.TrackingCode,
 			Status:              string(p.Status),
 			ShipmentType:        string(p.ShipmentType),
 			OriginOfficeID:      p.OriginOfficeID,
@@ -681,6 +692,8 @@ func (h *ParcelHandler) Board(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer token"
+// @Param X-Event-Source header string false "Origen del evento de tracking: counter, scanner_app o api (default api)"
+// @Param X-Device-Location header string false "Posición del dispositivo: lat,lon[,accuracy_m]"
 // @Param id path string true "UUID del envío" Format(uuid)
 // @Param payload body dto.DepartParcelRequest true "Solicitud con office de salida (requerido) y opcionalmente vehículo y timestamp de partida"
 // @Success 200 {object} handler.CreateParcelResponseEnvelope "Envío en ruta exitosamente (estado: EN_ROUTE)"
@@ -784,6 +797,7 @@ func (h *ParcelHandler) Depart(c *gin.Context) {
 		"success": true,
 		"data": dto.CreateParcelResponse{
 			ID:                  p.ID,
+			TrackingCode:        p.TrackingCode,
 			Status:              string(p.Status),
 			ShipmentType:        string(p.ShipmentType),
 			OriginOfficeID:      p.OriginOfficeID,
@@ -816,6 +830,8 @@ func (h *ParcelHandler) Depart(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer token"
+// @Param X-Event-Source header string false "Origen del evento de tracking: counter, scanner_app o api (default api)"
+// @Param X-Device-Location header string false "Posición del dispositivo: lat,lon[,accuracy_m]"
 // @Param id path string true "UUID del envío" Format(uuid)
 // @Param payload body dto.ArriveParcelRequest true "Solicitud con destination_office_id (requerido)"
 // @Success 200 {object} handler.CreateParcelResponseEnvelope "Envío llegado a destino exitosamente (estado: ARRIVED)"
@@ -896,6 +912,7 @@ func (h *ParcelHandler) Arrive(c *gin.Context) {
 		"success": true,
 		"data": dto.CreateParcelResponse{
 			ID:                  p.ID,
+			TrackingCode:        p.TrackingCode,
 			Status:              string(p.Status),
 			ShipmentType:        string(p.ShipmentType),
 			OriginOfficeID:      p.OriginOfficeID,
@@ -922,19 +939,21 @@ func (h *ParcelHandler) Arrive(c *gin.Context) {
 
 // Deliver godoc
 // @Summary Entregar envío al destinatario
-// @Description Transiciona el envío de estado ARRIVED a DELIVERED. Marca la finalización de la cadena de custodia. Requiere confirmación de package_key para seguridad. Captura usuario responsable de la entrega.
+// @Description Transiciona el envío de estado ARRIVED a DELIVERED. Marca la finalización de la cadena de custodia. Requiere confirmación de package_key para seguridad. Captura usuario responsable de la entrega. Se rechaza (outstanding_balance) si el pago del envío tiene saldo pendiente.
 // @Tags Parcels
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer token"
+// @Param X-Event-Source header string false "Origen del evento de tracking: counter, scanner_app o api (default api)"
+// @Param X-Device-Location header string false "Posición del dispositivo: lat,lon[,accuracy_m]"
 // @Param id path string true "UUID del envío" Format(uuid)
 // @Param payload body dto.DeliverParcelRequest true "Solicitud con package_key para confirmación (requerido)"
 // @Success 200 {object} handler.CreateParcelResponseEnvelope "Envío entregado exitosamente (estado: DELIVERED)"
 // @Failure 400 {object} handler.ErrorResponse "Validación fallida: id inválido o payload malformado"
 // @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
 // @Failure 404 {object} handler.ErrorResponse "Envío no encontrado"
-// @Failure 409 {object} handler.ErrorResponse "Conflicto: transición no permitida, package_key inválido o estado incompatible"
+// @Failure 409 {object} handler.ErrorResponse "Conflicto: transición no permitida, package_key inválido , estado incompatible o saldo de pago pendiente"
 // @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
 // @Router /parcels/{id}/deliver [post]
 func (h *ParcelHandler) Deliver(c *gin.Context) {
@@ -1008,6 +1027,7 @@ func (h *ParcelHandler) Deliver(c *gin.Context) {
 		"success": true,
 		"data": dto.CreateParcelResponse{
 			ID:                  p.ID,
+			TrackingCode:        p.TrackingCode,
 			Status:              string(p.Status),
 			ShipmentType:        string(p.ShipmentType),
 			OriginOfficeID:      p.OriginOfficeID,
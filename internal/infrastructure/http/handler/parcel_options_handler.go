@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_core/usecase"
+	"ms-parcel-core/internal/pkg/util/apperror"
+)
+
+type ParcelOptionsResponse struct {
+	RequirePackageKey       bool `json:"require_package_key"`
+	UsePriceTable           bool `json:"use_price_table"`
+	UseVolumetricWeight     bool `json:"use_volumetric_weight"`
+	VolumetricDivisor       int  `json:"volumetric_divisor"`
+	AllowManualPrice        bool `json:"allow_manual_price"`
+	AllowOverridePriceTable bool `json:"allow_override_price_table"`
+	AllowPayInDestination   bool `json:"allow_pay_in_destination"`
+	MaxPrints               int  `json:"max_prints"`
+	AllowReprint            bool `json:"allow_reprint"`
+	ReprintFeeEnabled       bool `json:"reprint_fee_enabled"`
+	CashboxFailOpen         bool `json:"cashbox_fail_open"`
+}
+
+type EffectiveParcelOptionsResponse struct {
+	TenantID  string                `json:"tenant_id"`
+	OfficeID  *string               `json:"office_id,omitempty"`
+	Options   ParcelOptionsResponse `json:"options"`
+	Sources   map[string]string     `json:"sources"`
+	Fallbacks []string              `json:"fallbacks"`
+}
+
+type ParcelOptionsHandler struct {
+	effectiveUC *usecase.GetEffectiveParcelOptionsUseCase
+}
+
+func NewParcelOptionsHandler(effectiveUC *usecase.GetEffectiveParcelOptionsUseCase) *ParcelOptionsHandler {
+	return &ParcelOptionsHandler{effectiveUC: effectiveUC}
+}
+
+// Effective godoc
+// @Summary Opciones efectivas de envíos
+// @Description Devuelve las opciones de envío que aplican al tenant (y a la oficina si se indica office_id), resultado de combinar defaults, configuración del tenant y overrides de la oficina. sources indica de qué nivel sale cada campo (DEFAULT, TENANT, OFFICE) y fallbacks lista los niveles ignorados por no estar disponibles o ser inválidos (tenant_options_unavailable, tenant_options_invalid, office_options_unavailable, office_options_invalid).
+// @Tags ParcelOptions
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param office_id query string false "UUID de la oficina" Format(uuid)
+// @Success 200 {object} handler.AnyDataEnvelope "Opciones efectivas"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: office_id inválido"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /parcel-options/effective [get]
+func (h *ParcelOptionsHandler) Effective(c *gin.Context) {
+	tenantID, _ := c.Get("tenant_id")
+	tenant := strings.TrimSpace(anyToString(tenantID))
+	if tenant == "" {
+		_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+		return
+	}
+
+	res, err := h.effectiveUC.Execute(c.Request.Context(), usecase.GetEffectiveParcelOptionsInput{
+		TenantID: tenant,
+		OfficeID: c.Query("office_id"),
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": toEffectiveParcelOptionsResponse(*res)})
+}
+
+func toEffectiveParcelOptionsResponse(res coreport.ResolvedParcelOptions) EffectiveParcelOptionsResponse {
+	var officeID *string
+	if res.OfficeID != "" {
+		o := res.OfficeID
+		officeID = &o
+	}
+	sources := make(map[string]string, len(res.Sources))
+	for k, v := range res.Sources {
+		sources[k] = string(v)
+	}
+	o := res.Options
+	return EffectiveParcelOptionsResponse{
+		TenantID: res.TenantID,
+		OfficeID: officeID,
+		Options: ParcelOptionsResponse{
+			RequirePackageKey:       o.RequirePackageKey,
+			UsePriceTable:           o.UsePriceTable,
+			UseVolumetricWeight:     o.UseVolumetricWeight,
+			VolumetricDivisor:       o.VolumetricDivisor,
+			AllowManualPrice:        o.AllowManualPrice,
+			AllowOverridePriceTable: o.AllowOverridePriceTable,
+			AllowPayInDestination:   o.AllowPayInDestination,
+			MaxPrints:               o.MaxPrints,
+			AllowReprint:            o.AllowReprint,
+			ReprintFeeEnabled:       o.ReprintFeeEnabled,
+			CashboxFailOpen:         o.CashboxFailOpen,
+		},
+		Sources:   sources,
+		Fallbacks: res.Fallbacks,
+	}
+}
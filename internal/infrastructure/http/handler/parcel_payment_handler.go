@@ -14,14 +14,30 @@ import (
 )
 
 type UpsertParcelPaymentRequest struct {
-	PaymentType  string  `json:"payment_type" binding:"required,oneof=CASH FOB CARD TRANSFER EWALLET FREE COLLECT_ON_DELIVERY"`
-	Currency     *string `json:"currency" binding:"omitempty,oneof=PEN USD"`
-	Amount       float64 `json:"amount" binding:"required"`
-	Notes        *string `json:"notes" binding:"omitempty,max=200"`
-	Channel      *string `json:"channel" binding:"omitempty,oneof=COUNTER WEB"`
-	OfficeID     *string `json:"office_id" binding:"omitempty,uuid"`
-	CashboxID    *string `json:"cashbox_id" binding:"omitempty,max=50"`
-	SellerUserID *string `json:"seller_user_id" binding:"omitempty,uuid"`
+	PaymentType string   `json:"payment_type" binding:"required,oneof=CASH FOB CARD TRANSFER EWALLET FREE COLLECT_ON_DELIVERY"`
+	Currency    *string  `json:"currency" binding:"omitempty,oneof=PEN USD"`
+	Amount      *float64 `json:"amount" binding:"omitempty,min=0"`
+	Notes       *string  `json:"notes" binding:"omitempty,max=200"`
+
+	// Motivo obligatorio si amount difiere del monto calculado (items + recargos)
+	ManualAmountReason *string                   `json:"manual_amount_reason" binding:"omitempty,max=200"`
+	Surcharges         []PaymentSurchargeRequest `json:"surcharges" binding:"omitempty,max=20,dive"`
+	Channel            *string                   `json:"channel" binding:"omitempty,oneof=COUNTER WEB"`
+	OfficeID           *string                   `json:"office_id" binding:"omitempty,uuid"`
+	CashboxID          *string                   `json:"cashbox_id" binding:"omitempty,max=50"`
+	SellerUserID       *string                   `json:"seller_user_id" binding:"omitempty,uuid"`
+}
+
+type PaymentSurchargeRequest struct {
+	Code        string  `json:"code" binding:"required,max=50"`
+	Description *string `json:"description" binding:"omitempty,max=200"`
+	Amount      float64 `json:"amount" binding:"min=0"`
+}
+
+type PaymentSurchargeResponse struct {
+	Code        string  `json:"code"`
+	Description *string `json:"description,omitempty"`
+	Amount      float64 `json:"amount"`
 }
 
 type ParcelPaymentResponse struct {
@@ -40,34 +56,119 @@ type ParcelPaymentResponse struct {
 	OfficeID     *string `json:"office_id,omitempty"`
 	CashboxID    *string `json:"cashbox_id,omitempty"`
 	SellerUserID *string `json:"seller_user_id,omitempty"`
+
+	ItemsAmount        float64                    `json:"items_amount"`
+	Surcharges         []PaymentSurchargeResponse `json:"surcharges"`
+	SurchargesAmount   float64                    `json:"surcharges_amount"`
+	CalculatedAmount   float64                    `json:"calculated_amount"`
+	AmountSource       string                     `json:"amount_source"`
+	ManualAmountReason *string                    `json:"manual_amount_reason,omitempty"`
+	AmountStale        bool                       `json:"amount_stale"`
+	PaidAmount         float64                    `json:"paid_amount"`
+	RefundedAmount     float64                    `json:"refunded_amount"`
+	Balance            float64                    `json:"balance"`
+}
+
+type AddPaymentTransactionRequest struct {
+	PaymentType  string  `json:"payment_type" binding:"required,oneof=CASH FOB CARD TRANSFER EWALLET COLLECT_ON_DELIVERY"`
+	Amount       float64 `json:"amount" binding:"required,gt=0"`
+	Currency     *string `json:"currency" binding:"omitempty,oneof=PEN USD"`
+	Channel      *string `json:"channel" binding:"omitempty,oneof=COUNTER WEB"`
+	OfficeID     *string `json:"office_id" binding:"omitempty,uuid"`
+	CashboxID    *string `json:"cashbox_id" binding:"omitempty,max=50"`
+	SellerUserID *string `json:"seller_user_id" binding:"omitempty,uuid"`
+	Notes        *string `json:"notes" binding:"omitempty,max=200"`
+}
+
+type RefundParcelPaymentRequest struct {
+	// Sin amount se devuelve todo lo cobrado
+	Amount           *float64 `json:"amount" binding:"omitempty,gt=0"`
+	Reason           string   `json:"reason" binding:"required,max=200"`
+	ApprovedByUserID string   `json:"approved_by_user_id" binding:"required,max=50"`
+	PaymentType      *string  `json:"payment_type" binding:"omitempty,oneof=CASH FOB CARD TRANSFER EWALLET COLLECT_ON_DELIVERY"`
+	Channel          *string  `json:"channel" binding:"omitempty,oneof=COUNTER WEB"`
+	OfficeID         *string  `json:"office_id" binding:"omitempty,uuid"`
+	CashboxID        string   `json:"cashbox_id" binding:"required,max=50"`
+	Notes            *string  `json:"notes" binding:"omitempty,max=200"`
+}
+
+type VoidPaymentTransactionRequest struct {
+	Reason           string `json:"reason" binding:"required,max=200"`
+	ApprovedByUserID string `json:"approved_by_user_id" binding:"required,max=50"`
+}
+
+type PaymentTransactionResponse struct {
+	ID              string  `json:"id"`
+	ParcelID        string  `json:"parcel_id"`
+	PaymentID       string  `json:"payment_id"`
+	Kind            string  `json:"kind"`
+	PaymentType     string  `json:"payment_type"`
+	Channel         string  `json:"channel"`
+	Amount          float64 `json:"amount"`
+	Currency        string  `json:"currency"`
+	OfficeID        *string `json:"office_id,omitempty"`
+	CashboxID       *string `json:"cashbox_id,omitempty"`
+	SellerUserID    *string `json:"seller_user_id,omitempty"`
+	CreatedByUserID *string `json:"created_by_user_id,omitempty"`
+	Notes           *string `json:"notes,omitempty"`
+	CreatedAt       string  `json:"created_at"`
+
+	Reason                *string `json:"reason,omitempty"`
+	ApprovedByUserID      *string `json:"approved_by_user_id,omitempty"`
+	ReversesTransactionID *string `json:"reverses_transaction_id,omitempty"`
+
+	CashboxMovementID    *string `json:"cashbox_movement_id,omitempty"`
+	CashboxPostingStatus string  `json:"cashbox_posting_status,omitempty"`
+	CashboxPostingError  *string `json:"cashbox_posting_error,omitempty"`
 }
 
 type ParcelPaymentHandler struct {
 	upsertUC   *paymentusecase.UpsertParcelPaymentUseCase
 	getUC      *paymentusecase.GetParcelPaymentUseCase
 	markPaidUC *paymentusecase.MarkPaidParcelPaymentUseCase
+	addTxUC    *paymentusecase.AddParcelPaymentTransactionUseCase
+	listTxUC   *paymentusecase.ListParcelPaymentTransactionsUseCase
+	refundUC   *paymentusecase.RefundParcelPaymentUseCase
+	voidTxUC   *paymentusecase.VoidParcelPaymentTransactionUseCase
 }
 
-func NewParcelPaymentHandler(upsertUC *paymentusecase.UpsertParcelPaymentUseCase, getUC *paymentusecase.GetParcelPaymentUseCase, markPaidUC *paymentusecase.MarkPaidParcelPaymentUseCase) *ParcelPaymentHandler {
-	return &ParcelPaymentHandler{upsertUC: upsertUC, getUC: getUC, markPaidUC: markPaidUC}
+func NewParcelPaymentHandler(
+	upsertUC *paymentusecase.UpsertParcelPaymentUseCase,
+	getUC *paymentusecase.GetParcelPaymentUseCase,
+	markPaidUC *paymentusecase.MarkPaidParcelPaymentUseCase,
+	addTxUC *paymentusecase.AddParcelPaymentTransactionUseCase,
+	listTxUC *paymentusecase.ListParcelPaymentTransactionsUseCase,
+	refundUC *paymentusecase.RefundParcelPaymentUseCase,
+	voidTxUC *paymentusecase.VoidParcelPaymentTransactionUseCase) *ParcelPaymentHandler {
+	return &ParcelPaymentHandler{
+		upsertUC:   upsertUC,
+		getUC:      getUC,
+		markPaidUC: markPaidUC,
+		addTxUC:    addTxUC,
+		listTxUC:   listTxUC,
+		refundUC:   refundUC,
+		voidTxUC:   voidTxUC,
+	}
 }
 
 // Upsert godoc
 // @Summary Crear o actualizar información de pago
-// @Description Crea o actualiza la información de pago del envío. Incluye tipo de pago, monto, moneda, canal, oficina y datos de caja. Soporta múltiples formas de pago (CASH, FOB, CARD, TRANSFER, EWALLET, FREE, COLLECT_ON_DELIVERY). El estado inicial es PENDING.
+// @Description Crea o actualiza la información de pago del envío. Incluye tipo de pago, moneda, canal, oficina y datos de caja. Soporta múltiples formas de pago (CASH, FOB, CARD, TRANSFER, EWALLET, FREE, COLLECT_ON_DELIVERY). El estado inicial es UNPAID (luego PARTIAL, PAID u OVERPAID según los cobros registrados). El monto se calcula como la suma de los items más los recargos (surcharges); enviar amount distinto al calculado es un override manual que requiere AllowManualPrice y manual_amount_reason. Si luego se agregan o eliminan items, el monto AUTO se recalcula y el manual queda marcado amount_stale.
 // @Tags ParcelPayments
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer token"
 // @Param id path string true "UUID del envío" Format(uuid)
-// @Param payload body UpsertParcelPaymentRequest true "Solicitud con datos de pago (monto requerido, type de pago, moneda por defecto PEN)"
+// @Param payload body UpsertParcelPaymentRequest true "Solicitud con datos de pago (type de pago, moneda por defecto PEN, amount opcional)"
 // @Success 200 {object} handler.AnyDataEnvelope "Pago creado o actualizado exitosamente"
 // @Failure 400 {object} handler.ErrorResponse "Validación fallida: id inválido, payload malformado o valores inválidos"
 // @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 403 {object} handler.ErrorResponse "Funcionalidad deshabilitada para el tenant (feature_disabled)"
 // @Failure 404 {object} handler.ErrorResponse "Envío no encontrado"
 // @Failure 409 {object} handler.ErrorResponse "Conflicto: estado incompatible o envío no permite esta operación"
 // @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Failure 503 {object} handler.ErrorResponse "No se pudo verificar la caja y el tenant opera en modo fail closed"
 // @Router /parcels/{id}/payment [put]
 func (h *ParcelPaymentHandler) Upsert(c *gin.Context) {
 	idStr := strings.TrimSpace(c.Param("id"))
@@ -100,54 +201,39 @@ func (h *ParcelPaymentHandler) Upsert(c *gin.Context) {
 		return
 	}
 
+	var surcharges []paymentdomain.PaymentSurcharge
+	if req.Surcharges != nil {
+		surcharges = make([]paymentdomain.PaymentSurcharge, 0, len(req.Surcharges))
+		for _, sc := range req.Surcharges {
+			surcharges = append(surcharges, paymentdomain.PaymentSurcharge{Code: sc.Code, Description: sc.Description, Amount: sc.Amount})
+		}
+	}
+
 	pay, err := h.upsertUC.Execute(c.Request.Context(), paymentusecase.UpsertParcelPaymentInput{
-		TenantID:     tenant,
-		ParcelID:     parcelID,
-		PaymentType:  paymentdomain.PaymentType(strings.TrimSpace(req.PaymentType)),
-		Currency:     paymentdomain.Currency(currency),
-		Amount:       req.Amount,
-		Notes:        req.Notes,
-		Channel:      paymentdomain.PaymentChannel(channel),
-		OfficeID:     req.OfficeID,
-		CashboxID:    req.CashboxID,
-		SellerUserID: req.SellerUserID,
+		TenantID:           tenant,
+		ParcelID:           parcelID,
+		PaymentType:        paymentdomain.PaymentType(strings.TrimSpace(req.PaymentType)),
+		Currency:           paymentdomain.Currency(currency),
+		Amount:             req.Amount,
+		ManualAmountReason: req.ManualAmountReason,
+		Surcharges:         surcharges,
+		Notes:              req.Notes,
+		Channel:            paymentdomain.PaymentChannel(channel),
+		OfficeID:           req.OfficeID,
+		CashboxID:          req.CashboxID,
+		SellerUserID:       req.SellerUserID,
 	})
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	var paidAtStr *string
-	if pay.PaidAt != nil {
-		s := pay.PaidAt.UTC().Format(time.RFC3339)
-		paidAtStr = &s
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data": ParcelPaymentResponse{
-			ID:           pay.ID,
-			ParcelID:     pay.ParcelID,
-			PaymentType:  string(pay.PaymentType),
-			Currency:     string(pay.Currency),
-			Amount:       pay.Amount,
-			Notes:        pay.Notes,
-			Status:       string(pay.Status),
-			CreatedAt:    pay.CreatedAt.UTC().Format(time.RFC3339),
-			UpdatedAt:    pay.UpdatedAt.UTC().Format(time.RFC3339),
-			PaidAt:       paidAtStr,
-			PaidByUserID: pay.PaidByUserID,
-			Channel:      string(pay.Channel),
-			OfficeID:     pay.OfficeID,
-			CashboxID:    pay.CashboxID,
-			SellerUserID: pay.SellerUserID,
-		},
-	})
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": toParcelPaymentResponse(*pay)})
 }
 
 // Get godoc
 // @Summary Obtener información de pago del envío
-// @Description Devuelve los detalles completos del pago registrado para un envío. Incluye tipo de pago, monto, estado (PENDING/PAID), moneda, canal, oficina y datos de caja.
+// @Description Devuelve los detalles completos del pago registrado para un envío. Incluye tipo de pago, monto, monto cobrado, saldo, monto devuelto, estado (UNPAID/PARTIAL/PAID/OVERPAID/PARTIALLY_REFUNDED/REFUNDED), moneda, canal, oficina y datos de caja.
 // @Tags ParcelPayments
 // @Produce json
 // @Security BearerAuth
@@ -184,37 +270,12 @@ func (h *ParcelPaymentHandler) Get(c *gin.Context) {
 		return
 	}
 
-	var paidAtStr *string
-	if pay.PaidAt != nil {
-		s := pay.PaidAt.UTC().Format(time.RFC3339)
-		paidAtStr = &s
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data": ParcelPaymentResponse{
-			ID:           pay.ID,
-			ParcelID:     pay.ParcelID,
-			PaymentType:  string(pay.PaymentType),
-			Currency:     string(pay.Currency),
-			Amount:       pay.Amount,
-			Notes:        pay.Notes,
-			Status:       string(pay.Status),
-			CreatedAt:    pay.CreatedAt.UTC().Format(time.RFC3339),
-			UpdatedAt:    pay.UpdatedAt.UTC().Format(time.RFC3339),
-			PaidAt:       paidAtStr,
-			PaidByUserID: pay.PaidByUserID,
-			Channel:      string(pay.Channel),
-			OfficeID:     pay.OfficeID,
-			CashboxID:    pay.CashboxID,
-			SellerUserID: pay.SellerUserID,
-		},
-	})
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": toParcelPaymentResponse(*pay)})
 }
 
 // MarkPaid godoc
 // @Summary Marcar pago como realizado
-// @Description Transiciona el pago a estado PAID. Intégrase con el servicio de caja (CASHBOX) para registrar la transacción y confirmar la recaudación. Captura el user_id del operador que marca el pago.
+// @Description Cobra el saldo pendiente en una sola transacción con el medio de pago, canal y caja del pago, dejándolo en estado PAID. Si no hay saldo pendiente no registra nada y devuelve el pago actual. Captura el user_id del operador que marca el pago. Si el pago tiene caja, el cobro se registra como ingreso en ms-cashbox (cashbox_posting_status en el ledger); si el registro falla se reintenta en segundo plano sin duplicar el movimiento.
 // @Tags ParcelPayments
 // @Produce json
 // @Security BearerAuth
@@ -223,6 +284,7 @@ func (h *ParcelPaymentHandler) Get(c *gin.Context) {
 // @Success 200 {object} handler.AnyDataEnvelope "Pago marcado como realizado exitosamente"
 // @Failure 400 {object} handler.ErrorResponse "Validación fallida: id inválido"
 // @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 403 {object} handler.ErrorResponse "Funcionalidad deshabilitada para el tenant (feature_disabled)"
 // @Failure 404 {object} handler.ErrorResponse "Envío o pago no encontrado"
 // @Failure 409 {object} handler.ErrorResponse "Conflicto: pago ya realizado o estado no permitido"
 // @Failure 500 {object} handler.ErrorResponse "Error interno del servidor o fallo de integración con caja"
@@ -255,30 +317,363 @@ func (h *ParcelPaymentHandler) MarkPaid(c *gin.Context) {
 		return
 	}
 
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": toParcelPaymentResponse(*pay)})
+}
+
+// AddTransaction godoc
+// @Summary Registrar cobro (parcial o total)
+// @Description Registra una transacción de cobro en el ledger del pago del envío (medio de pago, canal, monto, caja). Permite pagos divididos: parte en origen y el resto en destino, o con varios medios. El estado del pago se recalcula: UNPAID, PARTIAL, PAID u OVERPAID. Cobrar después del registro en origen requiere AllowPayInDestination. La entrega se bloquea mientras haya saldo pendiente. Los cobros con caja se registran como ingreso en ms-cashbox y se reintentan si falla.
+// @Tags ParcelPayments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param id path string true "UUID del envío" Format(uuid)
+// @Param payload body AddPaymentTransactionRequest true "Datos del cobro"
+// @Success 201 {object} handler.AnyDataEnvelope "Cobro registrado; devuelve transacción y pago actualizado"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: id inválido, payload malformado o valores inválidos"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 403 {object} handler.ErrorResponse "Funcionalidad deshabilitada para el tenant (feature_disabled)"
+// @Failure 404 {object} handler.ErrorResponse "Envío o pago no encontrado"
+// @Failure 409 {object} handler.ErrorResponse "Conflicto: caja cerrada, pago en destino deshabilitado o pago FREE"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Failure 503 {object} handler.ErrorResponse "No se pudo verificar la caja y el tenant opera en modo fail closed"
+// @Router /parcels/{id}/payment/transactions [post]
+func (h *ParcelPaymentHandler) AddTransaction(c *gin.Context) {
+	idStr := strings.TrimSpace(c.Param("id"))
+	parcelID, err := uuid.Parse(idStr)
+	if err != nil {
+		_ = c.Error(apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"}))
+		return
+	}
+
+	var req AddPaymentTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.NewBadRequest("validation_error", "payload inválido", map[string]any{"error": err.Error()}))
+		return
+	}
+
+	tenantID, _ := c.Get("tenant_id")
+	tenant := strings.TrimSpace(anyToString(tenantID))
+	if tenant == "" {
+		_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+		return
+	}
+
+	userIDVal, _ := c.Get("user_id")
+	uid := strings.TrimSpace(anyToString(userIDVal))
+	var uidPtr *string
+	if uid != "" {
+		uidPtr = &uid
+	}
+
+	var currency *paymentdomain.Currency
+	if req.Currency != nil && strings.TrimSpace(*req.Currency) != "" {
+		cur := paymentdomain.Currency(strings.TrimSpace(*req.Currency))
+		currency = &cur
+	}
+
+	channel := "COUNTER"
+	if req.Channel != nil && strings.TrimSpace(*req.Channel) != "" {
+		channel = strings.TrimSpace(*req.Channel)
+	}
+
+	out, err := h.addTxUC.Execute(c.Request.Context(), paymentusecase.AddParcelPaymentTransactionInput{
+		TenantID:     tenant,
+		UserID:       uidPtr,
+		ParcelID:     parcelID,
+		PaymentType:  paymentdomain.PaymentType(strings.TrimSpace(req.PaymentType)),
+		Channel:      paymentdomain.PaymentChannel(channel),
+		Amount:       req.Amount,
+		Currency:     currency,
+		OfficeID:     req.OfficeID,
+		CashboxID:    req.CashboxID,
+		SellerUserID: req.SellerUserID,
+		Notes:        req.Notes,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data": gin.H{
+			"transaction": toPaymentTransactionResponse(*out.Transaction),
+			"payment":     toParcelPaymentResponse(*out.Payment),
+		},
+	})
+}
+
+// ListTransactions godoc
+// @Summary Listar cobros del envío
+// @Description Devuelve el ledger de transacciones del pago del envío en orden de registro, con el estado de registro en caja de cada una (cashbox_posting_status: PENDING, POSTING, POSTED, FAILED) y el ID del movimiento en ms-cashbox.
+// @Tags ParcelPayments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param id path string true "UUID del envío" Format(uuid)
+// @Success 200 {object} handler.AnyDataEnvelope "Transacciones del pago"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: id inválido"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /parcels/{id}/payment/transactions [get]
+func (h *ParcelPaymentHandler) ListTransactions(c *gin.Context) {
+	idStr := strings.TrimSpace(c.Param("id"))
+	parcelID, err := uuid.Parse(idStr)
+	if err != nil {
+		_ = c.Error(apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"}))
+		return
+	}
+
+	tenantID, _ := c.Get("tenant_id")
+	tenant := strings.TrimSpace(anyToString(tenantID))
+	if tenant == "" {
+		_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+		return
+	}
+
+	txs, err := h.listTxUC.Execute(c.Request.Context(), tenant, parcelID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	out := make([]PaymentTransactionResponse, 0, len(txs))
+	for _, tx := range txs {
+		out = append(out, toPaymentTransactionResponse(tx))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": out})
+}
+
+// Refund godoc
+// @Summary Registrar devolución
+// @Description Devuelve total o parcialmente lo cobrado del envío. Requiere motivo, un aprobador distinto del operador y una caja abierta (si la caja no se puede verificar se rechaza). Sin amount se devuelve todo lo cobrado. El pago queda REFUNDED si no queda nada cobrado, PARTIALLY_REFUNDED si vuelve a tener saldo pendiente o PAID si solo se devolvió el vuelto, y se registra el evento PAYMENT_REFUNDED en el tracking. La devolución se registra como egreso (REFUND) en ms-cashbox.
+// @Tags ParcelPayments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param id path string true "UUID del envío" Format(uuid)
+// @Param payload body RefundParcelPaymentRequest true "Datos de la devolución"
+// @Success 201 {object} handler.AnyDataEnvelope "Devolución registrada; devuelve transacción y pago actualizado"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: id inválido, payload malformado, motivo o aprobador faltante"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 403 {object} handler.ErrorResponse "Funcionalidad deshabilitada para el tenant (feature_disabled)"
+// @Failure 404 {object} handler.ErrorResponse "Pago no encontrado"
+// @Failure 409 {object} handler.ErrorResponse "Conflicto: caja cerrada, nada cobrado o monto mayor a lo cobrado"
+// @Failure 503 {object} handler.ErrorResponse "No se pudo verificar la caja"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /parcels/{id}/payment/refunds [post]
+func (h *ParcelPaymentHandler) Refund(c *gin.Context) {
+	idStr := strings.TrimSpace(c.Param("id"))
+	parcelID, err := uuid.Parse(idStr)
+	if err != nil {
+		_ = c.Error(apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"}))
+		return
+	}
+
+	var req RefundParcelPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.NewBadRequest("validation_error", "payload inválido", map[string]any{"error": err.Error()}))
+		return
+	}
+
+	tenantID, _ := c.Get("tenant_id")
+	tenant := strings.TrimSpace(anyToString(tenantID))
+	if tenant == "" {
+		_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+		return
+	}
+
+	userIDVal, _ := c.Get("user_id")
+	uid := strings.TrimSpace(anyToString(userIDVal))
+	var uidPtr *string
+	if uid != "" {
+		uidPtr = &uid
+	}
+	userName, _ := c.Get("user_name")
+
+	var paymentType paymentdomain.PaymentType
+	if req.PaymentType != nil {
+		paymentType = paymentdomain.PaymentType(strings.TrimSpace(*req.PaymentType))
+	}
+	channel := "COUNTER"
+	if req.Channel != nil && strings.TrimSpace(*req.Channel) != "" {
+		channel = strings.TrimSpace(*req.Channel)
+	}
+	cashboxID := strings.TrimSpace(req.CashboxID)
+
+	out, err := h.refundUC.Execute(c.Request.Context(), paymentusecase.RefundParcelPaymentInput{
+		TenantID:         tenant,
+		UserID:           uidPtr,
+		UserName:         strings.TrimSpace(anyToString(userName)),
+		ParcelID:         parcelID,
+		Amount:           req.Amount,
+		Reason:           req.Reason,
+		ApprovedByUserID: req.ApprovedByUserID,
+		PaymentType:      paymentType,
+		Channel:          paymentdomain.PaymentChannel(channel),
+		OfficeID:         req.OfficeID,
+		CashboxID:        &cashboxID,
+		Notes:            req.Notes,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data": gin.H{
+			"transaction": toPaymentTransactionResponse(*out.Transaction),
+			"payment":     toParcelPaymentResponse(*out.Payment),
+		},
+	})
+}
+
+// VoidTransaction godoc
+// @Summary Anular cobro
+// @Description Anula un cobro (CHARGE) registrado por error agregando una transacción VOID por el mismo monto. Requiere motivo y un aprobador distinto del operador; si el cobro se hizo en una caja, esta debe estar abierta. No se puede anular un cobro ya anulado ni si las devoluciones quedarían por encima de lo cobrado. Registra el evento PAYMENT_VOIDED en el tracking.
+// @Tags ParcelPayments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param id path string true "UUID del envío" Format(uuid)
+// @Param txId path string true "UUID de la transacción de cobro" Format(uuid)
+// @Param payload body VoidPaymentTransactionRequest true "Motivo y aprobador"
+// @Success 201 {object} handler.AnyDataEnvelope "Anulación registrada; devuelve transacción y pago actualizado"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: ids inválidos, motivo o aprobador faltante"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 403 {object} handler.ErrorResponse "Funcionalidad deshabilitada para el tenant (feature_disabled)"
+// @Failure 404 {object} handler.ErrorResponse "Pago o transacción no encontrada"
+// @Failure 409 {object} handler.ErrorResponse "Conflicto: no es un cobro, ya anulado, caja cerrada o devoluciones superarían lo cobrado"
+// @Failure 503 {object} handler.ErrorResponse "No se pudo verificar la caja"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /parcels/{id}/payment/transactions/{txId}/void [post]
+func (h *ParcelPaymentHandler) VoidTransaction(c *gin.Context) {
+	idStr := strings.TrimSpace(c.Param("id"))
+	parcelID, err := uuid.Parse(idStr)
+	if err != nil {
+		_ = c.Error(apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"}))
+		return
+	}
+	txID, err := uuid.Parse(strings.TrimSpace(c.Param("txId")))
+	if err != nil {
+		_ = c.Error(apperror.NewBadRequest("validation_error", "txId inválido", map[string]any{"field": "txId"}))
+		return
+	}
+
+	var req VoidPaymentTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.NewBadRequest("validation_error", "payload inválido", map[string]any{"error": err.Error()}))
+		return
+	}
+
+	tenantID, _ := c.Get("tenant_id")
+	tenant := strings.TrimSpace(anyToString(tenantID))
+	if tenant == "" {
+		_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+		return
+	}
+
+	userIDVal, _ := c.Get("user_id")
+	uid := strings.TrimSpace(anyToString(userIDVal))
+	var uidPtr *string
+	if uid != "" {
+		uidPtr = &uid
+	}
+	userName, _ := c.Get("user_name")
+
+	out, err := h.voidTxUC.Execute(c.Request.Context(), paymentusecase.VoidParcelPaymentTransactionInput{
+		TenantID:         tenant,
+		UserID:           uidPtr,
+		UserName:         strings.TrimSpace(anyToString(userName)),
+		ParcelID:         parcelID,
+		TransactionID:    txID,
+		Reason:           req.Reason,
+		ApprovedByUserID: req.ApprovedByUserID,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data": gin.H{
+			"transaction": toPaymentTransactionResponse(*out.Transaction),
+			"payment":     toParcelPaymentResponse(*out.Payment),
+		},
+	})
+}
+
+func toParcelPaymentResponse(pay paymentdomain.ParcelPayment) ParcelPaymentResponse {
 	var paidAtStr *string
 	if pay.PaidAt != nil {
 		s := pay.PaidAt.UTC().Format(time.RFC3339)
 		paidAtStr = &s
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data": ParcelPaymentResponse{
-			ID:           pay.ID,
-			ParcelID:     pay.ParcelID,
-			PaymentType:  string(pay.PaymentType),
-			Currency:     string(pay.Currency),
-			Amount:       pay.Amount,
-			Notes:        pay.Notes,
-			Status:       string(pay.Status),
-			CreatedAt:    pay.CreatedAt.UTC().Format(time.RFC3339),
-			UpdatedAt:    pay.UpdatedAt.UTC().Format(time.RFC3339),
-			PaidAt:       paidAtStr,
-			PaidByUserID: pay.PaidByUserID,
-			Channel:      string(pay.Channel),
-			OfficeID:     pay.OfficeID,
-			CashboxID:    pay.CashboxID,
-			SellerUserID: pay.SellerUserID,
-		},
-	})
+	surcharges := make([]PaymentSurchargeResponse, 0, len(pay.Surcharges))
+	for _, sc := range pay.Surcharges {
+		surcharges = append(surcharges, PaymentSurchargeResponse{Code: sc.Code, Description: sc.Description, Amount: sc.Amount})
+	}
+
+	return ParcelPaymentResponse{
+		ID:                 pay.ID,
+		ParcelID:           pay.ParcelID,
+		PaymentType:        string(pay.PaymentType),
+		Currency:           string(pay.Currency),
+		Amount:             pay.Amount,
+		Notes:              pay.Notes,
+		Status:             string(pay.Status),
+		CreatedAt:          pay.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:          pay.UpdatedAt.UTC().Format(time.RFC3339),
+		PaidAt:             paidAtStr,
+		PaidByUserID:       pay.PaidByUserID,
+		Channel:            string(pay.Channel),
+		OfficeID:           pay.OfficeID,
+		CashboxID:          pay.CashboxID,
+		SellerUserID:       pay.SellerUserID,
+		ItemsAmount:        pay.ItemsAmount,
+		Surcharges:         surcharges,
+		SurchargesAmount:   pay.SurchargesAmount,
+		CalculatedAmount:   pay.CalculatedAmount(),
+		AmountSource:       string(pay.AmountSource),
+		ManualAmountReason: pay.ManualAmountReason,
+		AmountStale:        pay.AmountStale,
+		PaidAmount:         pay.PaidAmount,
+		RefundedAmount:     pay.RefundedAmount,
+		Balance:            pay.Balance,
+	}
+}
+
+func toPaymentTransactionResponse(tx paymentdomain.PaymentTransaction) PaymentTransactionResponse {
+	return PaymentTransactionResponse{
+		ID:              tx.ID,
+		ParcelID:        tx.ParcelID,
+		PaymentID:       tx.PaymentID,
+		Kind:            string(tx.Kind),
+		PaymentType:     string(tx.PaymentType),
+		Channel:         string(tx.Channel),
+		Amount:          tx.Amount,
+		Currency:        string(tx.Currency),
+		OfficeID:        tx.OfficeID,
+		CashboxID:       tx.CashboxID,
+		SellerUserID:    tx.SellerUserID,
+		CreatedByUserID: tx.CreatedByUserID,
+		Notes:           tx.Notes,
+		CreatedAt:       tx.CreatedAt.UTC().Format(time.RFC3339),
+
+		Reason:                tx.Reason,
+		ApprovedByUserID:      tx.ApprovedByUserID,
+		ReversesTransactionID: tx.ReversesTransactionID,
+
+		CashboxMovementID:    tx.CashboxMovementID,
+		CashboxPostingStatus: string(tx.CashboxPostingStatus),
+		CashboxPostingError:  tx.CashboxPostingError,
+	}
 }
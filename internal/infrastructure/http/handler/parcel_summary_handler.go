@@ -77,37 +77,13 @@ func (h *ParcelSummaryHandler) Get(c *gin.Context) {
 
 	var payment *ParcelPaymentResponse
 	if out.Payment != nil {
-		var paidAtStr *string
-		if out.Payment.PaidAt != nil {
-			s := out.Payment.PaidAt.UTC().Format(time.RFC3339)
-			paidAtStr = &s
-		}
-		payment = &ParcelPaymentResponse{
-			ID:           out.Payment.ID,
-			ParcelID:     out.Payment.ParcelID,
-			PaymentType:  string(out.Payment.PaymentType),
-			Currency:     string(out.Payment.Currency),
-			Amount:       out.Payment.Amount,
-			Notes:        out.Payment.Notes,
-			Status:       string(out.Payment.Status),
-			CreatedAt:    out.Payment.CreatedAt.UTC().Format(time.RFC3339),
-			UpdatedAt:    out.Payment.UpdatedAt.UTC().Format(time.RFC3339),
-			PaidAt:       paidAtStr,
-			PaidByUserID: out.Payment.PaidByUserID,
-		}
+		pr := toParcelPaymentResponse(*out.Payment)
+		payment = &pr
 	}
 
 	tracking := make([]gin.H, 0, len(out.Tracking))
 	for _, e := range out.Tracking {
-		tracking = append(tracking, gin.H{
-			"id":          e.ID,
-			"parcel_id":   e.ParcelID,
-			"event_type":  e.EventType,
-			"occurred_at": e.OccurredAt.UTC().Format(time.RFC3339),
-			"user_id":     e.UserID,
-			"user_name":   e.UserName,
-			"metadata":    e.Metadata,
-		})
+		tracking = append(tracking, gin.H(trackingEventToMap(e)))
 	}
 
 	// Asegurar límite 20 también en handler por consistencia
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"ms-parcel-core/internal/parcel/parcel_tracking/domain"
 	"ms-parcel-core/internal/parcel/parcel_tracking/usecase"
 	"ms-parcel-core/internal/pkg/util/apperror"
 )
@@ -56,16 +57,35 @@ func (h *ParcelTrackingHandler) ListByParcelID(c *gin.Context) {
 
 	out := make([]map[string]any, 0, len(evs))
 	for _, ev := range evs {
-		out = append(out, map[string]any{
-			"id":          ev.ID.String(),
-			"parcel_id":   ev.ParcelID,
-			"event_type":  ev.EventType,
-			"occurred_at": ev.OccurredAt.UTC().Format(time.RFC3339),
-			"user_id":     ev.UserID,
-			"user_name":   ev.UserName,
-			"metadata":    ev.Metadata,
-		})
+		out = append(out, trackingEventToMap(ev))
 	}
 
 	c.JSON(http.StatusOK, gin.H{"success": true, "data": out})
 }
+
+// trackingEventToMap es la representación JSON de un evento compartida por el listado, el resumen
+// y el stream. Los campos opcionales sin valor salen como null.
+func trackingEventToMap(ev domain.TrackingEvent) map[string]any {
+	var location map[string]any
+	if ev.Location != nil {
+		location = map[string]any{
+			"latitude":   ev.Location.Latitude,
+			"longitude":  ev.Location.Longitude,
+			"accuracy_m": ev.Location.AccuracyM,
+		}
+	}
+	return map[string]any{
+		"id":             ev.ID.String(),
+		"parcel_id":      ev.ParcelID,
+		"event_type":     ev.EventType,
+		"occurred_at":    ev.OccurredAt.UTC().Format(time.RFC3339),
+		"user_id":        ev.UserID,
+		"user_name":      ev.UserName,
+		"office_id":      ev.OfficeID,
+		"vehicle_id":     ev.VehicleID,
+		"location":       location,
+		"source":         string(ev.Source),
+		"correlation_id": ev.CorrelationID,
+		"metadata":       ev.Metadata,
+	}
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"bytes"
+	"io"
 	"net/http"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -9,10 +13,13 @@ import (
 	"github.com/google/uuid"
 
 	pricingdomain "ms-parcel-core/internal/parcel/parcel_pricing/domain"
+	"ms-parcel-core/internal/parcel/parcel_pricing/infrastructure/pricetable"
 	pricingusecase "ms-parcel-core/internal/parcel/parcel_pricing/usecase"
 	"ms-parcel-core/internal/pkg/util/apperror"
 )
 
+const priceRuleImportMaxBytes = 5 << 20
+
 type PriceRuleRequest struct {
 	ShipmentType        string  `json:"shipment_type" binding:"required"`
 	OriginOfficeID      string  `json:"origin_office_id" binding:"required"`
@@ -38,14 +45,80 @@ type PriceRuleResponse struct {
 	UpdatedAt           string  `json:"updated_at"`
 }
 
+type PriceRuleCandidateResponse struct {
+	Rule   PriceRuleResponse `json:"rule"`
+	Score  int               `json:"score"`
+	Winner bool              `json:"winner"`
+	Tied   bool              `json:"tied"`
+}
+
+type PriceRuleSimulationResponse struct {
+	ShipmentType        string                       `json:"shipment_type"`
+	OriginOfficeID      string                       `json:"origin_office_id"`
+	DestinationOfficeID string                       `json:"destination_office_id"`
+	Winner              *PriceRuleResponse           `json:"winner"`
+	Ambiguous           bool                         `json:"ambiguous"`
+	Candidates          []PriceRuleCandidateResponse `json:"candidates"`
+}
+
+type PriceRouteResponse struct {
+	ShipmentType        string `json:"shipment_type"`
+	OriginOfficeID      string `json:"origin_office_id"`
+	DestinationOfficeID string `json:"destination_office_id"`
+}
+
+type PriceRuleTieResponse struct {
+	RuleIDs  []string             `json:"rule_ids"`
+	Score    int                  `json:"score"`
+	Priority int                  `json:"priority"`
+	Routes   int                  `json:"routes"`
+	Examples []PriceRouteResponse `json:"examples"`
+}
+
+type UnreachablePriceRuleResponse struct {
+	Rule       PriceRuleResponse `json:"rule"`
+	Reason     string            `json:"reason"`
+	ShadowedBy []string          `json:"shadowed_by"`
+}
+
+type PriceRuleAnalysisResponse struct {
+	OfficeIDs          []string                       `json:"office_ids"`
+	ShipmentTypes      []string                       `json:"shipment_types"`
+	RoutesEvaluated    int                            `json:"routes_evaluated"`
+	Ties               []PriceRuleTieResponse         `json:"ties"`
+	Unreachable        []UnreachablePriceRuleResponse `json:"unreachable"`
+	Uncovered          []PriceRouteResponse           `json:"uncovered"`
+	UncoveredTotal     int                            `json:"uncovered_total"`
+	UncoveredTruncated bool                           `json:"uncovered_truncated"`
+}
+
 type PriceRuleHandler struct {
-	createUC *pricingusecase.CreatePriceRuleUseCase
-	updateUC *pricingusecase.UpdatePriceRuleUseCase
-	listUC   *pricingusecase.ListPriceRulesUseCase
+	createUC   *pricingusecase.CreatePriceRuleUseCase
+	updateUC   *pricingusecase.UpdatePriceRuleUseCase
+	listUC     *pricingusecase.ListPriceRulesUseCase
+	importUC   *pricingusecase.ImportPriceRulesUseCase
+	exportUC   *pricingusecase.ExportPriceRulesUseCase
+	simulateUC *pricingusecase.SimulatePriceRuleUseCase
+	analyzeUC  *pricingusecase.AnalyzePriceRulesUseCase
 }
 
-func NewPriceRuleHandler(createUC *pricingusecase.CreatePriceRuleUseCase, updateUC *pricingusecase.UpdatePriceRuleUseCase, listUC *pricingusecase.ListPriceRulesUseCase) *PriceRuleHandler {
-	return &PriceRuleHandler{createUC: createUC, updateUC: updateUC, listUC: listUC}
+func NewPriceRuleHandler(
+	createUC *pricingusecase.CreatePriceRuleUseCase,
+	updateUC *pricingusecase.UpdatePriceRuleUseCase,
+	listUC *pricingusecase.ListPriceRulesUseCase,
+	importUC *pricingusecase.ImportPriceRulesUseCase,
+	exportUC *pricingusecase.ExportPriceRulesUseCase,
+	simulateUC *pricingusecase.SimulatePriceRuleUseCase,
+	analyzeUC *pricingusecase.AnalyzePriceRulesUseCase) *PriceRuleHandler {
+	return &PriceRuleHandler{
+		createUC:   createUC,
+		updateUC:   updateUC,
+		listUC:     listUC,
+		importUC:   importUC,
+		exportUC:   exportUC,
+		simulateUC: simulateUC,
+		analyzeUC:  analyzeUC,
+	}
 }
 
 // Create godoc
@@ -187,6 +260,294 @@ func (h *PriceRuleHandler) List(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"success": true, "data": out})
 }
 
+// Import godoc
+// @Summary Importar reglas de precios (CSV/XLSX)
+// @Description Importa reglas de precios de forma masiva desde un archivo CSV o XLSX (campo multipart "file" o body crudo). Columnas: shipment_type, origin_office_id, destination_office_id, unit, price, currency, priority (opcional, default 0), active (opcional, default true). Cada fila se valida con las mismas reglas que POST /pricing/rules. Upsert por clave shipment_type + origin_office_id + destination_office_id. Con dry_run=true solo devuelve el reporte por fila sin persistir. Si alguna fila es inválida no se aplica ningún cambio.
+// @Tags Pricing
+// @Accept multipart/form-data
+// @Accept text/csv
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param file formData file false "Archivo CSV o XLSX"
+// @Param dry_run query bool false "Solo validar, sin persistir (default: false)"
+// @Param format query string false "Formato del archivo si no se puede inferir (csv, xlsx)"
+// @Success 200 {object} handler.AnyDataEnvelope "Reporte de importación por fila"
+// @Failure 400 {object} handler.ErrorResponse "Archivo inválido o filas con errores (details contiene el reporte)"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /pricing/rules/import [post]
+func (h *PriceRuleHandler) Import(c *gin.Context) {
+	tenantID, _ := c.Get("tenant_id")
+	tenant := strings.TrimSpace(anyToString(tenantID))
+	if tenant == "" {
+		_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+		return
+	}
+
+	dryRun := false
+	if v := strings.TrimSpace(c.Query("dry_run")); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			_ = c.Error(apperror.NewBadRequest("validation_error", "dry_run inválido", map[string]any{"field": "dry_run"}))
+			return
+		}
+		dryRun = b
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, priceRuleImportMaxBytes)
+
+	format := strings.ToLower(strings.TrimSpace(c.Query("format")))
+	var body io.Reader
+	if fh, err := c.FormFile("file"); err == nil {
+		f, err := fh.Open()
+		if err != nil {
+			_ = c.Error(apperror.NewBadRequest("invalid_file", "no se pudo leer el archivo", map[string]any{"error": err.Error()}))
+			return
+		}
+		defer f.Close()
+		body = f
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fh.Filename)), ".")
+		}
+	} else {
+		body = c.Request.Body
+		if format == "" {
+			ct := strings.ToLower(c.ContentType())
+			if strings.Contains(ct, "spreadsheetml") {
+				format = pricetable.FormatXLSX
+			} else {
+				format = pricetable.FormatCSV
+			}
+		}
+	}
+
+	var records [][]string
+	var err error
+	switch format {
+	case pricetable.FormatCSV, "txt":
+		records, err = pricetable.ReadCSV(body)
+	case pricetable.FormatXLSX:
+		records, err = pricetable.ReadXLSX(body)
+	default:
+		err = apperror.NewBadRequest("validation_error", "formato no soportado", map[string]any{"field": "format", "allowed": []string{pricetable.FormatCSV, pricetable.FormatXLSX}})
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	rows, err := pricetable.Decode(records)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	out, err := h.importUC.Execute(c.Request.Context(), pricingusecase.ImportPriceRulesInput{
+		TenantID: tenant,
+		DryRun:   dryRun,
+		Rows:     rows,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": out})
+}
+
+// Export godoc
+// @Summary Exportar tabla de precios activa
+// @Description Exporta las reglas de precios activas del tenant en CSV (default) o XLSX, con las mismas columnas que acepta POST /pricing/rules/import.
+// @Tags Pricing
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param format query string false "Formato de salida (csv, xlsx)"
+// @Success 200 {file} file "Archivo con la tabla de precios activa"
+// @Failure 400 {object} handler.ErrorResponse "Formato no soportado"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /pricing/rules/export [get]
+func (h *PriceRuleHandler) Export(c *gin.Context) {
+	tenantID, _ := c.Get("tenant_id")
+	tenant := strings.TrimSpace(anyToString(tenantID))
+	if tenant == "" {
+		_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+		return
+	}
+
+	format := strings.ToLower(strings.TrimSpace(c.DefaultQuery("format", pricetable.FormatCSV)))
+	if format != pricetable.FormatCSV && format != pricetable.FormatXLSX {
+		_ = c.Error(apperror.NewBadRequest("validation_error", "formato no soportado", map[string]any{"field": "format", "allowed": []string{pricetable.FormatCSV, pricetable.FormatXLSX}}))
+		return
+	}
+
+	rules, err := h.exportUC.Execute(c.Request.Context(), tenant)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	contentType := "text/csv; charset=utf-8"
+	if format == pricetable.FormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		err = pricetable.WriteXLSX(&buf, rules)
+	} else {
+		err = pricetable.WriteCSV(&buf, rules)
+	}
+	if err != nil {
+		_ = c.Error(apperror.NewInternal("internal_error", "no se pudo generar el archivo", map[string]any{"error": err.Error()}))
+		return
+	}
+
+	filename := "price_rules_" + time.Now().UTC().Format("20060102") + "." + format
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// Simulate godoc
+// @Summary Simular selección de regla de precios
+// @Description Para un tipo de envío y una ruta origen/destino devuelve todas las reglas candidatas con su puntaje de especificidad (exacto=10, comodín=1 por lado) y la regla ganadora, con el mismo criterio que se usa al cotizar ítems (especificidad y luego priority). ambiguous=true indica empate entre las mejores reglas. Las reglas inactivas que coinciden se listan al final sin poder ganar.
+// @Tags Pricing
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param shipment_type query string true "Tipo de envío (BUS, CARGUERO)"
+// @Param origin_office_id query string true "Oficina origen"
+// @Param destination_office_id query string true "Oficina destino"
+// @Success 200 {object} handler.AnyDataEnvelope "Candidatas y regla ganadora"
+// @Failure 400 {object} handler.ErrorResponse "Parámetros inválidos"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /pricing/rules/simulate [get]
+func (h *PriceRuleHandler) Simulate(c *gin.Context) {
+	tenantID, _ := c.Get("tenant_id")
+	tenant := strings.TrimSpace(anyToString(tenantID))
+	if tenant == "" {
+		_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+		return
+	}
+
+	out, err := h.simulateUC.Execute(c.Request.Context(), pricingusecase.SimulatePriceRuleInput{
+		TenantID:            tenant,
+		ShipmentType:        c.Query("shipment_type"),
+		OriginOfficeID:      c.Query("origin_office_id"),
+		DestinationOfficeID: c.Query("destination_office_id"),
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	resp := PriceRuleSimulationResponse{
+		ShipmentType:        out.ShipmentType,
+		OriginOfficeID:      out.OriginOfficeID,
+		DestinationOfficeID: out.DestinationOfficeID,
+		Ambiguous:           out.Ambiguous,
+		Candidates:          make([]PriceRuleCandidateResponse, 0, len(out.Candidates)),
+	}
+	if out.Winner != nil {
+		w := toPriceRuleResponse(*out.Winner)
+		resp.Winner = &w
+	}
+	for _, cand := range out.Candidates {
+		resp.Candidates = append(resp.Candidates, PriceRuleCandidateResponse{
+			Rule:   toPriceRuleResponse(cand.Rule),
+			Score:  cand.Score,
+			Winner: cand.Winner,
+			Tied:   cand.Tied,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": resp})
+}
+
+// Analysis godoc
+// @Summary Analizar conflictos de la tabla de precios
+// @Description Evalúa todas las rutas origen->destino entre las oficinas presentes en las reglas (más office_ids opcionales) por tipo de envío. Reporta empates ambiguos (mismo puntaje y priority), reglas activas inalcanzables (SHADOWED: siempre superadas por otra; NO_ROUTE: no aplican a ninguna ruta evaluada) y rutas sin ninguna regla que las cubra.
+// @Tags Pricing
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param office_ids query string false "Oficinas adicionales a evaluar, separadas por coma"
+// @Param shipment_types query string false "Tipos de envío a evaluar, separados por coma (default: los presentes en reglas)"
+// @Success 200 {object} handler.AnyDataEnvelope "Reporte de análisis"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /pricing/rules/analysis [get]
+func (h *PriceRuleHandler) Analysis(c *gin.Context) {
+	tenantID, _ := c.Get("tenant_id")
+	tenant := strings.TrimSpace(anyToString(tenantID))
+	if tenant == "" {
+		_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+		return
+	}
+
+	out, err := h.analyzeUC.Execute(c.Request.Context(), pricingusecase.AnalyzePriceRulesInput{
+		TenantID:      tenant,
+		OfficeIDs:     splitCSVQuery(c.Query("office_ids")),
+		ShipmentTypes: splitCSVQuery(c.Query("shipment_types")),
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	resp := PriceRuleAnalysisResponse{
+		OfficeIDs:          out.OfficeIDs,
+		ShipmentTypes:      out.ShipmentTypes,
+		RoutesEvaluated:    out.RoutesEvaluated,
+		Ties:               make([]PriceRuleTieResponse, 0, len(out.Ties)),
+		Unreachable:        make([]UnreachablePriceRuleResponse, 0, len(out.Unreachable)),
+		Uncovered:          toPriceRouteResponses(out.Uncovered),
+		UncoveredTotal:     out.UncoveredTotal,
+		UncoveredTruncated: out.UncoveredTruncated,
+	}
+	for _, t := range out.Ties {
+		resp.Ties = append(resp.Ties, PriceRuleTieResponse{
+			RuleIDs:  t.RuleIDs,
+			Score:    t.Score,
+			Priority: t.Priority,
+			Routes:   t.Routes,
+			Examples: toPriceRouteResponses(t.Examples),
+		})
+	}
+	for _, u := range out.Unreachable {
+		resp.Unreachable = append(resp.Unreachable, UnreachablePriceRuleResponse{
+			Rule:       toPriceRuleResponse(u.Rule),
+			Reason:     u.Reason,
+			ShadowedBy: u.ShadowedBy,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": resp})
+}
+
+func toPriceRouteResponses(routes []pricingusecase.PriceRoute) []PriceRouteResponse {
+	out := make([]PriceRouteResponse, 0, len(routes))
+	for _, r := range routes {
+		out = append(out, PriceRouteResponse{
+			ShipmentType:        r.ShipmentType,
+			OriginOfficeID:      r.OriginOfficeID,
+			DestinationOfficeID: r.DestinationOfficeID,
+		})
+	}
+	return out
+}
+
+func splitCSVQuery(v string) []string {
+	out := make([]string, 0)
+	for _, part := range strings.Split(v, ",") {
+		if p := strings.TrimSpace(part); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
 func toPriceRuleResponse(r pricingdomain.PriceRule) PriceRuleResponse {
 	return PriceRuleResponse{
 		ID:                  r.ID,
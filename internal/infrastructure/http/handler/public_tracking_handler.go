@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	coreusecase "ms-parcel-core/internal/parcel/parcel_core/usecase"
+)
+
+// publicTrackingDocumentHeader lleva el segundo factor; se prefiere al query param para que no
+// quede en los logs de proxies y gateways.
+const publicTrackingDocumentHeader = "X-Recipient-Document-Last4"
+
+type PublicTrackingOfficeResponse struct {
+	Name string `json:"name,omitempty"`
+	City string `json:"city,omitempty"`
+}
+
+type PublicTrackingEventResponse struct {
+	EventType  string                        `json:"event_type"`
+	OccurredAt string                        `json:"occurred_at"`
+	Office     *PublicTrackingOfficeResponse `json:"office,omitempty"`
+}
+
+type PublicTrackingBalanceResponse struct {
+	Currency string  `json:"currency"`
+	Balance  float64 `json:"balance"`
+	Status   string  `json:"status"`
+}
+
+type PublicTrackingDetailResponse struct {
+	ShipmentType  string                         `json:"shipment_type"`
+	DepartureAt   *string                        `json:"departure_at,omitempty"`
+	DeliveredAt   *string                        `json:"delivered_at,omitempty"`
+	ItemsCount    int                            `json:"items_count"`
+	TotalWeightKg float64                        `json:"total_weight_kg"`
+	Payment       *PublicTrackingBalanceResponse `json:"payment,omitempty"`
+}
+
+type PublicTrackingResponse struct {
+	TrackingCode string                        `json:"tracking_code"`
+	Status       string                        `json:"status"`
+	Origin       *PublicTrackingOfficeResponse `json:"origin,omitempty"`
+	Destination  *PublicTrackingOfficeResponse `json:"destination,omitempty"`
+	CreatedAt    string                        `json:"created_at"`
+	Timeline     []PublicTrackingEventResponse `json:"timeline"`
+	// Verified indica si se validó el documento del destinatario; solo entonces se incluye Detail
+	Verified bool                          `json:"verified"`
+	Detail   *PublicTrackingDetailResponse `json:"detail,omitempty"`
+}
+
+type PublicTrackingHandler struct {
+	uc *coreusecase.GetPublicTrackingUseCase
+}
+
+func NewPublicTrackingHandler(uc *coreusecase.GetPublicTrackingUseCase) *PublicTrackingHandler {
+	return &PublicTrackingHandler{uc: uc}
+}
+
+// Get godoc
+// @Summary Seguimiento público por tracking code
+// @Description Endpoint sin autenticación para que el remitente o destinatario consulte su envío. Devuelve estado, oficinas de origen/destino y la línea de tiempo (sin usuarios, ids internos ni metadata). Con los últimos 4 dígitos del documento del destinatario (header X-Recipient-Document-Last4 o query document_last4) agrega detalle: tipo de envío, salida, items, peso y saldo. Limitado por IP; los intentos fallidos de verificación se limitan por tracking code.
+// @Tags Public
+// @Produce json
+// @Param code path string true "Tracking code (p.ej. QBA7K2MX)"
+// @Param X-Recipient-Document-Last4 header string false "Últimos 4 dígitos del documento del destinatario"
+// @Param document_last4 query string false "Alternativa al header X-Recipient-Document-Last4"
+// @Success 200 {object} handler.PublicTrackingEnvelope "Seguimiento del envío"
+// @Failure 400 {object} handler.ErrorResponse "document_last4 inválido"
+// @Failure 403 {object} handler.ErrorResponse "No se pudo verificar al destinatario"
+// @Failure 404 {object} handler.ErrorResponse "Envío no encontrado"
+// @Failure 429 {object} handler.ErrorResponse "Demasiadas solicitudes o intentos de verificación"
+// @Failure 503 {object} handler.ErrorResponse "No se pudo consultar el documento del destinatario"
+// @Router /public/track/{code} [get]
+func (h *PublicTrackingHandler) Get(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+
+	last4 := strings.TrimSpace(c.GetHeader(publicTrackingDocumentHeader))
+	if last4 == "" {
+		last4 = strings.TrimSpace(c.Query("document_last4"))
+	}
+
+	out, err := h.uc.Execute(c.Request.Context(), coreusecase.GetPublicTrackingInput{
+		TrackingCode:  c.Param("code"),
+		DocumentLast4: last4,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": toPublicTrackingResponse(out)})
+}
+
+func toPublicTrackingResponse(out *coreusecase.GetPublicTrackingResult) PublicTrackingResponse {
+	timeline := make([]PublicTrackingEventResponse, 0, len(out.Timeline))
+	for _, e := range out.Timeline {
+		timeline = append(timeline, PublicTrackingEventResponse{
+			EventType:  e.EventType,
+			OccurredAt: e.OccurredAt.UTC().Format(time.RFC3339),
+			Office:     toPublicTrackingOffice(e.Office),
+		})
+	}
+
+	resp := PublicTrackingResponse{
+		TrackingCode: out.TrackingCode,
+		Status:       out.Status,
+		Origin:       toPublicTrackingOffice(out.Origin),
+		Destination:  toPublicTrackingOffice(out.Destination),
+		CreatedAt:    out.CreatedAt.UTC().Format(time.RFC3339),
+		Timeline:     timeline,
+		Verified:     out.Verified,
+	}
+	if d := out.Detail; d != nil {
+		detail := &PublicTrackingDetailResponse{
+			ShipmentType:  d.ShipmentType,
+			DepartureAt:   publicTrackingTime(d.DepartureAt),
+			DeliveredAt:   publicTrackingTime(d.DeliveredAt),
+			ItemsCount:    d.ItemsCount,
+			TotalWeightKg: d.TotalWeightKg,
+		}
+		if d.Payment != nil {
+			detail.Payment = &PublicTrackingBalanceResponse{Currency: d.Payment.Currency, Balance: d.Payment.Balance, Status: d.Payment.Status}
+		}
+		resp.Detail = detail
+	}
+	return resp
+}
+
+func toPublicTrackingOffice(o *coreusecase.PublicTrackingOffice) *PublicTrackingOfficeResponse {
+	if o == nil {
+		return nil
+	}
+	return &PublicTrackingOfficeResponse{Name: o.Name, City: o.City}
+}
+
+func publicTrackingTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.UTC().Format(time.RFC3339)
+	return &s
+}
@@ -39,3 +39,8 @@ type ParcelListResponseEnvelope struct {
 	Success bool                   `json:"success" example:"true"`
 	Data    dto.ParcelListResponse `json:"data"`
 }
+
+type PublicTrackingEnvelope struct {
+	Success bool                   `json:"success" example:"true"`
+	Data    PublicTrackingResponse `json:"data"`
+}
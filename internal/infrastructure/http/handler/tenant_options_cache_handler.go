@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	parcelclients "ms-parcel-core/internal/parcel/parcel_core/infrastructure/clients"
+	"ms-parcel-core/internal/pkg/util/apperror"
+)
+
+type InvalidateTenantOptionsRequest struct {
+	// Sin tenant_id se usa el tenant del token; all=true vacía el cache completo
+	TenantID *string `json:"tenant_id" binding:"omitempty,max=100"`
+	All      bool    `json:"all"`
+}
+
+type TenantOptionsCacheHandler struct {
+	cache *parcelclients.CachedTenantOptionsProvider
+}
+
+func NewTenantOptionsCacheHandler(cache *parcelclients.CachedTenantOptionsProvider) *TenantOptionsCacheHandler {
+	return &TenantOptionsCacheHandler{cache: cache}
+}
+
+// Invalidate godoc
+// @Summary Invalidar cache de opciones de tenant
+// @Description Descarta las opciones cacheadas de un tenant (o de todos con all=true) para que la próxima consulta vaya a ms-tenant-config. Pensado para llamarse tras un cambio de configuración. Requiere X-Admin-Token.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Token de administración"
+// @Param Authorization header string false "Bearer token"
+// @Param payload body InvalidateTenantOptionsRequest false "Tenant a invalidar"
+// @Success 200 {object} handler.AnyDataEnvelope "Cache invalidado; devuelve estadísticas"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: payload malformado o tenant faltante"
+// @Failure 401 {object} handler.ErrorResponse "Token de administración inválido"
+// @Failure 403 {object} handler.ErrorResponse "Administración deshabilitada"
+// @Router /admin/cache/tenant-options/invalidate [post]
+func (h *TenantOptionsCacheHandler) Invalidate(c *gin.Context) {
+	var req InvalidateTenantOptionsRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			_ = c.Error(apperror.NewBadRequest("validation_error", "payload inválido", map[string]any{"error": err.Error()}))
+			return
+		}
+	}
+
+	if req.All {
+		h.cache.InvalidateAll()
+		c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{"invalidated": "all", "stats": h.cache.Stats()}})
+		return
+	}
+
+	tenant := ""
+	if req.TenantID != nil {
+		tenant = strings.TrimSpace(*req.TenantID)
+	}
+	if tenant == "" {
+		tenantID, _ := c.Get("tenant_id")
+		tenant = strings.TrimSpace(anyToString(tenantID))
+	}
+	if tenant == "" {
+		_ = c.Error(apperror.NewBadRequest("validation_error", "tenant_id requerido", map[string]any{"field": "tenant_id"}))
+		return
+	}
+
+	h.cache.Invalidate(tenant)
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{"invalidated": tenant, "stats": h.cache.Stats()}})
+}
+
+// Stats godoc
+// @Summary Estadísticas del cache de opciones de tenant
+// @Description Devuelve aciertos (hits), aciertos con valor vencido (stale_hits), fallos (misses), refrescos en segundo plano, desalojos y entradas actuales del cache. Requiere X-Admin-Token.
+// @Tags Admin
+// @Produce json
+// @Param X-Admin-Token header string true "Token de administración"
+// @Success 200 {object} handler.AnyDataEnvelope "Estadísticas del cache"
+// @Failure 401 {object} handler.ErrorResponse "Token de administración inválido"
+// @Failure 403 {object} handler.ErrorResponse "Administración deshabilitada"
+// @Router /admin/cache/tenant-options/stats [get]
+func (h *TenantOptionsCacheHandler) Stats(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": h.cache.Stats()})
+}
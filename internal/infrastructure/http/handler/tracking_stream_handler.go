@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"ms-parcel-core/internal/parcel/parcel_tracking/domain"
+	"ms-parcel-core/internal/parcel/parcel_tracking/usecase"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+)
+
+// trackingStreamRetryMs es el "retry" que se sugiere al EventSource para reconectar.
+const trackingStreamRetryMs = 3000
+
+type TrackingStreamHandler struct {
+	uc        *usecase.StreamTrackingUseCase
+	heartbeat time.Duration
+	shutdown  <-chan struct{}
+	logger    *slog.Logger
+}
+
+// NewTrackingStreamHandler: en cada heartbeat además de mantener viva la conexión se relee el
+// repositorio, así llegan también los eventos registrados en otras réplicas. Al cerrarse shutdown se
+// cortan los streams para que el apagado no espere a los clientes.
+func NewTrackingStreamHandler(uc *usecase.StreamTrackingUseCase, heartbeat time.Duration, shutdown <-chan struct{}, logger *slog.Logger) *TrackingStreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	return &TrackingStreamHandler{uc: uc, heartbeat: heartbeat, shutdown: shutdown, logger: logging.OrDiscard(logger)}
+}
+
+// Stream godoc
+// @Summary Stream de eventos de tracking (SSE)
+// @Description Server-Sent Events con los eventos de tracking del tenant a medida que se registran. Filtros opcionales por envío, oficina (origen/destino del envío u oficina del evento) o vehículo. Cada evento lleva `id: <seq>`; al reconectar, el header Last-Event-ID (o el query last_event_id) reenvía lo ocurrido desde ese id. Sin Last-Event-ID empieza desde el evento más reciente. Envía un comentario `: ping` en cada heartbeat.
+// @Tags ParcelTracking
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param Last-Event-ID header string false "Seq del último evento recibido"
+// @Param last_event_id query string false "Alternativa al header Last-Event-ID"
+// @Param parcel_id query string false "UUID del envío" Format(uuid)
+// @Param office_id query string false "UUID de la oficina" Format(uuid)
+// @Param vehicle_id query string false "UUID del vehículo" Format(uuid)
+// @Success 200 {string} string "Stream text/event-stream con eventos `tracking`"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: filtro o Last-Event-ID inválido"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /tracking/stream [get]
+func (h *TrackingStreamHandler) Stream(c *gin.Context) {
+	tenantID, _ := c.Get("tenant_id")
+	tenant := strings.TrimSpace(anyToString(tenantID))
+	if tenant == "" {
+		_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if strings.TrimSpace(lastEventID) == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	ctx := c.Request.Context()
+	stream, err := h.uc.Open(ctx, usecase.StreamTrackingInput{
+		TenantID:    tenant,
+		ParcelID:    c.Query("parcel_id"),
+		OfficeID:    c.Query("office_id"),
+		VehicleID:   c.Query("vehicle_id"),
+		LastEventID: lastEventID,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer stream.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Evita que nginx acumule el stream en buffer
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	if _, err := fmt.Fprintf(c.Writer, "retry: %d\n\n", trackingStreamRetryMs); err != nil {
+		return
+	}
+	c.Writer.Flush()
+
+	// Backlog desde Last-Event-ID (o nada si empieza desde ahora)
+	if !h.push(c, stream, tenant) {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.shutdown:
+			return
+		case <-stream.Notifications():
+			if !h.push(c, stream, tenant) {
+				return
+			}
+		case <-heartbeat.C:
+			if !h.push(c, stream, tenant) {
+				return
+			}
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// push escribe los eventos pendientes; devuelve false si hay que cerrar el stream. Ante un error de
+// lectura se cierra: el cliente reconecta con Last-Event-ID y no pierde eventos.
+func (h *TrackingStreamHandler) push(c *gin.Context, stream *usecase.TrackingStream, tenantID string) bool {
+	ctx := c.Request.Context()
+	for {
+		evs, more, err := stream.Next(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				h.logger.WarnContext(ctx, "no se pudieron leer eventos para el stream de tracking", "tenant_id", tenantID, "error", err)
+			}
+			return false
+		}
+		for _, ev := range evs {
+			if err := writeTrackingStreamEvent(c.Writer, ev); err != nil {
+				return false
+			}
+		}
+		if len(evs) > 0 {
+			c.Writer.Flush()
+		}
+		if !more {
+			return true
+		}
+	}
+}
+
+func writeTrackingStreamEvent(w gin.ResponseWriter, ev domain.TrackingEvent) error {
+	payload := trackingEventToMap(ev)
+	payload["seq"] = ev.Seq
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: tracking\ndata: %s\n\n", ev.Seq, data)
+	return err
+}
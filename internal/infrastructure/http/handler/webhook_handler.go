@@ -0,0 +1,466 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	webhookdomain "ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	webhookport "ms-parcel-core/internal/parcel/parcel_webhook/port"
+	webhookusecase "ms-parcel-core/internal/parcel/parcel_webhook/usecase"
+	"ms-parcel-core/internal/pkg/util/apperror"
+)
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required,max=500"`
+	EventTypes  []string `json:"event_types"`
+	Active      *bool    `json:"active"`
+	Description *string  `json:"description" binding:"omitempty,max=200"`
+}
+
+// UpdateWebhookRequest es parcial: los campos ausentes no se modifican.
+type UpdateWebhookRequest struct {
+	URL          *string   `json:"url" binding:"omitempty,max=500"`
+	EventTypes   *[]string `json:"event_types"`
+	Active       *bool     `json:"active"`
+	Description  *string   `json:"description" binding:"omitempty,max=200"`
+	RotateSecret bool      `json:"rotate_secret"`
+}
+
+type WebhookResponse struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+	// Secret solo se devuelve al crear o rotar; el resto de las respuestas muestra SecretHint
+	Secret          string  `json:"secret,omitempty"`
+	SecretHint      string  `json:"secret_hint"`
+	Description     *string `json:"description,omitempty"`
+	CreatedByUserID *string `json:"created_by_user_id,omitempty"`
+	CreatedAt       string  `json:"created_at"`
+	UpdatedAt       string  `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string  `json:"id"`
+	EventID        string  `json:"event_id"`
+	EventType      string  `json:"event_type"`
+	ParcelID       string  `json:"parcel_id"`
+	Status         string  `json:"status"`
+	Attempts       int     `json:"attempts"`
+	NextAttemptAt  *string `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int    `json:"last_status_code,omitempty"`
+	LastError      *string `json:"last_error,omitempty"`
+	DeliveredAt    *string `json:"delivered_at,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+	Payload        string  `json:"payload"`
+}
+
+type WebhookDeliveryListPagination struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Count  int `json:"count"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Items      []WebhookDeliveryResponse     `json:"items"`
+	Pagination WebhookDeliveryListPagination `json:"pagination"`
+}
+
+type WebhookHandler struct {
+	createUC         *webhookusecase.CreateWebhookSubscriptionUseCase
+	updateUC         *webhookusecase.UpdateWebhookSubscriptionUseCase
+	getUC            *webhookusecase.GetWebhookSubscriptionUseCase
+	listUC           *webhookusecase.ListWebhookSubscriptionsUseCase
+	deleteUC         *webhookusecase.DeleteWebhookSubscriptionUseCase
+	listDeliveriesUC *webhookusecase.ListWebhookDeliveriesUseCase
+	retryDeliveryUC  *webhookusecase.RetryWebhookDeliveryUseCase
+}
+
+func NewWebhookHandler(
+	createUC *webhookusecase.CreateWebhookSubscriptionUseCase,
+	updateUC *webhookusecase.UpdateWebhookSubscriptionUseCase,
+	getUC *webhookusecase.GetWebhookSubscriptionUseCase,
+	listUC *webhookusecase.ListWebhookSubscriptionsUseCase,
+	deleteUC *webhookusecase.DeleteWebhookSubscriptionUseCase,
+	listDeliveriesUC *webhookusecase.ListWebhookDeliveriesUseCase,
+	retryDeliveryUC *webhookusecase.RetryWebhookDeliveryUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		createUC:         createUC,
+		updateUC:         updateUC,
+		getUC:            getUC,
+		listUC:           listUC,
+		deleteUC:         deleteUC,
+		listDeliveriesUC: listDeliveriesUC,
+		retryDeliveryUC:  retryDeliveryUC,
+	}
+}
+
+// Create godoc
+// @Summary Crear suscripción de webhook
+// @Description Registra un endpoint del tenant que recibe los eventos de tracking de sus envíos (PARCEL_CREATED … PARCEL_DELIVERED, PARCEL_ITEM_ADDED/REMOVED). event_types vacío = todos. Cada entrega es un POST JSON firmado en el header X-Webhook-Signature ("t=<unix>,v1=<hex>", HMAC-SHA256 del secreto sobre "<unix>.<body>"). El secreto se devuelve solo en esta respuesta.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param payload body CreateWebhookRequest true "URL, tipos de evento y estado de la suscripción"
+// @Success 200 {object} handler.AnyDataEnvelope "Suscripción creada (incluye secret)"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: url inválida (debe ser https hacia un host público) o tipo de evento no soportado"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /webhooks [post]
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.NewBadRequest("validation_error", "payload inválido", map[string]any{"error": err.Error()}))
+		return
+	}
+
+	tenantID, _ := c.Get("tenant_id")
+	userID, _ := c.Get("user_id")
+	tenant := strings.TrimSpace(anyToString(tenantID))
+	if tenant == "" {
+		_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+		return
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+	out, err := h.createUC.Execute(c.Request.Context(), webhookusecase.CreateWebhookSubscriptionInput{
+		TenantID:    tenant,
+		UserID:      strings.TrimSpace(anyToString(userID)),
+		URL:         req.URL,
+		EventTypes:  req.EventTypes,
+		Active:      active,
+		Description: req.Description,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": toWebhookResponse(*out, true)})
+}
+
+// List godoc
+// @Summary Listar suscripciones de webhook
+// @Description Lista las suscripciones de webhook del tenant. El secreto no se incluye (solo secret_hint).
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Success 200 {object} handler.AnyDataEnvelope "Suscripciones del tenant"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /webhooks [get]
+func (h *WebhookHandler) List(c *gin.Context) {
+	tenantID, _ := c.Get("tenant_id")
+	tenant := strings.TrimSpace(anyToString(tenantID))
+	if tenant == "" {
+		_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+		return
+	}
+
+	subs, err := h.listUC.Execute(c.Request.Context(), tenant)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	out := make([]WebhookResponse, 0, len(subs))
+	for _, s := range subs {
+		out = append(out, toWebhookResponse(s, false))
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": out})
+}
+
+// GetByID godoc
+// @Summary Obtener suscripción de webhook
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param id path string true "UUID de la suscripción" Format(uuid)
+// @Success 200 {object} handler.AnyDataEnvelope "Suscripción"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: id inválido"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 404 {object} handler.ErrorResponse "Suscripción no encontrada"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandler) GetByID(c *gin.Context) {
+	id, ok := webhookPathID(c, "id")
+	if !ok {
+		return
+	}
+	tenantID, _ := c.Get("tenant_id")
+
+	out, err := h.getUC.Execute(c.Request.Context(), strings.TrimSpace(anyToString(tenantID)), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": toWebhookResponse(*out, false)})
+}
+
+// Update godoc
+// @Summary Actualizar suscripción de webhook
+// @Description Actualización parcial: url, event_types, active y description. Con rotate_secret=true se genera un secreto nuevo, que se devuelve solo en esta respuesta; las entregas pendientes se firman con el secreto nuevo.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param id path string true "UUID de la suscripción" Format(uuid)
+// @Param payload body UpdateWebhookRequest true "Campos a modificar"
+// @Success 200 {object} handler.AnyDataEnvelope "Suscripción actualizada"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: id, url (debe ser https hacia un host público) o tipo de evento inválido"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 404 {object} handler.ErrorResponse "Suscripción no encontrada"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /webhooks/{id} [patch]
+func (h *WebhookHandler) Update(c *gin.Context) {
+	id, ok := webhookPathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.NewBadRequest("validation_error", "payload inválido", map[string]any{"error": err.Error()}))
+		return
+	}
+
+	tenantID, _ := c.Get("tenant_id")
+	out, err := h.updateUC.Execute(c.Request.Context(), webhookusecase.UpdateWebhookSubscriptionInput{
+		TenantID:     strings.TrimSpace(anyToString(tenantID)),
+		ID:           id,
+		URL:          req.URL,
+		EventTypes:   req.EventTypes,
+		Active:       req.Active,
+		Description:  req.Description,
+		RotateSecret: req.RotateSecret,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": toWebhookResponse(*out, req.RotateSecret)})
+}
+
+// Delete godoc
+// @Summary Eliminar suscripción de webhook
+// @Description Elimina la suscripción. Las entregas pendientes pasan a DEAD; el log de entregas se conserva.
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param id path string true "UUID de la suscripción" Format(uuid)
+// @Success 200 {object} handler.AnyDataEnvelope "Suscripción eliminada"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: id inválido"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 404 {object} handler.ErrorResponse "Suscripción no encontrada"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id, ok := webhookPathID(c, "id")
+	if !ok {
+		return
+	}
+	tenantID, _ := c.Get("tenant_id")
+
+	if err := h.deleteUC.Execute(c.Request.Context(), strings.TrimSpace(anyToString(tenantID)), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{"deleted": true}})
+}
+
+// ListDeliveries godoc
+// @Summary Log de entregas de un webhook
+// @Description Lista las entregas de la suscripción (más recientes primero) con estado, intentos, último status HTTP y error, y el payload enviado.
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param id path string true "UUID de la suscripción" Format(uuid)
+// @Param status query string false "Filtra por estado" Enums(PENDING, DELIVERING, DELIVERED, DEAD)
+// @Param parcel_id query string false "Filtra por envío" Format(uuid)
+// @Param limit query int false "Límite de resultados (default: 50, max: 200)"
+// @Param offset query int false "Desplazamiento (default: 0)"
+// @Success 200 {object} handler.AnyDataEnvelope "Entregas paginadas"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: id, status, parcel_id, limit u offset inválido"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 404 {object} handler.ErrorResponse "Suscripción no encontrada"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	id, ok := webhookPathID(c, "id")
+	if !ok {
+		return
+	}
+
+	var filters webhookport.ListWebhookDeliveryFilters
+	if s := strings.TrimSpace(c.Query("status")); s != "" {
+		st := webhookdomain.DeliveryStatus(strings.ToUpper(s))
+		filters.Status = &st
+	}
+	if p := strings.TrimSpace(c.Query("parcel_id")); p != "" {
+		if _, err := uuid.Parse(p); err != nil {
+			_ = c.Error(apperror.NewBadRequest("validation_error", "parcel_id inválido", map[string]any{"field": "parcel_id"}))
+			return
+		}
+		filters.ParcelID = &p
+	}
+
+	limit := 50
+	if l := strings.TrimSpace(c.Query("limit")); l != "" {
+		v, err := strconv.Atoi(l)
+		if err != nil {
+			_ = c.Error(apperror.NewBadRequest("validation_error", "limit inválido", map[string]any{"field": "limit"}))
+			return
+		}
+		limit = v
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	offset := 0
+	if o := strings.TrimSpace(c.Query("offset")); o != "" {
+		v, err := strconv.Atoi(o)
+		if err != nil {
+			_ = c.Error(apperror.NewBadRequest("validation_error", "offset inválido", map[string]any{"field": "offset"}))
+			return
+		}
+		offset = v
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	filters.Limit = limit
+	filters.Offset = offset
+
+	tenantID, _ := c.Get("tenant_id")
+	out, err := h.listDeliveriesUC.Execute(c.Request.Context(), webhookusecase.ListWebhookDeliveriesInput{
+		TenantID:       strings.TrimSpace(anyToString(tenantID)),
+		SubscriptionID: id,
+		Filters:        filters,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	items := make([]WebhookDeliveryResponse, 0, len(out.Items))
+	for _, d := range out.Items {
+		items = append(items, toWebhookDeliveryResponse(d))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": WebhookDeliveryListResponse{
+			Items:      items,
+			Pagination: WebhookDeliveryListPagination{Limit: limit, Offset: offset, Count: out.Count},
+		},
+	})
+}
+
+// RetryDelivery godoc
+// @Summary Reintentar entrega de webhook
+// @Description Reencola una entrega en estado DEAD con el ciclo completo de reintentos. La suscripción debe estar activa.
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer token"
+// @Param id path string true "UUID de la suscripción" Format(uuid)
+// @Param delivery_id path string true "UUID de la entrega" Format(uuid)
+// @Success 200 {object} handler.AnyDataEnvelope "Entrega reencolada (estado: PENDING)"
+// @Failure 400 {object} handler.ErrorResponse "Validación fallida: id o delivery_id inválido"
+// @Failure 401 {object} handler.ErrorResponse "No autorizado: token inválido o credenciales faltantes"
+// @Failure 404 {object} handler.ErrorResponse "Suscripción o entrega no encontrada"
+// @Failure 409 {object} handler.ErrorResponse "La entrega no está en DEAD o la suscripción está inactiva"
+// @Failure 500 {object} handler.ErrorResponse "Error interno del servidor"
+// @Router /webhooks/{id}/deliveries/{delivery_id}/retry [post]
+func (h *WebhookHandler) RetryDelivery(c *gin.Context) {
+	id, ok := webhookPathID(c, "id")
+	if !ok {
+		return
+	}
+	deliveryID, ok := webhookPathID(c, "delivery_id")
+	if !ok {
+		return
+	}
+	tenantID, _ := c.Get("tenant_id")
+
+	out, err := h.retryDeliveryUC.Execute(c.Request.Context(), strings.TrimSpace(anyToString(tenantID)), id, deliveryID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": toWebhookDeliveryResponse(*out)})
+}
+
+func webhookPathID(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(strings.TrimSpace(c.Param(name)))
+	if err != nil {
+		_ = c.Error(apperror.NewBadRequest("validation_error", name+" inválido", map[string]any{"field": name}))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func toWebhookResponse(s webhookdomain.WebhookSubscription, withSecret bool) WebhookResponse {
+	types := s.EventTypes
+	if types == nil {
+		types = []string{}
+	}
+	out := WebhookResponse{
+		ID:              s.ID,
+		URL:             s.URL,
+		EventTypes:      types,
+		Active:          s.Active,
+		SecretHint:      s.SecretHint(),
+		Description:     s.Description,
+		CreatedByUserID: s.CreatedByUserID,
+		CreatedAt:       s.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:       s.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	if withSecret {
+		out.Secret = s.Secret
+	}
+	return out
+}
+
+func toWebhookDeliveryResponse(d webhookdomain.WebhookDelivery) WebhookDeliveryResponse {
+	out := WebhookDeliveryResponse{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		ParcelID:       d.ParcelID,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt.UTC().Format(time.RFC3339),
+		Payload:        d.Payload,
+	}
+	// next_attempt_at solo tiene sentido mientras la entrega sigue en curso
+	if d.Status == webhookdomain.DeliveryStatusPending || d.Status == webhookdomain.DeliveryStatusDelivering {
+		s := d.NextAttemptAt.UTC().Format(time.RFC3339)
+		out.NextAttemptAt = &s
+	}
+	if d.DeliveredAt != nil {
+		s := d.DeliveredAt.UTC().Format(time.RFC3339)
+		out.DeliveredAt = &s
+	}
+	return out
+}
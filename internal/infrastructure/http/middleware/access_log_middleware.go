@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"ms-parcel-core/internal/pkg/util/logging"
+)
+
+// AccessLogMiddleware registra un log por request con método, ruta (template, no la URL real),
+// status, latencia, tenant y usuario. No se registran query string, headers ni body para no
+// filtrar datos sensibles (package_key, tokens).
+func AccessLogMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	logger = logging.OrDiscard(logger)
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := c.Writer.Status()
+
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		logger.LogAttrs(c.Request.Context(), level, "http request",
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("tenant_id", c.GetString("tenant_id")),
+			slog.String("user_id", c.GetString("user_id")),
+			slog.String("request_id", c.GetString("request_id")),
+		)
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"ms-parcel-core/internal/pkg/util/apperror"
+)
+
+// AdminTokenMiddleware protege los endpoints de administración con X-Admin-Token == ADMIN_API_TOKEN.
+// Sin ADMIN_API_TOKEN configurado los endpoints quedan deshabilitados.
+func AdminTokenMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		expected := strings.TrimSpace(os.Getenv("ADMIN_API_TOKEN"))
+		if expected == "" {
+			_ = c.Error(apperror.New("forbidden", "administración deshabilitada", nil, 403))
+			c.Abort()
+			return
+		}
+
+		got := strings.TrimSpace(c.GetHeader("X-Admin-Token"))
+		if subtle.ConstantTimeCompare([]byte(got), []byte(expected)) != 1 {
+			_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -2,8 +2,11 @@ package middleware
 
 import (
 	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+
+	"ms-parcel-core/internal/pkg/util/requestctx"
 )
 
 func AuthMiddleware() gin.HandlerFunc {
@@ -24,6 +27,12 @@ func AuthMiddleware() gin.HandlerFunc {
 			}
 		}
 
+		// El token se propaga a los clientes HTTP salientes (cashbox, tenant config)
+		if auth := strings.TrimSpace(c.GetHeader("Authorization")); auth != "" {
+			token := strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+			c.Request = c.Request.WithContext(requestctx.WithAuthToken(c.Request.Context(), token))
+		}
+
 		c.Next()
 	}
 }
@@ -2,13 +2,21 @@ package middleware
 
 import (
 	"errors"
+	"log/slog"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
-func ErrorMiddleware() gin.HandlerFunc {
+// ErrorMiddleware serializa el último error del request; los 5xx se registran en el log y
+// marcan el span HTTP como fallido.
+func ErrorMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	logger = logging.OrDiscard(logger)
 	return func(c *gin.Context) {
 		c.Next()
 
@@ -21,15 +29,24 @@ func ErrorMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		span := trace.SpanFromContext(c.Request.Context())
 		var appErr *apperror.AppError
 		if errors.As(last.Err, &appErr) && appErr != nil {
-			c.JSON(appErr.Status, gin.H{"success": false, "error": appErr})
+			span.SetAttributes(attribute.String("app.error_code", appErr.Code))
+			if appErr.Status >= 500 {
+				logger.ErrorContext(c.Request.Context(), "error en request", "code", appErr.Code, "status", appErr.Status, "error", last.Err)
+				tracing.RecordError(span, last.Err)
+			}
+			c.JSON(appErr.Status, gin.H{"success": false, "error": appErr.WithRequestID(c.GetString("request_id"))})
 			return
 		}
 
+		logger.ErrorContext(c.Request.Context(), "error no controlado en request", "error", last.Err)
+		tracing.RecordError(span, last.Err)
+
 		c.JSON(500, gin.H{
 			"success": false,
-			"error":   apperror.NewInternal("internal_error", "error interno", map[string]any{"error": last.Err.Error()}),
+			"error":   apperror.NewInternal("internal_error", "error interno", map[string]any{"error": last.Err.Error()}).WithRequestID(c.GetString("request_id")),
 		})
 	}
 }
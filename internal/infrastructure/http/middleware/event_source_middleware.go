@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	trackingdomain "ms-parcel-core/internal/parcel/parcel_tracking/domain"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/requestctx"
+)
+
+const (
+	// EventSourceHeader declara desde dónde opera el cliente: counter, scanner_app o api (default).
+	EventSourceHeader = "X-Event-Source"
+	// DeviceLocationHeader es la posición del dispositivo: "<lat>,<lon>" o "<lat>,<lon>,<precisión_m>".
+	DeviceLocationHeader = "X-Device-Location"
+)
+
+// EventSourceMiddleware deja en el context del request el origen y la posición del dispositivo que
+// se guardan en los eventos de tracking. Un header inválido responde 400, así que va después de
+// ErrorMiddleware.
+func EventSourceMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		source := trackingdomain.TrackingSourceAPI
+		if v := strings.TrimSpace(c.GetHeader(EventSourceHeader)); v != "" {
+			s, ok := trackingdomain.ParseTrackingSource(v)
+			if !ok || s == trackingdomain.TrackingSourceSystem {
+				_ = c.Error(apperror.NewBadRequest("validation_error", "X-Event-Source inválido", map[string]any{"field": EventSourceHeader, "allowed": []string{"counter", "scanner_app", "api"}}))
+				c.Abort()
+				return
+			}
+			source = s
+		}
+		ctx := requestctx.WithEventSource(c.Request.Context(), string(source))
+
+		if v := strings.TrimSpace(c.GetHeader(DeviceLocationHeader)); v != "" {
+			loc, ok := parseDeviceLocation(v)
+			if !ok {
+				_ = c.Error(apperror.NewBadRequest("validation_error", "X-Device-Location inválido, se espera \"<lat>,<lon>[,<precisión_m>]\"", map[string]any{"field": DeviceLocationHeader}))
+				c.Abort()
+				return
+			}
+			ctx = requestctx.WithLocation(ctx, loc)
+		}
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func parseDeviceLocation(v string) (requestctx.Location, bool) {
+	parts := strings.Split(v, ",")
+	if len(parts) != 2 && len(parts) != 3 {
+		return requestctx.Location{}, false
+	}
+	nums := make([]float64, len(parts))
+	for i, p := range parts {
+		n, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return requestctx.Location{}, false
+		}
+		nums[i] = n
+	}
+	loc := requestctx.Location{Latitude: nums[0], Longitude: nums[1]}
+	if loc.Latitude < -90 || loc.Latitude > 90 || loc.Longitude < -180 || loc.Longitude > 180 {
+		return requestctx.Location{}, false
+	}
+	if len(nums) == 3 {
+		if nums[2] < 0 {
+			return requestctx.Location{}, false
+		}
+		loc.AccuracyM = &nums[2]
+	}
+	return loc, true
+}
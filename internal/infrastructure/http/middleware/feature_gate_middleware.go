@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+)
+
+// FeatureGateMiddleware corta la ruta con feature_disabled si la funcionalidad está
+// deshabilitada para el tenant. Debe ir después de AuthMiddleware.
+func FeatureGateMiddleware(gate coreport.FeatureGate, feature string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if gate == nil {
+			c.Next()
+			return
+		}
+
+		tenant := strings.TrimSpace(c.GetString("tenant_id"))
+		if tenant == "" {
+			_ = c.Error(apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil))
+			c.Abort()
+			return
+		}
+
+		if err := gate.Require(c.Request.Context(), tenant, feature); err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
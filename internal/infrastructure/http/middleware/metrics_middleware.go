@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPObserver recibe una observación por request (implementado por las métricas Prometheus).
+type HTTPObserver interface {
+	ObserveHTTP(method string, route string, status int, d time.Duration)
+}
+
+// MetricsMiddleware mide cada request etiquetando por el template de la ruta: las rutas no
+// registradas se agrupan en "unmatched" para no crear una serie por URL.
+func MetricsMiddleware(observer HTTPObserver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if observer == nil {
+			c.Next()
+			return
+		}
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		observer.ObserveHTTP(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/ratelimit"
+)
+
+// RateLimitMiddleware limita los requests por IP del cliente. c.ClientIP solo lee X-Forwarded-For
+// si el request llega desde un proxy de TRUSTED_PROXIES; sin proxies configurados usa la IP de la
+// conexión, así un cliente no puede cambiar de clave falseando el header. Al superar el límite
+// responde 429 con Retry-After.
+func RateLimitMiddleware(limiter *ratelimit.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ok, retryAfter := limiter.Allow(c.ClientIP())
+		if !ok {
+			secs := int(math.Ceil(retryAfter.Seconds()))
+			c.Header("Retry-After", strconv.Itoa(secs))
+			_ = c.Error(apperror.New("rate_limited", "demasiadas solicitudes, intente más tarde", map[string]any{"retry_after_seconds": secs}, 429))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+)
+
+// RecoveryMiddleware reemplaza a gin.Recovery: registra el panic en el logger estructurado y
+// responde con el formato de error de la API (incluido el request_id). El handler corre dentro del
+// recover, así que debug.Stack incluye el punto del panic.
+func RecoveryMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	logger = logging.OrDiscard(logger)
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		logger.ErrorContext(c.Request.Context(), "panic en request", "route", c.FullPath(), "panic", recovered, "stack", string(debug.Stack()))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   apperror.NewInternal("internal_error", "error interno", nil).WithRequestID(c.GetString("request_id")),
+		})
+	})
+}
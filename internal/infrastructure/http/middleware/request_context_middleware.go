@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+
+	"ms-parcel-core/internal/pkg/util/requestctx"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+// RequestContextMiddleware copia tenant y usuario del contexto de Gin al context del request
+// para que los casos de uso y el logger los vean (el request ID lo pone RequestIDMiddleware);
+// también marca el span HTTP con el tenant. Va después de AuthMiddleware.
+func RequestContextMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := strings.TrimSpace(c.GetString("tenant_id"))
+		ctx := requestctx.WithIdentity(c.Request.Context(), tenantID, strings.TrimSpace(c.GetString("user_id")))
+		c.Request = c.Request.WithContext(ctx)
+		if tenantID != "" {
+			trace.SpanFromContext(ctx).SetAttributes(tracing.TenantID(tenantID))
+		}
+
+		c.Next()
+	}
+}
@@ -1,82 +1,131 @@
 package router
 
 import (
+	"context"
+	"log/slog"
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	"ms-parcel-core/internal/infrastructure/http/handler"
-	parcelclients "ms-parcel-core/internal/parcel/parcel_core/infrastructure/clients"
-	parcelrepo "ms-parcel-core/internal/parcel/parcel_core/infrastructure/repository"
+	"ms-parcel-core/internal/infrastructure/http/middleware"
+	"ms-parcel-core/internal/infrastructure/persistence"
 	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
 	"ms-parcel-core/internal/parcel/parcel_core/usecase"
 	docclients "ms-parcel-core/internal/parcel/parcel_documents/infrastructure/clients"
-	docrepo "ms-parcel-core/internal/parcel/parcel_documents/infrastructure/repository"
 	docusecase "ms-parcel-core/internal/parcel/parcel_documents/usecase"
-	itemrepo "ms-parcel-core/internal/parcel/parcel_item/infrastructure/repository"
 	itemusecase "ms-parcel-core/internal/parcel/parcel_item/usecase"
-	paymentrepo "ms-parcel-core/internal/parcel/parcel_payment/infrastructure/repository"
+	outboxrecorder "ms-parcel-core/internal/parcel/parcel_outbox/infrastructure/recorder"
+	paymentbalance "ms-parcel-core/internal/parcel/parcel_payment/infrastructure/balance"
+	paymentitemsync "ms-parcel-core/internal/parcel/parcel_payment/infrastructure/itemsync"
 	paymentusecase "ms-parcel-core/internal/parcel/parcel_payment/usecase"
-	pricingrepo "ms-parcel-core/internal/parcel/parcel_pricing/infrastructure/repository"
 	pricingusecase "ms-parcel-core/internal/parcel/parcel_pricing/usecase"
+	trackingbroadcast "ms-parcel-core/internal/parcel/parcel_tracking/infrastructure/broadcast"
 	trackingrecorder "ms-parcel-core/internal/parcel/parcel_tracking/infrastructure/recorder"
-	trackingrepo "ms-parcel-core/internal/parcel/parcel_tracking/infrastructure/repository"
 	trackingusecase "ms-parcel-core/internal/parcel/parcel_tracking/usecase"
+	webhookdispatcher "ms-parcel-core/internal/parcel/parcel_webhook/infrastructure/dispatcher"
+	webhookrecorder "ms-parcel-core/internal/parcel/parcel_webhook/infrastructure/recorder"
+	webhookusecase "ms-parcel-core/internal/parcel/parcel_webhook/usecase"
 )
 
 func RegisterParcelRoutesWithDeps(
+	ctx context.Context,
 	rg *gin.RouterGroup,
-	repo *parcelrepo.InMemoryParcelRepository,
-	trkRepo *trackingrepo.InMemoryTrackingRepository,
-	itemRepo *itemrepo.InMemoryParcelItemRepository,
-	payRepo *paymentrepo.InMemoryParcelPaymentRepository,
-	tenantConfig coreport.TenantConfigClient,
-	tenantOptionsProvider coreport.TenantOptionsProvider,
+	repos persistence.Repositories,
+	cashboxClient coreport.CashboxClient,
+	features coreport.FeatureGate,
+	optionsResolver coreport.ParcelOptionsResolver,
+	metrics coreport.ParcelMetrics,
+	logger *slog.Logger,
 ) {
-	trkRecorder := trackingrecorder.NewTrackingRecorderAdapter(trkRepo)
-
-	createUC := usecase.NewCreateParcelUseCase(repo, tenantConfig, trkRecorder, tenantOptionsProvider)
+	repo, trkRepo, itemRepo, payRepo, uow := repos.Parcels, repos.Tracking, repos.Items, repos.Payments, repos.UnitOfWork
+	// Cada evento de tracking avisa a los streams SSE al confirmarse y encola además las entregas de
+	// webhooks de las suscripciones del tenant
+	trackingBroker := trackingbroadcast.NewTrackingBroker()
+	trkRecorder := webhookrecorder.NewWebhookTrackingRecorder(trackingrecorder.NewNotifyingTrackingRecorder(trackingrecorder.NewTrackingRecorderAdapter(trkRepo), trackingBroker), repos.WebhookSubscriptions, repos.WebhookDeliveries, repo, logger)
+	// Eventos de integración: se guardan en el outbox junto con el cambio; los publica el dispatcher
+	events := outboxrecorder.NewOutboxRecorderAdapter(repos.Outbox)
+
+	createUC := usecase.NewCreateParcelUseCase(repo, features, trkRecorder, uow, events, optionsResolver, metrics, logger)
 	getUC := usecase.NewGetParcelUseCase(repo)
 	listUC := usecase.NewListParcelsUseCase(repo)
-	registerUC := usecase.NewRegisterParcelUseCase(repo, trkRecorder)
-	boardUC := usecase.NewBoardParcelUseCase(repo, trkRecorder)
-	departUC := usecase.NewDepartParcelUseCase(repo, trkRecorder)
-	arriveUC := usecase.NewArriveParcelUseCase(repo, trkRecorder)
-	deliverUC := usecase.NewDeliverParcelUseCase(repo, trkRecorder)
+	registerUC := usecase.NewRegisterParcelUseCase(repo, trkRecorder, uow, events, metrics, logger)
+	boardUC := usecase.NewBoardParcelUseCase(repo, trkRecorder, uow, events, metrics, logger)
+	departUC := usecase.NewDepartParcelUseCase(repo, trkRecorder, uow, events, metrics, logger)
+	arriveUC := usecase.NewArriveParcelUseCase(repo, trkRecorder, uow, events, metrics, logger)
+	paymentBalance := paymentbalance.NewPaymentBalanceAdapter(payRepo)
+	deliverUC := usecase.NewDeliverParcelUseCase(repo, trkRecorder, paymentBalance, uow, events, metrics, logger)
 
 	parcelsHandler := handler.NewParcelHandler(createUC, listUC, getUC, registerUC, boardUC, departUC, arriveUC, deliverUC)
 
-	priceRuleRepo := pricingrepo.NewInMemoryPriceRuleRepository()
+	priceRuleRepo := repos.PriceRules
 	createRuleUC := pricingusecase.NewCreatePriceRuleUseCase(priceRuleRepo)
 	updateRuleUC := pricingusecase.NewUpdatePriceRuleUseCase(priceRuleRepo)
 	listRuleUC := pricingusecase.NewListPriceRulesUseCase(priceRuleRepo)
-	rulesHandler := handler.NewPriceRuleHandler(createRuleUC, updateRuleUC, listRuleUC)
+	importRulesUC := pricingusecase.NewImportPriceRulesUseCase(priceRuleRepo, uow)
+	exportRulesUC := pricingusecase.NewExportPriceRulesUseCase(priceRuleRepo)
+	simulateRuleUC := pricingusecase.NewSimulatePriceRuleUseCase(priceRuleRepo)
+	analyzeRulesUC := pricingusecase.NewAnalyzePriceRulesUseCase(priceRuleRepo)
+	rulesHandler := handler.NewPriceRuleHandler(createRuleUC, updateRuleUC, listRuleUC, importRulesUC, exportRulesUC, simulateRuleUC, analyzeRulesUC)
+
+	recalcPayUC := paymentusecase.NewRecalculateParcelPaymentUseCase(payRepo, itemRepo, uow)
+	paymentSync := paymentitemsync.NewPaymentAmountSyncAdapter(recalcPayUC)
 
-	addItemUC := itemusecase.NewAddParcelItemUseCase(repo, itemRepo, trkRecorder, tenantOptionsProvider, priceRuleRepo)
+	addItemUC := itemusecase.NewAddParcelItemUseCase(repo, itemRepo, trkRecorder, optionsResolver, priceRuleRepo, paymentSync, uow, events, logger)
 	listItemsUC := itemusecase.NewListParcelItemsUseCase(repo, itemRepo)
-	deleteItemUC := itemusecase.NewDeleteParcelItemUseCase(repo, itemRepo, trkRecorder)
+	deleteItemUC := itemusecase.NewDeleteParcelItemUseCase(repo, itemRepo, trkRecorder, paymentSync, uow, events, logger)
 	itemsHandler := handler.NewParcelItemHandler(addItemUC, listItemsUC, deleteItemUC)
 
-	cashboxClient := parcelclients.NewCashboxStubClient()
-	upsertPayUC := paymentusecase.NewUpsertParcelPaymentUseCase(repo, payRepo, tenantOptionsProvider, cashboxClient)
+	upsertPayUC := paymentusecase.NewUpsertParcelPaymentUseCase(repo, payRepo, itemRepo, optionsResolver, cashboxClient, features, logger)
 	getPayUC := paymentusecase.NewGetParcelPaymentUseCase(payRepo)
-	markPaidUC := paymentusecase.NewMarkPaidParcelPaymentUseCase(repo, payRepo, tenantOptionsProvider)
-	paymentHandler := handler.NewParcelPaymentHandler(upsertPayUC, getPayUC, markPaidUC)
+	markPaidUC := paymentusecase.NewMarkPaidParcelPaymentUseCase(repo, payRepo, optionsResolver, cashboxClient, features, uow, events, metrics, logger)
+	addPayTxUC := paymentusecase.NewAddParcelPaymentTransactionUseCase(repo, payRepo, optionsResolver, cashboxClient, features, uow, events, metrics, logger)
+	listPayTxUC := paymentusecase.NewListParcelPaymentTransactionsUseCase(payRepo)
+	refundPayUC := paymentusecase.NewRefundParcelPaymentUseCase(payRepo, cashboxClient, trkRecorder, uow, events, features, logger)
+	voidPayTxUC := paymentusecase.NewVoidParcelPaymentTransactionUseCase(payRepo, cashboxClient, trkRecorder, uow, events, features, logger)
+
+	paymentHandler := handler.NewParcelPaymentHandler(upsertPayUC, getPayUC, markPaidUC, addPayTxUC, listPayTxUC, refundPayUC, voidPayTxUC)
 
 	listTrackingUC := trackingusecase.NewListTrackingUseCase(trkRepo)
 	trackingHandler := handler.NewParcelTrackingHandler(listTrackingUC)
+	streamTrackingUC := trackingusecase.NewStreamTrackingUseCase(trkRepo, repo, trackingBroker)
+	trackingStreamHandler := handler.NewTrackingStreamHandler(streamTrackingUC, envDurationMs("TRACKING_STREAM_HEARTBEAT_MS", 15*time.Second), ctx.Done(), logger)
 
 	// Summary
 	summaryUC := usecase.NewGetParcelSummaryUseCase(repo, itemRepo, payRepo, trkRepo)
 	summaryHandler := handler.NewParcelSummaryHandler(summaryUC)
 
-	printRepo := docrepo.NewInMemoryPrintRepository()
+	printRepo := repos.Prints
 	qrGen := docclients.NewStubQRGenerator()
-	registerPrintUC := docusecase.NewRegisterPrintUseCase(repo, printRepo, tenantOptionsProvider, qrGen)
+	registerPrintUC := docusecase.NewRegisterPrintUseCase(repo, printRepo, optionsResolver, qrGen, features, metrics, logger)
 	docsHandler := handler.NewParcelDocumentsHandler(registerPrintUC, printRepo)
 
+	webhookSubs, webhookDeliveries := repos.WebhookSubscriptions, repos.WebhookDeliveries
+	webhookURLPolicy := webhookusecase.WebhookURLPolicy{AllowInsecure: webhookdispatcher.ConfigFromEnv().AllowInsecureURLs}
+	webhooksHandler := handler.NewWebhookHandler(
+		webhookusecase.NewCreateWebhookSubscriptionUseCase(webhookSubs, webhookURLPolicy),
+		webhookusecase.NewUpdateWebhookSubscriptionUseCase(webhookSubs, webhookURLPolicy),
+		webhookusecase.NewGetWebhookSubscriptionUseCase(webhookSubs),
+		webhookusecase.NewListWebhookSubscriptionsUseCase(webhookSubs),
+		webhookusecase.NewDeleteWebhookSubscriptionUseCase(webhookSubs),
+		webhookusecase.NewListWebhookDeliveriesUseCase(webhookSubs, webhookDeliveries),
+		webhookusecase.NewRetryWebhookDeliveryUseCase(webhookSubs, webhookDeliveries),
+	)
+
+	effectiveOptionsUC := usecase.NewGetEffectiveParcelOptionsUseCase(optionsResolver)
+	optionsHandler := handler.NewParcelOptionsHandler(effectiveOptionsUC)
+
+	rg.GET("/parcel-options/effective", optionsHandler.Effective)
+
+	// Gates por funcionalidad; los casos de uso vuelven a validar para otros puntos de entrada
+	createGate := middleware.FeatureGateMiddleware(features, coreport.FeatureParcelCreate)
+	paymentsGate := middleware.FeatureGateMiddleware(features, coreport.FeaturePayments)
+	documentsGate := middleware.FeatureGateMiddleware(features, coreport.FeatureDocuments)
+
 	parcels := rg.Group("/parcels")
 	{
 		parcels.GET("", parcelsHandler.List)
-		parcels.POST("", parcelsHandler.Create)
+		parcels.POST("", createGate, parcelsHandler.Create)
 
 		parcels.GET("/:id", parcelsHandler.GetByID)
 
@@ -92,20 +141,41 @@ func RegisterParcelRoutesWithDeps(
 		parcels.GET("/:id/items", itemsHandler.List)
 		parcels.DELETE("/:id/items/:item_id", itemsHandler.Delete)
 
-		parcels.PUT("/:id/payment", paymentHandler.Upsert)
+		parcels.PUT("/:id/payment", paymentsGate, paymentHandler.Upsert)
 		parcels.GET("/:id/payment", paymentHandler.Get)
-		parcels.POST("/:id/payment/mark-paid", paymentHandler.MarkPaid)
+		parcels.POST("/:id/payment/mark-paid", paymentsGate, paymentHandler.MarkPaid)
+		parcels.POST("/:id/payment/transactions", paymentsGate, paymentHandler.AddTransaction)
+		parcels.GET("/:id/payment/transactions", paymentHandler.ListTransactions)
+		parcels.POST("/:id/payment/transactions/:txId/void", paymentsGate, paymentHandler.VoidTransaction)
+		parcels.POST("/:id/payment/refunds", paymentsGate, paymentHandler.Refund)
 
 		parcels.GET("/:id/summary", summaryHandler.Get)
 
-		parcels.POST("/:id/documents/print", docsHandler.RegisterPrint)
+		parcels.POST("/:id/documents/print", documentsGate, docsHandler.RegisterPrint)
 		parcels.GET("/:id/documents/prints", docsHandler.ListPrints)
 	}
 
+	rg.GET("/tracking/stream", trackingStreamHandler.Stream)
+
 	pricing := rg.Group("/pricing")
 	{
 		pricing.POST("/rules", rulesHandler.Create)
 		pricing.PUT("/rules/:id", rulesHandler.Update)
 		pricing.GET("/rules", rulesHandler.List)
+		pricing.POST("/rules/import", rulesHandler.Import)
+		pricing.GET("/rules/export", rulesHandler.Export)
+		pricing.GET("/rules/simulate", rulesHandler.Simulate)
+		pricing.GET("/rules/analysis", rulesHandler.Analysis)
+	}
+
+	webhooks := rg.Group("/webhooks")
+	{
+		webhooks.POST("", webhooksHandler.Create)
+		webhooks.GET("", webhooksHandler.List)
+		webhooks.GET("/:id", webhooksHandler.GetByID)
+		webhooks.PATCH("/:id", webhooksHandler.Update)
+		webhooks.DELETE("/:id", webhooksHandler.Delete)
+		webhooks.GET("/:id/deliveries", webhooksHandler.ListDeliveries)
+		webhooks.POST("/:id/deliveries/:delivery_id/retry", webhooksHandler.RetryDelivery)
 	}
 }
@@ -0,0 +1,67 @@
+package router
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"ms-parcel-core/internal/infrastructure/http/handler"
+	"ms-parcel-core/internal/infrastructure/http/middleware"
+	"ms-parcel-core/internal/infrastructure/persistence"
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	coreusecase "ms-parcel-core/internal/parcel/parcel_core/usecase"
+	paymentbalance "ms-parcel-core/internal/parcel/parcel_payment/infrastructure/balance"
+	"ms-parcel-core/internal/pkg/util/ratelimit"
+)
+
+type publicTrackingConfig struct {
+	// RequestsPerMinute limita por IP todo el grupo /public
+	RequestsPerMinute int
+	// VerifyMaxFailures y VerifyWindow limitan los intentos fallidos de verificación por tracking code
+	VerifyMaxFailures int
+	VerifyWindow      time.Duration
+}
+
+// publicTrackingConfigFromEnv lee PUBLIC_TRACK_RATE_LIMIT_PER_MIN (default 30),
+// PUBLIC_TRACK_VERIFY_MAX_FAILURES (default 5) y PUBLIC_TRACK_VERIFY_WINDOW_MS (default 15 min).
+func publicTrackingConfigFromEnv() publicTrackingConfig {
+	return publicTrackingConfig{
+		RequestsPerMinute: envInt("PUBLIC_TRACK_RATE_LIMIT_PER_MIN", 30),
+		VerifyMaxFailures: envInt("PUBLIC_TRACK_VERIFY_MAX_FAILURES", 5),
+		VerifyWindow:      envDurationMs("PUBLIC_TRACK_VERIFY_WINDOW_MS", 15*time.Minute),
+	}
+}
+
+// RegisterPublicTrackingRoutes registra el seguimiento público (sin autenticación ni tenant).
+func RegisterPublicTrackingRoutes(v1 *gin.RouterGroup, repos persistence.Repositories, offices coreport.OfficeDirectory, persons coreport.PersonDirectory, logger *slog.Logger) {
+	cfg := publicTrackingConfigFromEnv()
+
+	verifyFailures := ratelimit.New(cfg.VerifyMaxFailures, cfg.VerifyWindow)
+	uc := coreusecase.NewGetPublicTrackingUseCase(repos.Parcels, repos.Tracking, repos.Items, paymentbalance.NewPaymentBalanceAdapter(repos.Payments), offices, persons, verifyFailures, logger)
+	h := handler.NewPublicTrackingHandler(uc)
+
+	public := v1.Group("/public", middleware.RateLimitMiddleware(ratelimit.New(cfg.RequestsPerMinute, time.Minute)))
+	{
+		public.GET("/track/:code", h.Get)
+	}
+}
+
+func envInt(key string, def int) int {
+	v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || v <= 0 {
+		return def
+	}
+	return v
+}
+
+func envDurationMs(key string, def time.Duration) time.Duration {
+	v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || v <= 0 {
+		return def
+	}
+	return time.Duration(v) * time.Millisecond
+}
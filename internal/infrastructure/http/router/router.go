@@ -1,41 +1,52 @@
 package router
 
 import (
-	"net/http"
-	"time"
+	"context"
+	"log/slog"
 
 	"github.com/gin-gonic/gin"
 
+	"ms-parcel-core/internal/infrastructure/health"
 	"ms-parcel-core/internal/infrastructure/http/handler"
+	"ms-parcel-core/internal/infrastructure/http/middleware"
+	appmetrics "ms-parcel-core/internal/infrastructure/metrics"
+	"ms-parcel-core/internal/infrastructure/persistence"
 	parcelclients "ms-parcel-core/internal/parcel/parcel_core/infrastructure/clients"
-	parcelrepo "ms-parcel-core/internal/parcel/parcel_core/infrastructure/repository"
-	itemrepo "ms-parcel-core/internal/parcel/parcel_item/infrastructure/repository"
+	parcelfeatureflags "ms-parcel-core/internal/parcel/parcel_core/infrastructure/featureflags"
+	parceloptions "ms-parcel-core/internal/parcel/parcel_core/infrastructure/options"
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
 	manifestusecase "ms-parcel-core/internal/parcel/parcel_manifest/usecase"
-	paymentrepo "ms-parcel-core/internal/parcel/parcel_payment/infrastructure/repository"
-	trackingrepo "ms-parcel-core/internal/parcel/parcel_tracking/infrastructure/repository"
 )
 
-func RegisterRoutes(engine *gin.Engine) {
-	// Health mínimo para verificar server correcto
-	engine.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
-	})
+// RegisterRoutes arma el composition root. ctx se cancela al apagar y cierra los streams abiertos;
+// repos es en memoria o PostgreSQL según la configuración; cashbox lo comparte con el worker de
+// reintentos de caja (ver NewCashboxClient); checks recibe los chequeos de readiness de los clientes
+// externos. No arranca workers: los arranca y espera cmd/api.
+func RegisterRoutes(ctx context.Context, engine *gin.Engine, repos persistence.Repositories, cashbox coreport.CashboxClient, checks *health.Service, logger *slog.Logger, metrics *appmetrics.PrometheusMetrics) {
+	healthHandler := handler.NewHealthHandler(checks)
+	engine.GET("/health", healthHandler.Live)
+	engine.GET("/health/live", healthHandler.Live)
+	engine.GET("/health/ready", healthHandler.Ready)
+	engine.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	v1 := engine.Group("/api/v1")
 	{
-		// Composition root (deps únicos)
-		parcelRepo := parcelrepo.NewInMemoryParcelRepository()
-		trkRepo := trackingrepo.NewInMemoryTrackingRepository()
-		itemRepo := itemrepo.NewInMemoryParcelItemRepository()
-		payRepo := paymentrepo.NewInMemoryParcelPaymentRepository()
+		tenantConfig, tenantOptions, offices := tenantConfigClients(checks, logger)
+		tenantOptionsProvider := parcelclients.NewCachedTenantOptionsProvider(tenantOptions, parcelclients.CachedTenantOptionsConfigFromEnv(), logger)
 
-		tenantConfig := parcelclients.NewTenantConfigStubClient()
-		tenantOptionsProvider := parcelclients.NewCachedTenantOptionsProvider(tenantConfig, 60*time.Second)
+		metrics.RegisterTenantOptionsCache(tenantOptionsProvider)
 
-		RegisterParcelRoutesWithDeps(v1, parcelRepo, trkRepo, itemRepo, payRepo, tenantConfig, tenantOptionsProvider)
+		optionsResolver := parceloptions.NewParcelOptionsResolver(tenantOptionsProvider, tenantOptionsProvider, logger)
+		featureGate := parcelfeatureflags.NewFeatureGate(tenantConfig, parcelfeatureflags.FeatureGateConfigFromEnv(), logger)
+
+		RegisterParcelRoutesWithDeps(ctx, v1, repos, cashbox, featureGate, optionsResolver, metrics, logger)
+
+		// Seguimiento público: sin tenant, el gateway debe dejar pasar /api/v1/public sin token
+		officeDirectory := parcelclients.NewCachedOfficeDirectory(offices, parcelclients.CachedOfficeDirectoryConfigFromEnv(), logger)
+		RegisterPublicTrackingRoutes(v1, repos, officeDirectory, personDirectory(checks), logger)
 
 		// Manifests (preview virtual)
-		buildUC := manifestusecase.NewBuildManifestPreviewUseCase(parcelRepo)
+		buildUC := manifestusecase.NewBuildManifestPreviewUseCase(repos.Parcels)
 		h := handler.NewManifestHandler(buildUC)
 
 		manifests := v1.Group("/manifests")
@@ -43,5 +54,47 @@ func RegisterRoutes(engine *gin.Engine) {
 			manifests.POST("/preview", h.PreviewPost)
 			manifests.GET("/preview", h.PreviewGet)
 		}
+
+		// Admin
+		cacheHandler := handler.NewTenantOptionsCacheHandler(tenantOptionsProvider)
+		admin := v1.Group("/admin", middleware.AdminTokenMiddleware())
+		{
+			admin.POST("/cache/tenant-options/invalidate", cacheHandler.Invalidate)
+			admin.GET("/cache/tenant-options/stats", cacheHandler.Stats)
+		}
+	}
+}
+
+// tenantConfigClients usa ms-tenant-config por HTTP si está configurado; si no, el stub.
+// No es crítico para readiness: opciones y flags tienen last-known-good y fail open.
+func tenantConfigClients(checks *health.Service, logger *slog.Logger) (coreport.TenantConfigClient, coreport.TenantOptionsProvider, coreport.OfficeDirectory) {
+	if cfg := parcelclients.TenantConfigHTTPClientConfigFromEnv(); cfg.BaseURL != "" {
+		c := parcelclients.NewTenantConfigHTTPClient(cfg, nil, logger)
+		checks.Register("tenant_config", health.CheckerFunc(c.Ping), false)
+		return c, c, c
+	}
+	c := parcelclients.NewTenantConfigStubClient()
+	return c, c, c
+}
+
+// personDirectory usa ms-persons por HTTP si está configurado; si no, el stub.
+// No es crítico para readiness: solo lo usa la verificación del seguimiento público.
+func personDirectory(checks *health.Service) coreport.PersonDirectory {
+	if cfg := parcelclients.PersonHTTPClientConfigFromEnv(); cfg.BaseURL != "" {
+		c := parcelclients.NewPersonHTTPClient(cfg, nil)
+		checks.Register("persons", health.CheckerFunc(c.Ping), false)
+		return c
+	}
+	return parcelclients.NewPersonStubClient()
+}
+
+// NewCashboxClient usa ms-cashbox por HTTP si está configurado; si no, el stub.
+// No es crítico para readiness: la política ante caja no disponible la decide cada tenant.
+func NewCashboxClient(checks *health.Service) coreport.CashboxClient {
+	if cfg := parcelclients.CashboxHTTPClientConfigFromEnv(); cfg.BaseURL != "" {
+		c := parcelclients.NewCashboxHTTPClient(cfg, nil)
+		checks.Register("cashbox", health.CheckerFunc(c.Ping), false)
+		return c
 	}
+	return parcelclients.NewCashboxStubClient()
 }
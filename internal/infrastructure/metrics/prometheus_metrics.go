@@ -0,0 +1,234 @@
+// Package metrics expone métricas Prometheus de HTTP y de dominio en /metrics.
+package metrics
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	coredomain "ms-parcel-core/internal/parcel/parcel_core/domain"
+	parcelclients "ms-parcel-core/internal/parcel/parcel_core/infrastructure/clients"
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	docdomain "ms-parcel-core/internal/parcel/parcel_documents/domain"
+	paydomain "ms-parcel-core/internal/parcel/parcel_payment/domain"
+)
+
+const (
+	namespace = "parcel_core"
+
+	// otherLabel agrupa valores fuera del conjunto permitido para acotar la cardinalidad.
+	otherLabel = "other"
+)
+
+// Valores conocidos de etiquetas; cualquier otro valor se reporta como "other".
+var (
+	knownMethods       = setOf(http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead, http.MethodOptions)
+	knownShipmentTypes = setOf(string(coredomain.ShipmentTypeBus), string(coredomain.ShipmentTypeCarguero))
+	knownDocumentTypes = setOf(string(docdomain.DocumentTypeLabel), string(docdomain.DocumentTypeReceipt), string(docdomain.DocumentTypeManifest), string(docdomain.DocumentTypeGuide))
+	knownPaymentTypes  = setOf(
+		string(paydomain.PaymentTypeCash), string(paydomain.PaymentTypeFOB), string(paydomain.PaymentTypeCard), string(paydomain.PaymentTypeTransfer),
+		string(paydomain.PaymentTypeEWallet), string(paydomain.PaymentTypeFree), string(paydomain.PaymentTypeCollectOnDelivery),
+	)
+)
+
+type Config struct {
+	// MaxTenants: cantidad de tenants distintos con etiqueta propia; el resto se agrupa en "other".
+	MaxTenants int
+	// MaxErrorCodes: cantidad de códigos de apperror distintos con etiqueta propia.
+	MaxErrorCodes int
+}
+
+// ConfigFromEnv lee METRICS_MAX_TENANTS y METRICS_MAX_ERROR_CODES.
+func ConfigFromEnv() Config {
+	return Config{
+		MaxTenants:    envInt("METRICS_MAX_TENANTS", 200),
+		MaxErrorCodes: envInt("METRICS_MAX_ERROR_CODES", 50),
+	}
+}
+
+func envInt(key string, def int) int {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// PrometheusMetrics implementa ParcelMetrics y las métricas HTTP sobre un registry propio.
+type PrometheusMetrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpLatency  *prometheus.HistogramVec
+
+	parcelsCreated     *prometheus.CounterVec
+	transitions        *prometheus.CounterVec
+	transitionFailures *prometheus.CounterVec
+	documentsPrinted   *prometheus.CounterVec
+	paymentsPaid       *prometheus.CounterVec
+
+	tenants    *boundedLabel
+	errorCodes *boundedLabel
+}
+
+var _ coreport.ParcelMetrics = (*PrometheusMetrics)(nil)
+
+func NewPrometheusMetrics(cfg Config) *PrometheusMetrics {
+	if cfg.MaxTenants <= 0 {
+		cfg.MaxTenants = 200
+	}
+	if cfg.MaxErrorCodes <= 0 {
+		cfg.MaxErrorCodes = 50
+	}
+
+	m := &PrometheusMetrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "http_requests_total",
+			Help: "Requests HTTP por método, ruta (template) y status.",
+		}, []string{"method", "route", "status"}),
+		httpLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace, Name: "http_request_duration_seconds",
+			Help:    "Latencia de requests HTTP por método, ruta (template) y status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		parcelsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "parcels_created_total",
+			Help: "Envíos creados por tenant y tipo de envío.",
+		}, []string{"tenant", "shipment_type"}),
+		transitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "parcel_status_transitions_total",
+			Help: "Transiciones de estado exitosas por transición y estado destino.",
+		}, []string{"transition", "to_status"}),
+		transitionFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "parcel_status_transition_failures_total",
+			Help: "Intentos de transición rechazados por transición y código de error.",
+		}, []string{"transition", "code"}),
+		documentsPrinted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "documents_printed_total",
+			Help: "Impresiones de documentos por tipo, distinguiendo reimpresiones.",
+		}, []string{"document_type", "reprint"}),
+		paymentsPaid: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "payments_paid_total",
+			Help: "Pagos que quedaron PAID por tipo de pago.",
+		}, []string{"payment_type"}),
+		tenants:    newBoundedLabel(cfg.MaxTenants),
+		errorCodes: newBoundedLabel(cfg.MaxErrorCodes),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpLatency,
+		m.parcelsCreated, m.transitions, m.transitionFailures, m.documentsPrinted, m.paymentsPaid,
+	)
+	return m
+}
+
+// Handler sirve las métricas en formato Prometheus.
+func (m *PrometheusMetrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTP registra un request. route debe ser el template de la ruta, nunca la URL real.
+func (m *PrometheusMetrics) ObserveHTTP(method string, route string, status int, d time.Duration) {
+	method = knownOrOther(knownMethods, method)
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpLatency.WithLabelValues(method, route, code).Observe(d.Seconds())
+}
+
+// RegisterTenantOptionsCache expone hits/misses del cache de opciones de tenant y oficina.
+func (m *PrometheusMetrics) RegisterTenantOptionsCache(cache *parcelclients.CachedTenantOptionsProvider) {
+	if cache == nil {
+		return
+	}
+	counter := func(name, help, level string, read func(parcelclients.TenantOptionsCacheStats) uint64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace, Name: name, Help: help,
+			ConstLabels: prometheus.Labels{"level": level},
+		}, func() float64 { return float64(read(cache.Stats())) })
+	}
+	m.registry.MustRegister(
+		counter("tenant_options_cache_hits_total", "Aciertos del cache de opciones.", "tenant", func(s parcelclients.TenantOptionsCacheStats) uint64 { return s.Tenants.Hits }),
+		counter("tenant_options_cache_stale_hits_total", "Aciertos stale (servidos mientras se refresca).", "tenant", func(s parcelclients.TenantOptionsCacheStats) uint64 { return s.Tenants.StaleHits }),
+		counter("tenant_options_cache_misses_total", "Fallos del cache de opciones.", "tenant", func(s parcelclients.TenantOptionsCacheStats) uint64 { return s.Tenants.Misses }),
+		counter("tenant_options_cache_hits_total", "Aciertos del cache de opciones.", "office", func(s parcelclients.TenantOptionsCacheStats) uint64 { return s.Offices.Hits }),
+		counter("tenant_options_cache_stale_hits_total", "Aciertos stale (servidos mientras se refresca).", "office", func(s parcelclients.TenantOptionsCacheStats) uint64 { return s.Offices.StaleHits }),
+		counter("tenant_options_cache_misses_total", "Fallos del cache de opciones.", "office", func(s parcelclients.TenantOptionsCacheStats) uint64 { return s.Offices.Misses }),
+	)
+}
+
+func (m *PrometheusMetrics) ParcelCreated(tenantID string, shipmentType string) {
+	m.parcelsCreated.WithLabelValues(m.tenants.value(tenantID), knownOrOther(knownShipmentTypes, shipmentType)).Inc()
+}
+
+func (m *PrometheusMetrics) StatusTransition(transition string, toStatus string) {
+	m.transitions.WithLabelValues(transition, toStatus).Inc()
+}
+
+func (m *PrometheusMetrics) TransitionFailed(transition string, code string) {
+	m.transitionFailures.WithLabelValues(transition, m.errorCodes.value(code)).Inc()
+}
+
+func (m *PrometheusMetrics) DocumentPrinted(documentType string, reprint bool) {
+	m.documentsPrinted.WithLabelValues(knownOrOther(knownDocumentTypes, documentType), strconv.FormatBool(reprint)).Inc()
+}
+
+func (m *PrometheusMetrics) PaymentMarkedPaid(paymentType string) {
+	m.paymentsPaid.WithLabelValues(knownOrOther(knownPaymentTypes, paymentType)).Inc()
+}
+
+// boundedLabel acepta los primeros max valores distintos; los siguientes se reportan como "other".
+type boundedLabel struct {
+	max int
+
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+func newBoundedLabel(max int) *boundedLabel {
+	return &boundedLabel{max: max, seen: map[string]struct{}{}}
+}
+
+func (b *boundedLabel) value(v string) string {
+	if v == "" {
+		return otherLabel
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.seen[v]; ok {
+		return v
+	}
+	if len(b.seen) >= b.max {
+		return otherLabel
+	}
+	b.seen[v] = struct{}{}
+	return v
+}
+
+func setOf(values ...string) map[string]struct{} {
+	out := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		out[v] = struct{}{}
+	}
+	return out
+}
+
+func knownOrOther(known map[string]struct{}, v string) string {
+	if _, ok := known[v]; ok {
+		return v
+	}
+	return otherLabel
+}
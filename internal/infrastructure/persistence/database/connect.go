@@ -2,16 +2,19 @@ package database
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 
 	"ms-parcel-core/internal/config"
 	mypostgres "ms-parcel-core/internal/infrastructure/persistence/postgres"
+	"ms-parcel-core/internal/pkg/util/logging"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func Connect(cfg config.DBConfig) (*gorm.DB, error) {
+func Connect(cfg config.DBConfig, logger *slog.Logger) (*gorm.DB, error) {
+	logger = logging.OrDiscard(logger)
+
 	dsn := fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
 		cfg.Host, cfg.User, cfg.Password, cfg.Name, cfg.Port,
@@ -19,16 +22,12 @@ func Connect(cfg config.DBConfig) (*gorm.DB, error) {
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
-		log.Printf("Error connecting to the database: %v", err)
+		logger.Error("error connecting to the database", "host", cfg.Host, "db", cfg.Name, "error", err)
 		return nil, err
 	}
 
 	mypostgres.RegisterTenantScope(db)
 
-	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS \"uuid-ossp\"").Error; err != nil {
-		log.Printf("Error enabling uuid-ossp: %v", err)
-	}
-
-	log.Println("Connected to PostgreSQL successfully")
+	logger.Info("connected to PostgreSQL", "host", cfg.Host, "db", cfg.Name)
 	return db, nil
 }
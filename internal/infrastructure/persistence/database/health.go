@@ -0,0 +1,34 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Ping verifica que la conexión a PostgreSQL responda.
+func Ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// CheckMigrations falla si quedan migraciones pendientes o alguna aplicada cambió de contenido.
+func CheckMigrations(ctx context.Context, db *gorm.DB) error {
+	m, err := NewMigrator(db, nil)
+	if err != nil {
+		return err
+	}
+	return m.Check(ctx)
+}
+
+// Close cierra el pool de conexiones.
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
@@ -0,0 +1,2 @@
+-- uuid_generate_v4() es el default de las claves primarias
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS price_rules;
+DROP TABLE IF EXISTS print_records;
+DROP TABLE IF EXISTS tracking_events;
+DROP TABLE IF EXISTS parcel_payment_transactions;
+DROP TABLE IF EXISTS parcel_payments;
+DROP TABLE IF EXISTS parcel_items;
+DROP TABLE IF EXISTS parcels;
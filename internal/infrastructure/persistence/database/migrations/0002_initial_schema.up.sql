@@ -0,0 +1,160 @@
+-- Esquema inicial equivalente al que generaba AutoMigrate. Usa IF NOT EXISTS (con los mismos
+-- nombres de índice que GORM) para adoptar bases creadas antes de las migraciones versionadas.
+
+CREATE TABLE IF NOT EXISTS parcels (
+    id                      uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id               varchar(100) NOT NULL,
+    origin_office_id        varchar(100) NOT NULL,
+    destination_office_id   varchar(100) NOT NULL,
+    sender_person_id        varchar(100) NOT NULL,
+    recipient_person_id     varchar(100) NOT NULL,
+    shipment_type           varchar(50)  NOT NULL,
+    notes                   text,
+    package_key_hash_sha256 varchar(255),
+    status                  varchar(50)  NOT NULL,
+    tracking_code           varchar(50),
+    created_by_user_id      varchar(100) NOT NULL,
+    created_by_user_name    varchar(255),
+    created_at              timestamptz  NOT NULL,
+    registered_at           timestamptz,
+    boarded_vehicle_id      varchar(100),
+    boarded_trip_id         varchar(100),
+    boarded_departure_at    timestamptz,
+    boarded_at              timestamptz,
+    boarded_by_user_id      varchar(100),
+    delivered_at            timestamptz,
+    delivered_by_user_id    varchar(100),
+    arrived_at              timestamptz,
+    arrived_by_user_id      varchar(100),
+    departed_at             timestamptz,
+    departed_by_user_id     varchar(100)
+);
+CREATE INDEX IF NOT EXISTS idx_parcel_tenant ON parcels (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_parcels_origin_office_id ON parcels (origin_office_id);
+CREATE INDEX IF NOT EXISTS idx_parcels_destination_office_id ON parcels (destination_office_id);
+CREATE INDEX IF NOT EXISTS idx_parcels_status ON parcels (status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_parcels_tracking_code ON parcels (tracking_code);
+
+CREATE TABLE IF NOT EXISTS parcel_items (
+    id                uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    parcel_id         uuid           NOT NULL,
+    description       varchar(500)   NOT NULL,
+    quantity          bigint         NOT NULL,
+    weight_kg         decimal(10,2)  NOT NULL,
+    length_cm         decimal(10,2),
+    width_cm          decimal(10,2),
+    height_cm         decimal(10,2),
+    volumetric_weight decimal(10,2),
+    billable_weight   decimal(10,2)  NOT NULL,
+    unit_price        decimal(10,2)  NOT NULL,
+    content_type      varchar(100),
+    notes             text,
+    created_at        timestamptz    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_parcel_items_parcel_id ON parcel_items (parcel_id);
+
+CREATE TABLE IF NOT EXISTS parcel_payments (
+    id                   uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    parcel_id            uuid          NOT NULL,
+    tenant_id            varchar(100)  NOT NULL,
+    payment_type         varchar(50)   NOT NULL,
+    status               varchar(50)   NOT NULL,
+    amount               decimal(10,2) NOT NULL,
+    currency             varchar(3)    NOT NULL,
+    channel              varchar(50),
+    office_id            varchar(100),
+    cashbox_id           varchar(100),
+    seller_user_id       varchar(100),
+    notes                text,
+    created_at           timestamptz   NOT NULL,
+    updated_at           timestamptz   NOT NULL,
+    paid_at              timestamptz,
+    paid_by_user_id      varchar(100),
+    items_amount         decimal(10,2) NOT NULL DEFAULT 0,
+    surcharges_amount    decimal(10,2) NOT NULL DEFAULT 0,
+    surcharges           jsonb,
+    amount_source        varchar(20)   NOT NULL DEFAULT 'AUTO',
+    manual_amount_reason varchar(200),
+    amount_stale         boolean       NOT NULL DEFAULT false,
+    amount_calculated_at timestamptz,
+    paid_amount          decimal(10,2) NOT NULL DEFAULT 0,
+    refunded_amount      decimal(10,2) NOT NULL DEFAULT 0,
+    balance              decimal(10,2) NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_parcel_payments_parcel_id ON parcel_payments (parcel_id);
+CREATE INDEX IF NOT EXISTS idx_parcel_payments_tenant_id ON parcel_payments (tenant_id);
+
+CREATE TABLE IF NOT EXISTS parcel_payment_transactions (
+    id                       uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id                varchar(100)  NOT NULL,
+    parcel_id                uuid          NOT NULL,
+    payment_id               uuid          NOT NULL,
+    kind                     varchar(20)   NOT NULL,
+    payment_type             varchar(50)   NOT NULL,
+    channel                  varchar(50)   NOT NULL,
+    amount                   decimal(10,2) NOT NULL,
+    currency                 varchar(3)    NOT NULL,
+    office_id                varchar(100),
+    cashbox_id               varchar(100),
+    seller_user_id           varchar(100),
+    created_by_user_id       varchar(100),
+    notes                    text,
+    created_at               timestamptz   NOT NULL,
+    reason                   text,
+    approved_by_user_id      varchar(100),
+    reverses_transaction_id  uuid,
+    cashbox_movement_id      varchar(100),
+    cashbox_posting_status   varchar(20),
+    cashbox_posting_attempts bigint        NOT NULL DEFAULT 0,
+    cashbox_posting_error    text,
+    cashbox_next_attempt_at  timestamptz,
+    cashbox_posted_at        timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_parcel_payment_transactions_tenant_id ON parcel_payment_transactions (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_parcel_payment_transactions_parcel_id ON parcel_payment_transactions (parcel_id);
+CREATE INDEX IF NOT EXISTS idx_parcel_payment_transactions_payment_id ON parcel_payment_transactions (payment_id);
+CREATE INDEX IF NOT EXISTS idx_parcel_payment_transactions_created_at ON parcel_payment_transactions (created_at);
+CREATE INDEX IF NOT EXISTS idx_parcel_payment_transactions_reverses_transaction_id ON parcel_payment_transactions (reverses_transaction_id);
+CREATE INDEX IF NOT EXISTS idx_parcel_payment_transactions_cashbox_posting_status ON parcel_payment_transactions (cashbox_posting_status);
+CREATE INDEX IF NOT EXISTS idx_parcel_payment_transactions_cashbox_next_attempt_at ON parcel_payment_transactions (cashbox_next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS tracking_events (
+    id          uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    parcel_id   varchar(100) NOT NULL,
+    event_type  varchar(50)  NOT NULL,
+    occurred_at timestamptz  NOT NULL,
+    user_id     varchar(100) NOT NULL,
+    user_name   varchar(255),
+    metadata    jsonb
+);
+CREATE INDEX IF NOT EXISTS idx_tracking_events_parcel_id ON tracking_events (parcel_id);
+CREATE INDEX IF NOT EXISTS idx_tracking_events_occurred_at ON tracking_events (occurred_at);
+
+CREATE TABLE IF NOT EXISTS print_records (
+    id                 uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    parcel_id          varchar(100) NOT NULL,
+    tenant_id          varchar(100) NOT NULL,
+    document_type      varchar(50)  NOT NULL,
+    printed_at         timestamptz  NOT NULL,
+    printed_by_user_id varchar(100)
+);
+CREATE INDEX IF NOT EXISTS idx_print_records_parcel_id ON print_records (parcel_id);
+CREATE INDEX IF NOT EXISTS idx_print_records_tenant_id ON print_records (tenant_id);
+
+CREATE TABLE IF NOT EXISTS price_rules (
+    id                    uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id             varchar(100)  NOT NULL,
+    shipment_type         varchar(50)   NOT NULL,
+    origin_office_id      varchar(100)  NOT NULL,
+    destination_office_id varchar(100)  NOT NULL,
+    unit                  varchar(50)   NOT NULL,
+    price                 decimal(10,2) NOT NULL,
+    currency              varchar(3)    NOT NULL,
+    priority              bigint        NOT NULL DEFAULT 0,
+    active                boolean       NOT NULL DEFAULT true,
+    created_at            timestamptz   NOT NULL,
+    updated_at            timestamptz   NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_price_rules_tenant_id ON price_rules (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_price_rules_origin_office_id ON price_rules (origin_office_id);
+CREATE INDEX IF NOT EXISTS idx_price_rules_destination_office_id ON price_rules (destination_office_id);
@@ -0,0 +1,23 @@
+-- Outbox transaccional: los eventos de integración se guardan en la misma transacción que el
+-- cambio de estado y un dispatcher los publica después (at-least-once, id = clave de deduplicación).
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id              uuid PRIMARY KEY,
+    tenant_id       varchar(100) NOT NULL,
+    event_type      varchar(100) NOT NULL,
+    version         integer      NOT NULL DEFAULT 1,
+    aggregate_type  varchar(50)  NOT NULL,
+    aggregate_id    varchar(100) NOT NULL,
+    occurred_at     timestamptz  NOT NULL,
+    payload         jsonb        NOT NULL DEFAULT '{}'::jsonb,
+    status          varchar(20)  NOT NULL,
+    attempts        integer      NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz  NOT NULL,
+    last_error      text,
+    published_at    timestamptz,
+    created_at      timestamptz  NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_tenant_id ON outbox_events (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_aggregate ON outbox_events (aggregate_type, aggregate_id);
+-- El dispatcher solo recorre los eventos sin publicar
+CREATE INDEX IF NOT EXISTS idx_outbox_events_due ON outbox_events (next_attempt_at, created_at)
+    WHERE status IN ('PENDING', 'PUBLISHING');
@@ -0,0 +1,39 @@
+-- Webhooks por tenant: suscripciones a eventos de tracking y el log de entregas (con reintentos
+-- y dead-letter). Las entregas se encolan en la misma transacción que el evento de tracking.
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id                 uuid PRIMARY KEY,
+    tenant_id          varchar(100) NOT NULL,
+    url                varchar(500) NOT NULL,
+    secret             varchar(100) NOT NULL,
+    event_types        jsonb        NOT NULL DEFAULT '[]'::jsonb,
+    active             boolean      NOT NULL DEFAULT true,
+    description        varchar(200),
+    created_by_user_id varchar(100),
+    created_at         timestamptz  NOT NULL,
+    updated_at         timestamptz  NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_tenant_id ON webhook_subscriptions (tenant_id);
+
+-- Sin FK a webhook_subscriptions: el log de entregas se conserva aunque se borre la suscripción
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id               uuid PRIMARY KEY,
+    tenant_id        varchar(100) NOT NULL,
+    subscription_id  uuid         NOT NULL,
+    event_id         uuid         NOT NULL,
+    event_type       varchar(100) NOT NULL,
+    parcel_id        varchar(100) NOT NULL,
+    payload          text         NOT NULL,
+    status           varchar(20)  NOT NULL,
+    attempts         integer      NOT NULL DEFAULT 0,
+    next_attempt_at  timestamptz  NOT NULL,
+    last_status_code integer,
+    last_error       text,
+    delivered_at     timestamptz,
+    created_at       timestamptz  NOT NULL,
+    updated_at       timestamptz  NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_tenant_id ON webhook_deliveries (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries (subscription_id, created_at DESC);
+-- El dispatcher solo recorre las entregas sin confirmar
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at, created_at)
+    WHERE status IN ('PENDING', 'DELIVERING');
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tracking_events_seq;
+ALTER TABLE tracking_events DROP COLUMN IF EXISTS seq;
+DROP SEQUENCE IF EXISTS tracking_events_seq_seq;
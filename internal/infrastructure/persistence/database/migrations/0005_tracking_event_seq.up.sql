@@ -0,0 +1,16 @@
+-- Orden de registro de los eventos de tracking: id de los eventos del stream SSE y cursor de
+-- Last-Event-ID. Los eventos existentes se numeran por occurred_at.
+CREATE SEQUENCE IF NOT EXISTS tracking_events_seq_seq;
+ALTER TABLE tracking_events ADD COLUMN IF NOT EXISTS seq bigint;
+
+UPDATE tracking_events te
+SET seq = n.rn
+FROM (SELECT id, row_number() OVER (ORDER BY occurred_at, id) AS rn FROM tracking_events) n
+WHERE te.id = n.id AND te.seq IS NULL;
+
+SELECT setval('tracking_events_seq_seq', COALESCE((SELECT MAX(seq) FROM tracking_events), 0) + 1, false);
+
+ALTER TABLE tracking_events ALTER COLUMN seq SET DEFAULT nextval('tracking_events_seq_seq');
+ALTER TABLE tracking_events ALTER COLUMN seq SET NOT NULL;
+ALTER SEQUENCE tracking_events_seq_seq OWNED BY tracking_events.seq;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tracking_events_seq ON tracking_events (seq);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_tracking_events_vehicle_id;
+DROP INDEX IF EXISTS idx_tracking_events_office_id;
+ALTER TABLE tracking_events
+    DROP COLUMN IF EXISTS correlation_id,
+    DROP COLUMN IF EXISTS source,
+    DROP COLUMN IF EXISTS location_accuracy_m,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS vehicle_id,
+    DROP COLUMN IF EXISTS office_id;
@@ -0,0 +1,33 @@
+-- Campos propios de los eventos de tracking: oficina, vehículo, posición GPS, origen y correlación.
+ALTER TABLE tracking_events ADD COLUMN IF NOT EXISTS office_id varchar(100);
+ALTER TABLE tracking_events ADD COLUMN IF NOT EXISTS vehicle_id varchar(100);
+ALTER TABLE tracking_events ADD COLUMN IF NOT EXISTS latitude double precision;
+ALTER TABLE tracking_events ADD COLUMN IF NOT EXISTS longitude double precision;
+ALTER TABLE tracking_events ADD COLUMN IF NOT EXISTS location_accuracy_m double precision;
+ALTER TABLE tracking_events ADD COLUMN IF NOT EXISTS source varchar(20);
+ALTER TABLE tracking_events ADD COLUMN IF NOT EXISTS correlation_id varchar(128);
+
+-- Eventos existentes: la oficina y el vehículo salen de la metadata o, si no están, del envío (la
+-- misma oficina que usa hoy cada transición). Todos se registraron por la API; no tienen posición
+-- ni correlación.
+UPDATE tracking_events te
+SET office_id = CASE te.event_type
+        WHEN 'PARCEL_CREATED' THEN COALESCE(te.metadata->>'origin_office_id', p.origin_office_id)
+        WHEN 'PARCEL_REGISTERED' THEN p.origin_office_id
+        WHEN 'PARCEL_BOARDED' THEN p.origin_office_id
+        WHEN 'PARCEL_IN_TRANSIT' THEN COALESCE(te.metadata->>'departure_office_id', p.origin_office_id)
+        WHEN 'PARCEL_ARRIVED_DESTINATION' THEN COALESCE(te.metadata->>'destination_office_id', p.destination_office_id)
+        WHEN 'PARCEL_DELIVERED' THEN p.destination_office_id
+    END,
+    vehicle_id = CASE
+        WHEN te.event_type IN ('PARCEL_BOARDED', 'PARCEL_IN_TRANSIT') THEN COALESCE(te.metadata->>'vehicle_id', p.boarded_vehicle_id)
+        WHEN te.event_type = 'PARCEL_ARRIVED_DESTINATION' THEN p.boarded_vehicle_id
+    END
+FROM parcels p
+WHERE p.id::text = te.parcel_id AND te.source IS NULL;
+
+UPDATE tracking_events SET source = 'API' WHERE source IS NULL;
+ALTER TABLE tracking_events ALTER COLUMN source SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tracking_events_office_id ON tracking_events (office_id);
+CREATE INDEX IF NOT EXISTS idx_tracking_events_vehicle_id ON tracking_events (vehicle_id);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trg_tracking_events_commit_seq ON tracking_events;
+DROP FUNCTION IF EXISTS tracking_events_assign_commit_seq();
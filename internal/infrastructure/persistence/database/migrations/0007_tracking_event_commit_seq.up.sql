@@ -0,0 +1,22 @@
+-- El seq se asignaba al insertar, dentro de la unidad de trabajo: un evento con seq menor podía
+-- confirmarse después que uno mayor y el stream, que avanza el cursor por seq, lo salteaba. Ahora se
+-- reasigna al confirmar: el trigger diferido corre en el commit y toma un lock por tenant que se
+-- libera recién con la transacción ya visible, así el orden de seq de un tenant es el de confirmación.
+-- El parcel se busca casteando parcel_id a uuid (y no p.id a text) para usar el índice de la PK.
+CREATE OR REPLACE FUNCTION tracking_events_assign_commit_seq() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(
+        hashtext('tracking_events_seq'),
+        hashtext(COALESCE((SELECT p.tenant_id FROM parcels p WHERE p.id = NEW.parcel_id::uuid), ''))
+    );
+    UPDATE tracking_events SET seq = nextval('tracking_events_seq_seq') WHERE id = NEW.id;
+    RETURN NULL;
+END;
+$$;
+
+DROP TRIGGER IF EXISTS trg_tracking_events_commit_seq ON tracking_events;
+CREATE CONSTRAINT TRIGGER trg_tracking_events_commit_seq
+    AFTER INSERT ON tracking_events
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION tracking_events_assign_commit_seq();
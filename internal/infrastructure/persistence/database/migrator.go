@@ -0,0 +1,373 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ms-parcel-core/internal/pkg/util/logging"
+
+	"gorm.io/gorm"
+)
+
+// Las migraciones viven en migrations/NNNN_nombre.up.sql y NNNN_nombre.down.sql y se embeben en el binario.
+// Cada una corre en su propia transacción; no usar sentencias que Postgres no admite dentro de una
+// (p.ej. CREATE INDEX CONCURRENTLY).
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+const (
+	migrationsTable = "schema_migrations"
+
+	// migrationLockKey identifica el advisory lock que serializa arranques concurrentes.
+	migrationLockKey int64 = 0x70617263656c // "parcel"
+)
+
+const (
+	MigrationApplied          = "applied"
+	MigrationPending          = "pending"
+	MigrationChecksumMismatch = "checksum_mismatch"
+	// MigrationUnknown es una versión aplicada en la base que no existe en el binario (p.ej. binario viejo).
+	MigrationUnknown = "unknown"
+)
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	State     string     `json:"state"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+type appliedMigration struct {
+	Version   int64     `gorm:"column:version"`
+	Name      string    `gorm:"column:name"`
+	Checksum  string    `gorm:"column:checksum"`
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+// Migrator aplica y revierte las migraciones versionadas registrando cada versión en schema_migrations.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	logger     *slog.Logger
+}
+
+func NewMigrator(db *gorm.DB, logger *slog.Logger) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations, logger: logging.OrDiscard(logger)}, nil
+}
+
+// Migrate aplica las migraciones pendientes.
+func Migrate(ctx context.Context, db *gorm.DB, logger *slog.Logger) error {
+	m, err := NewMigrator(db, logger)
+	if err != nil {
+		return err
+	}
+	_, err = m.Up(ctx)
+	return err
+}
+
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		match := migrationFileName.FindStringSubmatch(e.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migración con nombre inválido: %s", e.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		body, err := fs.ReadFile(fsys, "migrations/"+e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migración %d con nombres distintos: %s y %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	out := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" || strings.TrimSpace(m.Down) == "" {
+			return nil, fmt.Errorf("migración %04d_%s: falta up o down", m.Version, m.Name)
+		}
+		sum := sha256.Sum256([]byte(m.Up))
+		m.Checksum = hex.EncodeToString(sum[:])
+		out = append(out, *m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+// Status devuelve el estado de cada migración, incluidas las versiones aplicadas desconocidas para este binario.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]MigrationStatus, 0, len(m.migrations))
+	for _, mg := range m.migrations {
+		st := MigrationStatus{Version: mg.Version, Name: mg.Name, State: MigrationPending}
+		if a, ok := applied[mg.Version]; ok {
+			st.State = MigrationApplied
+			if a.Checksum != mg.Checksum {
+				st.State = MigrationChecksumMismatch
+			}
+			at := a.AppliedAt
+			st.AppliedAt = &at
+			delete(applied, mg.Version)
+		}
+		out = append(out, st)
+	}
+	for _, a := range applied {
+		at := a.AppliedAt
+		out = append(out, MigrationStatus{Version: a.Version, Name: a.Name, State: MigrationUnknown, AppliedAt: &at})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+// Check falla si hay migraciones pendientes o aplicadas con checksum distinto; lo usa readiness.
+func (m *Migrator) Check(ctx context.Context) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	pending := make([]string, 0)
+	mismatched := make([]string, 0)
+	for _, s := range status {
+		switch s.State {
+		case MigrationPending:
+			pending = append(pending, migrationLabel(s.Version, s.Name))
+		case MigrationChecksumMismatch:
+			mismatched = append(mismatched, migrationLabel(s.Version, s.Name))
+		}
+	}
+	if len(mismatched) > 0 {
+		return fmt.Errorf("migraciones modificadas después de aplicarse: %s", strings.Join(mismatched, ", "))
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("migraciones pendientes: %s", strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// Up aplica en orden las migraciones pendientes bajo el advisory lock. Falla sin aplicar nada si
+// alguna migración ya aplicada cambió de contenido.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	done := make([]Migration, 0)
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		if err := m.ensureTable(conn); err != nil {
+			return err
+		}
+		pending, err := m.pendingUp(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, mg := range pending {
+			start := time.Now()
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(mg.Up).Error; err != nil {
+					return err
+				}
+				return tx.Exec("INSERT INTO "+migrationsTable+" (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)",
+					mg.Version, mg.Name, mg.Checksum, time.Now().UTC()).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migración %s: %w", migrationLabel(mg.Version, mg.Name), err)
+			}
+			m.logger.InfoContext(ctx, "migración aplicada", "version", mg.Version, "name", mg.Name, "duration_ms", time.Since(start).Milliseconds())
+			done = append(done, mg)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down revierte las últimas steps migraciones aplicadas (en orden inverso).
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	done := make([]Migration, 0)
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		if err := m.ensureTable(conn); err != nil {
+			return err
+		}
+		targets, err := m.pendingDown(ctx, conn, steps)
+		if err != nil {
+			return err
+		}
+		for _, mg := range targets {
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(mg.Down).Error; err != nil {
+					return err
+				}
+				return tx.Exec("DELETE FROM "+migrationsTable+" WHERE version = ?", mg.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("revertir migración %s: %w", migrationLabel(mg.Version, mg.Name), err)
+			}
+			m.logger.InfoContext(ctx, "migración revertida", "version", mg.Version, "name", mg.Name)
+			done = append(done, mg)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// PlanUp escribe en w el SQL que aplicaría Up, sin ejecutarlo ni tomar el lock.
+func (m *Migrator) PlanUp(ctx context.Context, w io.Writer) error {
+	pending, err := m.pendingUp(ctx, m.db)
+	if err != nil {
+		return err
+	}
+	return writePlan(w, pending, true)
+}
+
+// PlanDown escribe en w el SQL que ejecutaría Down(steps), sin ejecutarlo.
+func (m *Migrator) PlanDown(ctx context.Context, w io.Writer, steps int) error {
+	targets, err := m.pendingDown(ctx, m.db, steps)
+	if err != nil {
+		return err
+	}
+	return writePlan(w, targets, false)
+}
+
+func writePlan(w io.Writer, migrations []Migration, up bool) error {
+	if len(migrations) == 0 {
+		_, err := fmt.Fprintln(w, "-- sin migraciones para ejecutar")
+		return err
+	}
+	for _, mg := range migrations {
+		body, dir := mg.Up, "up"
+		if !up {
+			body, dir = mg.Down, "down"
+		}
+		if _, err := fmt.Fprintf(w, "-- %s (%s)\n%s\n", migrationLabel(mg.Version, mg.Name), dir, strings.TrimRight(body, "\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) pendingUp(ctx context.Context, conn *gorm.DB) ([]Migration, error) {
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Migration, 0)
+	for _, mg := range m.migrations {
+		a, ok := applied[mg.Version]
+		if !ok {
+			out = append(out, mg)
+			continue
+		}
+		if a.Checksum != mg.Checksum {
+			return nil, fmt.Errorf("la migración %s cambió después de aplicarse (checksum %s, esperado %s); crear una migración nueva en lugar de editarla",
+				migrationLabel(mg.Version, mg.Name), a.Checksum, mg.Checksum)
+		}
+	}
+	return out, nil
+}
+
+func (m *Migrator) pendingDown(ctx context.Context, conn *gorm.DB, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, errors.New("steps debe ser mayor a 0")
+	}
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Migration, 0, steps)
+	for i := len(m.migrations) - 1; i >= 0 && len(out) < steps; i-- {
+		if _, ok := applied[m.migrations[i].Version]; ok {
+			out = append(out, m.migrations[i])
+		}
+	}
+	return out, nil
+}
+
+// applied lee schema_migrations; si la tabla no existe todavía, no hay nada aplicado.
+func (m *Migrator) applied(ctx context.Context, conn *gorm.DB) (map[int64]appliedMigration, error) {
+	conn = conn.WithContext(ctx)
+	out := map[int64]appliedMigration{}
+	if !conn.Migrator().HasTable(migrationsTable) {
+		return out, nil
+	}
+	var rows []appliedMigration
+	if err := conn.Raw("SELECT version, name, checksum, applied_at FROM " + migrationsTable).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		out[r.Version] = r
+	}
+	return out, nil
+}
+
+func (m *Migrator) ensureTable(conn *gorm.DB) error {
+	return conn.Exec(`CREATE TABLE IF NOT EXISTS ` + migrationsTable + ` (
+	version    bigint PRIMARY KEY,
+	name       text NOT NULL,
+	checksum   varchar(64) NOT NULL,
+	applied_at timestamptz NOT NULL
+)`).Error
+}
+
+// withLock ejecuta fn en una única conexión del pool con un advisory lock de sesión, para que dos
+// instancias que arrancan a la vez no apliquen la misma migración.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return fmt.Errorf("lock de migraciones: %w", err)
+		}
+		defer func() {
+			// con el ctx cancelado el unlock fallaría; el lock se libera igual al cerrar la conexión
+			if err := conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(?)", migrationLockKey).Error; err != nil {
+				m.logger.Warn("no se pudo liberar el lock de migraciones", "error", err)
+			}
+		}()
+		return fn(conn)
+	})
+}
+
+func migrationLabel(version int64, name string) string {
+	return fmt.Sprintf("%04d_%s", version, name)
+}
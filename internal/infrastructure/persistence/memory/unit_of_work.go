@@ -0,0 +1,82 @@
+// Package memory tiene la unidad de trabajo de los repositorios en memoria (desarrollo sin base).
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type journalKey struct{}
+
+// journal acumula las funciones que deshacen cada escritura de la unidad de trabajo.
+type journal struct {
+	mu   sync.Mutex
+	undo []func()
+}
+
+func (j *journal) rollback() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for i := len(j.undo) - 1; i >= 0; i-- {
+		j.undo[i]()
+	}
+	j.undo = nil
+}
+
+// InMemoryUnitOfWork serializa las unidades de trabajo y, si fn falla, deshace en orden inverso las
+// escrituras que los repositorios en memoria registraron con OnRollback. Las lecturas fuera de una
+// unidad de trabajo pueden ver cambios que luego se revierten.
+type InMemoryUnitOfWork struct {
+	mu sync.Mutex
+}
+
+var _ port.UnitOfWork = (*InMemoryUnitOfWork)(nil)
+
+func NewInMemoryUnitOfWork() *InMemoryUnitOfWork {
+	return &InMemoryUnitOfWork{}
+}
+
+func (u *InMemoryUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(journalKey{}).(*journal); ok {
+		return fn(ctx)
+	}
+
+	ctx, span := tracing.Start(ctx, "UnitOfWork.Do")
+	defer span.End()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	ctx, runCommitHooks := port.WithCommitHooks(ctx)
+	j := &journal{}
+	defer func() {
+		if r := recover(); r != nil {
+			j.rollback()
+			panic(r)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, journalKey{}, j)); err != nil {
+		j.rollback()
+		tracing.RecordError(span, err)
+		return err
+	}
+	runCommitHooks()
+	return nil
+}
+
+// OnRollback registra cómo deshacer una escritura si ctx pertenece a una unidad de trabajo;
+// fuera de una unidad de trabajo no hace nada. undo corre después de que la escritura terminó, así que
+// debe tomar el lock del repositorio por su cuenta.
+func OnRollback(ctx context.Context, undo func()) {
+	j, ok := ctx.Value(journalKey{}).(*journal)
+	if !ok {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.undo = append(j.undo, undo)
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	outboxdomain "ms-parcel-core/internal/parcel/parcel_outbox/domain"
+)
+
+// DBOutboxEvent representa el modelo de base de datos para OutboxEvent
+type DBOutboxEvent struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key"`
+	TenantID      string    `gorm:"type:varchar(100);not null;index"`
+	EventType     string    `gorm:"type:varchar(100);not null"`
+	Version       int       `gorm:"not null;default:1"`
+	AggregateType string    `gorm:"type:varchar(50);not null"`
+	AggregateID   string    `gorm:"type:varchar(100);not null"`
+	OccurredAt    time.Time `gorm:"not null"`
+	Payload       string    `gorm:"type:jsonb;not null"`
+	Status        string    `gorm:"type:varchar(20);not null"`
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"not null"`
+	LastError     *string   `gorm:"type:text"`
+	PublishedAt   *time.Time
+	CreatedAt     time.Time `gorm:"not null"`
+}
+
+func (DBOutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// ToDomain convierte DBOutboxEvent a outboxdomain.OutboxEvent
+func (db *DBOutboxEvent) ToDomain() outboxdomain.OutboxEvent {
+	var payload map[string]any
+	if db.Payload != "" {
+		_ = json.Unmarshal([]byte(db.Payload), &payload)
+	}
+	return outboxdomain.OutboxEvent{
+		ID:            db.ID.String(),
+		TenantID:      db.TenantID,
+		Type:          db.EventType,
+		Version:       db.Version,
+		AggregateType: db.AggregateType,
+		AggregateID:   db.AggregateID,
+		OccurredAt:    db.OccurredAt,
+		Payload:       payload,
+		Status:        outboxdomain.OutboxStatus(db.Status),
+		Attempts:      db.Attempts,
+		NextAttemptAt: db.NextAttemptAt,
+		LastError:     db.LastError,
+		PublishedAt:   db.PublishedAt,
+		CreatedAt:     db.CreatedAt,
+	}
+}
+
+// FromDomain convierte outboxdomain.OutboxEvent a DBOutboxEvent. A diferencia de la metadata de
+// tracking, un payload que no se puede serializar es un error: el evento no se publicaría nunca.
+func (db *DBOutboxEvent) FromDomain(ev outboxdomain.OutboxEvent) error {
+	id, err := uuid.Parse(ev.ID)
+	if err != nil {
+		return err
+	}
+	payload := ev.Payload
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	*db = DBOutboxEvent{
+		ID:            id,
+		TenantID:      ev.TenantID,
+		EventType:     ev.Type,
+		Version:       ev.Version,
+		AggregateType: ev.AggregateType,
+		AggregateID:   ev.AggregateID,
+		OccurredAt:    ev.OccurredAt,
+		Payload:       string(data),
+		Status:        string(ev.Status),
+		Attempts:      ev.Attempts,
+		NextAttemptAt: ev.NextAttemptAt,
+		LastError:     ev.LastError,
+		PublishedAt:   ev.PublishedAt,
+		CreatedAt:     ev.CreatedAt,
+	}
+	return nil
+}
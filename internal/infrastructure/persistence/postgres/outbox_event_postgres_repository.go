@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"ms-parcel-core/internal/parcel/parcel_outbox/domain"
+	"ms-parcel-core/internal/parcel/parcel_outbox/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+// OutboxEventPostgresRepository implementa OutboxRepository usando GORM
+type OutboxEventPostgresRepository struct {
+	db *gorm.DB
+}
+
+var _ port.OutboxRepository = (*OutboxEventPostgresRepository)(nil)
+
+// NewOutboxEventPostgresRepository crea una nueva instancia del repositorio
+func NewOutboxEventPostgresRepository(db *gorm.DB) *OutboxEventPostgresRepository {
+	return &OutboxEventPostgresRepository{db: db}
+}
+
+func (r *OutboxEventPostgresRepository) Add(ctx context.Context, ev domain.OutboxEvent) error {
+	ctx, span := tracing.Start(ctx, "OutboxRepository.Add", tracing.TenantID(ev.TenantID))
+	defer span.End()
+
+	var m DBOutboxEvent
+	if err := m.FromDomain(ev); err != nil {
+		return apperror.NewInternal("internal_error", "evento de outbox inválido", map[string]any{"type": ev.Type})
+	}
+	return withTenant(ctx, r.db, ev.TenantID).Create(&m).Error
+}
+
+// ClaimDue reclama el lote con un único UPDATE sobre un SELECT ... FOR UPDATE SKIP LOCKED: varias
+// instancias pueden despachar a la vez sin bloquearse ni tomar el mismo evento.
+func (r *OutboxEventPostgresRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.OutboxEvent, error) {
+	ctx, span := tracing.Start(ctx, "OutboxRepository.ClaimDue")
+	defer span.End()
+
+	if limit <= 0 {
+		limit = 100
+	}
+	db := conn(ctx, r.db)
+	due := db.Model(&DBOutboxEvent{}).Select("id").
+		Where(outboxEventDueSQL, now).
+		Order("created_at ASC").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked})
+
+	var rows []DBOutboxEvent
+	err := db.Model(&rows).Clauses(clause.Returning{}).
+		Where("id IN (?)", due).
+		Updates(map[string]any{
+			"status":          string(domain.OutboxStatusPublishing),
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": now.Add(lease),
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// RETURNING no garantiza orden
+	out := make([]domain.OutboxEvent, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].ToDomain())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (r *OutboxEventPostgresRepository) SaveDelivery(ctx context.Context, ev domain.OutboxEvent) error {
+	ctx, span := tracing.Start(ctx, "OutboxRepository.SaveDelivery", tracing.TenantID(ev.TenantID))
+	defer span.End()
+
+	id, err := uuid.Parse(ev.ID)
+	if err != nil {
+		return apperror.New("not_found", "evento no encontrado", map[string]any{"event_id": ev.ID}, 404)
+	}
+
+	// Solo se actualizan los campos de entrega; el evento en sí es inmutable. La condición sobre estado
+	// e intentos descarta el resultado si el lease venció y otro dispatcher ya lo retomó.
+	res := withTenant(ctx, r.db, ev.TenantID).Model(&DBOutboxEvent{}).
+		Where("id = ? AND status = ? AND attempts = ?", id, string(domain.OutboxStatusPublishing), ev.Attempts).
+		Updates(map[string]any{
+			"status":          string(ev.Status),
+			"attempts":        ev.Attempts,
+			"next_attempt_at": ev.NextAttemptAt,
+			"last_error":      ev.LastError,
+			"published_at":    ev.PublishedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return port.ErrEventChanged
+	}
+	return nil
+}
+
+// outboxEventDueSQL es el equivalente SQL de outboxEventDue del repositorio en memoria.
+var outboxEventDueSQL = "status IN ('" + string(domain.OutboxStatusPending) + "', '" + string(domain.OutboxStatusPublishing) + "')" +
+	" AND next_attempt_at <= ?"
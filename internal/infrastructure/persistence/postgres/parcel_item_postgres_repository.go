@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"ms-parcel-core/internal/parcel/parcel_item/domain"
+	"ms-parcel-core/internal/parcel/parcel_item/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+// ParcelItemPostgresRepository filtra por tenant a través del parcel: parcel_items no tiene tenant_id.
+type ParcelItemPostgresRepository struct {
+	db *gorm.DB
+}
+
+var _ port.ParcelItemRepository = (*ParcelItemPostgresRepository)(nil)
+
+const parcelItemTenantScope = "EXISTS (SELECT 1 FROM parcels p WHERE p.id = parcel_items.parcel_id AND p.tenant_id = ?)"
+
+func NewParcelItemPostgresRepository(db *gorm.DB) *ParcelItemPostgresRepository {
+	return &ParcelItemPostgresRepository{db: db}
+}
+
+func (r *ParcelItemPostgresRepository) Add(ctx context.Context, tenantID string, item domain.ParcelItem) (uuid.UUID, error) {
+	ctx, span := tracing.Start(ctx, "ParcelItemRepository.Add", tracing.TenantID(tenantID))
+	defer span.End()
+
+	var m DBParcelItem
+	if err := m.FromDomain(item); err != nil {
+		return uuid.Nil, apperror.NewBadRequest("validation_error", "parcel_id inválido", map[string]any{"field": "parcel_id"})
+	}
+	if err := conn(ctx, r.db).Create(&m).Error; err != nil {
+		return uuid.Nil, err
+	}
+	return m.ID, nil
+}
+
+func (r *ParcelItemPostgresRepository) ListByParcelID(ctx context.Context, tenantID string, parcelID uuid.UUID) ([]domain.ParcelItem, error) {
+	ctx, span := tracing.Start(ctx, "ParcelItemRepository.ListByParcelID", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	var rows []DBParcelItem
+	err := conn(ctx, r.db).
+		Where("parcel_id = ?", parcelID).
+		Where(parcelItemTenantScope, tenantID).
+		Order("created_at ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.ParcelItem, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].ToDomain())
+	}
+	return out, nil
+}
+
+func (r *ParcelItemPostgresRepository) Delete(ctx context.Context, tenantID string, parcelID uuid.UUID, itemID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "ParcelItemRepository.Delete", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	return conn(ctx, r.db).
+		Where("id = ? AND parcel_id = ?", itemID, parcelID).
+		Where(parcelItemTenantScope, tenantID).
+		Delete(&DBParcelItem{}).Error
+}
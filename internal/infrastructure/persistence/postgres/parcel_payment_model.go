@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -27,6 +28,23 @@ type DBParcelPayment struct {
 	UpdatedAt    time.Time `gorm:"not null"`
 	PaidAt       *time.Time
 	PaidByUserID *string `gorm:"type:varchar(100)"`
+
+	ItemsAmount        float64 `gorm:"type:decimal(10,2);not null;default:0"`
+	SurchargesAmount   float64 `gorm:"type:decimal(10,2);not null;default:0"`
+	Surcharges         *string `gorm:"type:jsonb"`
+	AmountSource       string  `gorm:"type:varchar(20);not null;default:'AUTO'"`
+	ManualAmountReason *string `gorm:"type:varchar(200)"`
+	AmountStale        bool    `gorm:"not null;default:false"`
+	AmountCalculatedAt *time.Time
+	PaidAmount         float64 `gorm:"type:decimal(10,2);not null;default:0"`
+	RefundedAmount     float64 `gorm:"type:decimal(10,2);not null;default:0"`
+	Balance            float64 `gorm:"type:decimal(10,2);not null;default:0"`
+}
+
+type dbPaymentSurcharge struct {
+	Code        string  `json:"code"`
+	Description *string `json:"description,omitempty"`
+	Amount      float64 `json:"amount"`
 }
 
 func (DBParcelPayment) TableName() string {
@@ -35,6 +53,15 @@ func (DBParcelPayment) TableName() string {
 
 // ToDomain convierte DBParcelPayment a paymentdomain.ParcelPayment
 func (db *DBParcelPayment) ToDomain() paymentdomain.ParcelPayment {
+	var surcharges []paymentdomain.PaymentSurcharge
+	if db.Surcharges != nil && *db.Surcharges != "" {
+		var raw []dbPaymentSurcharge
+		_ = json.Unmarshal([]byte(*db.Surcharges), &raw)
+		for _, sc := range raw {
+			surcharges = append(surcharges, paymentdomain.PaymentSurcharge{Code: sc.Code, Description: sc.Description, Amount: sc.Amount})
+		}
+	}
+
 	return paymentdomain.ParcelPayment{
 		ID:           db.ID.String(),
 		ParcelID:     db.ParcelID.String(),
@@ -52,6 +79,17 @@ func (db *DBParcelPayment) ToDomain() paymentdomain.ParcelPayment {
 		UpdatedAt:    db.UpdatedAt,
 		PaidAt:       db.PaidAt,
 		PaidByUserID: db.PaidByUserID,
+
+		ItemsAmount:        db.ItemsAmount,
+		Surcharges:         surcharges,
+		SurchargesAmount:   db.SurchargesAmount,
+		AmountSource:       paymentdomain.PaymentAmountSource(db.AmountSource),
+		ManualAmountReason: db.ManualAmountReason,
+		AmountStale:        db.AmountStale,
+		AmountCalculatedAt: db.AmountCalculatedAt,
+		PaidAmount:         db.PaidAmount,
+		RefundedAmount:     db.RefundedAmount,
+		Balance:            db.Balance,
 	}
 }
 
@@ -70,6 +108,19 @@ func (db *DBParcelPayment) FromDomain(p paymentdomain.ParcelPayment) error {
 		return err
 	}
 
+	var surchargesJSON *string
+	if p.Surcharges != nil {
+		raw := make([]dbPaymentSurcharge, 0, len(p.Surcharges))
+		for _, sc := range p.Surcharges {
+			raw = append(raw, dbPaymentSurcharge{Code: sc.Code, Description: sc.Description, Amount: sc.Amount})
+		}
+		data, err := json.Marshal(raw)
+		if err == nil {
+			str := string(data)
+			surchargesJSON = &str
+		}
+	}
+
 	*db = DBParcelPayment{
 		ID:           id,
 		ParcelID:     parcelID,
@@ -87,6 +138,17 @@ func (db *DBParcelPayment) FromDomain(p paymentdomain.ParcelPayment) error {
 		UpdatedAt:    p.UpdatedAt,
 		PaidAt:       p.PaidAt,
 		PaidByUserID: p.PaidByUserID,
+
+		ItemsAmount:        p.ItemsAmount,
+		SurchargesAmount:   p.SurchargesAmount,
+		Surcharges:         surchargesJSON,
+		AmountSource:       string(p.AmountSource),
+		ManualAmountReason: p.ManualAmountReason,
+		AmountStale:        p.AmountStale,
+		AmountCalculatedAt: p.AmountCalculatedAt,
+		PaidAmount:         p.PaidAmount,
+		RefundedAmount:     p.RefundedAmount,
+		Balance:            p.Balance,
 	}
 	return nil
 }
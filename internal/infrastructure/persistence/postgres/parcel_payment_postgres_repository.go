@@ -0,0 +1,212 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"ms-parcel-core/internal/parcel/parcel_payment/domain"
+	"ms-parcel-core/internal/parcel/parcel_payment/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type ParcelPaymentPostgresRepository struct {
+	db *gorm.DB
+}
+
+var _ port.ParcelPaymentRepository = (*ParcelPaymentPostgresRepository)(nil)
+
+// parcelPaymentUpsertColumns son las columnas que reemplaza Upsert; id, parcel_id, tenant_id y created_at se conservan.
+var parcelPaymentUpsertColumns = []string{
+	"payment_type", "status", "amount", "currency", "channel", "office_id", "cashbox_id", "seller_user_id",
+	"notes", "updated_at", "paid_at", "paid_by_user_id", "items_amount", "surcharges_amount", "surcharges",
+	"amount_source", "manual_amount_reason", "amount_stale", "amount_calculated_at", "paid_amount",
+	"refunded_amount", "balance",
+}
+
+func NewParcelPaymentPostgresRepository(db *gorm.DB) *ParcelPaymentPostgresRepository {
+	return &ParcelPaymentPostgresRepository{db: db}
+}
+
+func (r *ParcelPaymentPostgresRepository) Upsert(ctx context.Context, tenantID string, p domain.ParcelPayment) (*domain.ParcelPayment, error) {
+	ctx, span := tracing.Start(ctx, "ParcelPaymentRepository.Upsert", tracing.TenantID(tenantID))
+	defer span.End()
+
+	var m DBParcelPayment
+	if err := m.FromDomain(p); err != nil {
+		return nil, apperror.NewBadRequest("validation_error", "parcel_id inválido", map[string]any{"field": "parcel_id"})
+	}
+	err := withTenant(ctx, r.db, tenantID).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "parcel_id"}},
+		DoUpdates: clause.AssignmentColumns(parcelPaymentUpsertColumns),
+	}).Create(&m).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.getByParcelID(ctx, tenantID, m.ParcelID)
+}
+
+func (r *ParcelPaymentPostgresRepository) GetByParcelID(ctx context.Context, tenantID string, parcelID uuid.UUID) (*domain.ParcelPayment, error) {
+	ctx, span := tracing.Start(ctx, "ParcelPaymentRepository.GetByParcelID", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	return r.getByParcelID(ctx, tenantID, parcelID)
+}
+
+func (r *ParcelPaymentPostgresRepository) LockByParcelID(ctx context.Context, tenantID string, parcelID uuid.UUID) (*domain.ParcelPayment, error) {
+	ctx, span := tracing.Start(ctx, "ParcelPaymentRepository.LockByParcelID", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	var m DBParcelPayment
+	err := withTenant(ctx, r.db, tenantID).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("parcel_id = ?", parcelID).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := m.ToDomain()
+	return &out, nil
+}
+
+func (r *ParcelPaymentPostgresRepository) AddTransaction(ctx context.Context, tenantID string, tx domain.PaymentTransaction) (*domain.PaymentTransaction, error) {
+	ctx, span := tracing.Start(ctx, "ParcelPaymentRepository.AddTransaction", tracing.TenantID(tenantID))
+	defer span.End()
+
+	if tx.ID == "" {
+		tx.ID = uuid.NewString()
+	}
+	tx.TenantID = tenantID
+
+	var m DBPaymentTransaction
+	if err := m.FromDomain(tx); err != nil {
+		return nil, apperror.NewBadRequest("validation_error", "transacción inválida", map[string]any{"error": err.Error()})
+	}
+	if err := withTenant(ctx, r.db, tenantID).Create(&m).Error; err != nil {
+		return nil, err
+	}
+	out := m.ToDomain()
+	return &out, nil
+}
+
+func (r *ParcelPaymentPostgresRepository) ListTransactions(ctx context.Context, tenantID string, parcelID uuid.UUID) ([]domain.PaymentTransaction, error) {
+	ctx, span := tracing.Start(ctx, "ParcelPaymentRepository.ListTransactions", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	var rows []DBPaymentTransaction
+	if err := withTenant(ctx, r.db, tenantID).Where("parcel_id = ?", parcelID).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return paymentTransactionsToDomain(rows), nil
+}
+
+// ClaimCashboxPosting toma la transacción con un único UPDATE condicional, así dos procesos no
+// pueden reclamar la misma transacción.
+func (r *ParcelPaymentPostgresRepository) ClaimCashboxPosting(ctx context.Context, tenantID string, parcelID uuid.UUID, txID string, now time.Time, lease time.Duration) (*domain.PaymentTransaction, error) {
+	ctx, span := tracing.Start(ctx, "ParcelPaymentRepository.ClaimCashboxPosting", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	id, err := uuid.Parse(txID)
+	if err != nil {
+		return nil, apperror.New("not_found", "transacción no encontrada", map[string]any{"transaction_id": txID}, 404)
+	}
+
+	var rows []DBPaymentTransaction
+	res := withTenant(ctx, r.db, tenantID).Model(&rows).Clauses(clause.Returning{}).
+		Where("id = ? AND parcel_id = ?", id, parcelID).
+		Where(cashboxPostingDueSQL, now).
+		Updates(map[string]any{
+			"cashbox_posting_status":  string(domain.CashboxPostingInProgress),
+			"cashbox_next_attempt_at": now.Add(lease),
+		})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if len(rows) > 0 {
+		out := rows[0].ToDomain()
+		return &out, nil
+	}
+
+	// No se pudo reclamar: ya posteada, o la tiene otro proceso, o no existe
+	var count int64
+	if err := withTenant(ctx, r.db, tenantID).Model(&DBPaymentTransaction{}).Where("id = ? AND parcel_id = ?", id, parcelID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, apperror.New("not_found", "transacción no encontrada", map[string]any{"transaction_id": txID}, 404)
+	}
+	return nil, nil
+}
+
+func (r *ParcelPaymentPostgresRepository) SaveCashboxPosting(ctx context.Context, tenantID string, tx domain.PaymentTransaction) error {
+	ctx, span := tracing.Start(ctx, "ParcelPaymentRepository.SaveCashboxPosting", tracing.TenantID(tenantID))
+	defer span.End()
+
+	id, err := uuid.Parse(tx.ID)
+	if err != nil {
+		return apperror.New("not_found", "transacción no encontrada", map[string]any{"transaction_id": tx.ID}, 404)
+	}
+
+	// Solo se actualizan los campos de posteo; el resto de la transacción es inmutable
+	res := withTenant(ctx, r.db, tenantID).Model(&DBPaymentTransaction{}).Where("id = ?", id).Updates(map[string]any{
+		"cashbox_movement_id":      tx.CashboxMovementID,
+		"cashbox_posting_status":   string(tx.CashboxPostingStatus),
+		"cashbox_posting_attempts": tx.CashboxPostingAttempts,
+		"cashbox_posting_error":    tx.CashboxPostingError,
+		"cashbox_next_attempt_at":  tx.CashboxNextAttemptAt,
+		"cashbox_posted_at":        tx.CashboxPostedAt,
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return apperror.New("not_found", "transacción no encontrada", map[string]any{"transaction_id": tx.ID}, 404)
+	}
+	return nil
+}
+
+func (r *ParcelPaymentPostgresRepository) ListPendingCashboxPostings(ctx context.Context, now time.Time, limit int) ([]domain.PaymentTransaction, error) {
+	ctx, span := tracing.Start(ctx, "ParcelPaymentRepository.ListPendingCashboxPostings")
+	defer span.End()
+
+	q := conn(ctx, r.db).Where(cashboxPostingDueSQL, now).Order("created_at ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	var rows []DBPaymentTransaction
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return paymentTransactionsToDomain(rows), nil
+}
+
+// cashboxPostingDueSQL es el equivalente SQL de cashboxPostingDue del repositorio en memoria.
+var cashboxPostingDueSQL = "cashbox_posting_status IN ('" + string(domain.CashboxPostingPending) + "', '" + string(domain.CashboxPostingInProgress) + "')" +
+	" AND (cashbox_next_attempt_at IS NULL OR cashbox_next_attempt_at <= ?)"
+
+func (r *ParcelPaymentPostgresRepository) getByParcelID(ctx context.Context, tenantID string, parcelID uuid.UUID) (*domain.ParcelPayment, error) {
+	var m DBParcelPayment
+	err := withTenant(ctx, r.db, tenantID).Where("parcel_id = ?", parcelID).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := m.ToDomain()
+	return &out, nil
+}
+
+func paymentTransactionsToDomain(rows []DBPaymentTransaction) []domain.PaymentTransaction {
+	out := make([]domain.PaymentTransaction, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].ToDomain())
+	}
+	return out
+}
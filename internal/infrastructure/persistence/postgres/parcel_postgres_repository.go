@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"ms-parcel-core/internal/parcel/parcel_core/domain"
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type ParcelPostgresRepository struct {
+	db *gorm.DB
+}
+
+var _ port.ParcelRepository = (*ParcelPostgresRepository)(nil)
+var _ port.TrackingCodeChecker = (*ParcelPostgresRepository)(nil)
+var _ port.TrackingCodeResolver = (*ParcelPostgresRepository)(nil)
+
+func NewParcelPostgresRepository(db *gorm.DB) *ParcelPostgresRepository {
+	return &ParcelPostgresRepository{db: db}
+}
+
+func (r *ParcelPostgresRepository) Create(ctx context.Context, p domain.Parcel) (uuid.UUID, error) {
+	ctx, span := tracing.Start(ctx, "ParcelRepository.Create", tracing.TenantID(p.TenantID))
+	defer span.End()
+
+	var m DBParcel
+	if err := m.FromDomain(p); err != nil {
+		return uuid.Nil, err
+	}
+	if err := withTenant(ctx, r.db, p.TenantID).Create(&m).Error; err != nil {
+		return uuid.Nil, err
+	}
+	return m.ID, nil
+}
+
+func (r *ParcelPostgresRepository) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.Parcel, error) {
+	ctx, span := tracing.Start(ctx, "ParcelRepository.GetByID", tracing.TenantID(tenantID), tracing.ParcelID(id.String()))
+	defer span.End()
+
+	return r.get(ctx, tenantID, id)
+}
+
+func (r *ParcelPostgresRepository) LockByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.Parcel, error) {
+	ctx, span := tracing.Start(ctx, "ParcelRepository.LockByID", tracing.TenantID(tenantID), tracing.ParcelID(id.String()))
+	defer span.End()
+
+	var m DBParcel
+	err := withTenant(ctx, r.db, tenantID).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("id = ?", id).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := m.ToDomain()
+	return &out, nil
+}
+
+func (r *ParcelPostgresRepository) UpdateRegistered(ctx context.Context, tenantID string, id uuid.UUID, registeredAtUTC time.Time, userID string, userName string) (*domain.Parcel, error) {
+	ctx, span := tracing.Start(ctx, "ParcelRepository.UpdateRegistered", tracing.TenantID(tenantID), tracing.ParcelID(id.String()))
+	defer span.End()
+	_ = userID
+	_ = userName
+
+	return r.update(ctx, tenantID, id, map[string]any{
+		"status":        string(domain.ParcelStatusRegistered),
+		"registered_at": registeredAtUTC,
+	})
+}
+
+func (r *ParcelPostgresRepository) UpdateBoarded(ctx context.Context, tenantID string, id uuid.UUID, boardedAtUTC time.Time, vehicleID string, tripID *string, departureAt *time.Time, boardedByUserID *string) (*domain.Parcel, error) {
+	ctx, span := tracing.Start(ctx, "ParcelRepository.UpdateBoarded", tracing.TenantID(tenantID), tracing.ParcelID(id.String()))
+	defer span.End()
+
+	return r.update(ctx, tenantID, id, map[string]any{
+		"status":               string(domain.ParcelStatusBoarded),
+		"boarded_at":           boardedAtUTC,
+		"boarded_vehicle_id":   vehicleID,
+		"boarded_trip_id":      tripID,
+		"boarded_departure_at": departureAt,
+		"boarded_by_user_id":   boardedByUserID,
+	})
+}
+
+func (r *ParcelPostgresRepository) UpdateDelivered(ctx context.Context, tenantID string, id uuid.UUID, deliveredAtUTC time.Time, deliveredByUserID *string) (*domain.Parcel, error) {
+	ctx, span := tracing.Start(ctx, "ParcelRepository.UpdateDelivered", tracing.TenantID(tenantID), tracing.ParcelID(id.String()))
+	defer span.End()
+
+	return r.update(ctx, tenantID, id, map[string]any{
+		"status":               string(domain.ParcelStatusDelivered),
+		"delivered_at":         deliveredAtUTC,
+		"delivered_by_user_id": deliveredByUserID,
+	})
+}
+
+func (r *ParcelPostgresRepository) UpdateArrivedDestination(ctx context.Context, tenantID string, id uuid.UUID, arrivedAtUTC time.Time, arrivedByUserID *string) (*domain.Parcel, error) {
+	ctx, span := tracing.Start(ctx, "ParcelRepository.UpdateArrivedDestination", tracing.TenantID(tenantID), tracing.ParcelID(id.String()))
+	defer span.End()
+
+	return r.update(ctx, tenantID, id, map[string]any{
+		"status":             string(domain.ParcelStatusArrivedDestination),
+		"arrived_at":         arrivedAtUTC,
+		"arrived_by_user_id": arrivedByUserID,
+	})
+}
+
+func (r *ParcelPostgresRepository) UpdateInTransit(ctx context.Context, tenantID string, id uuid.UUID, departedAtUTC time.Time, departedByUserID *string, vehicleID *string) (*domain.Parcel, error) {
+	ctx, span := tracing.Start(ctx, "ParcelRepository.UpdateInTransit", tracing.TenantID(tenantID), tracing.ParcelID(id.String()))
+	defer span.End()
+
+	values := map[string]any{
+		"status":              string(domain.ParcelStatusInTransit),
+		"departed_at":         departedAtUTC,
+		"departed_by_user_id": departedByUserID,
+	}
+	if vehicleID != nil {
+		// En MVP usamos boarded_vehicle_id como referencia del vehículo de tránsito
+		values["boarded_vehicle_id"] = *vehicleID
+	}
+	return r.update(ctx, tenantID, id, values)
+}
+
+func (r *ParcelPostgresRepository) ListByFilters(ctx context.Context, tenantID string, f port.ListParcelFilters) ([]domain.Parcel, error) {
+	ctx, span := tracing.Start(ctx, "ParcelRepository.ListByFilters", tracing.TenantID(tenantID))
+	defer span.End()
+
+	var rows []DBParcel
+	if err := applyParcelFilters(withTenant(ctx, r.db, tenantID).Model(&DBParcel{}), f).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return parcelsToDomain(rows), nil
+}
+
+func (r *ParcelPostgresRepository) List(ctx context.Context, tenantID string, f port.ListParcelFilters) ([]domain.Parcel, int, error) {
+	ctx, span := tracing.Start(ctx, "ParcelRepository.List", tracing.TenantID(tenantID))
+	defer span.End()
+
+	q := applyParcelFilters(withTenant(ctx, r.db, tenantID).Model(&DBParcel{}), f)
+	if s := parcelQuery(f); s != "" {
+		if id, err := uuid.Parse(s); err == nil {
+			q = q.Where("id = ?", id)
+		} else {
+			q = q.Where("LOWER(tracking_code) = LOWER(?)", s)
+		}
+	}
+	q = q.Session(&gorm.Session{})
+
+	var count int64
+	if err := q.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	offset := f.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	var rows []DBParcel
+	if err := q.Order("created_at DESC").Limit(limit).Offset(offset).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return parcelsToDomain(rows), int(count), nil
+}
+
+// ExistsTrackingCode busca en todos los tenants: el índice único de tracking_code es global.
+func (r *ParcelPostgresRepository) ExistsTrackingCode(ctx context.Context, code string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "ParcelRepository.ExistsTrackingCode")
+	defer span.End()
+
+	var count int64
+	if err := conn(ctx, r.db).Model(&DBParcel{}).Where("tracking_code = ?", code).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// FindByTrackingCode busca en todos los tenants (sin scope): solo para el seguimiento público.
+func (r *ParcelPostgresRepository) FindByTrackingCode(ctx context.Context, code string) (*domain.Parcel, error) {
+	ctx, span := tracing.Start(ctx, "ParcelRepository.FindByTrackingCode")
+	defer span.End()
+
+	var m DBParcel
+	err := conn(ctx, r.db).Where("tracking_code = ?", code).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := m.ToDomain()
+	return &p, nil
+}
+
+func (r *ParcelPostgresRepository) get(ctx context.Context, tenantID string, id uuid.UUID) (*domain.Parcel, error) {
+	var m DBParcel
+	err := withTenant(ctx, r.db, tenantID).Where("id = ?", id).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := m.ToDomain()
+	return &p, nil
+}
+
+// update aplica los cambios de una transición y devuelve el parcel actualizado (nil si no existe).
+func (r *ParcelPostgresRepository) update(ctx context.Context, tenantID string, id uuid.UUID, values map[string]any) (*domain.Parcel, error) {
+	res := withTenant(ctx, r.db, tenantID).Model(&DBParcel{}).Where("id = ?", id).Updates(values)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	return r.get(ctx, tenantID, id)
+}
+
+func applyParcelFilters(q *gorm.DB, f port.ListParcelFilters) *gorm.DB {
+	if f.Status != nil {
+		q = q.Where("status = ?", string(*f.Status))
+	}
+	if f.VehicleID != nil {
+		q = q.Where("boarded_vehicle_id = ?", *f.VehicleID)
+	}
+	if f.OriginOfficeID != nil {
+		q = q.Where("origin_office_id = ?", *f.OriginOfficeID)
+	}
+	if f.DestinationOfficeID != nil {
+		q = q.Where("destination_office_id = ?", *f.DestinationOfficeID)
+	}
+	if f.SenderPersonID != nil {
+		q = q.Where("sender_person_id = ?", *f.SenderPersonID)
+	}
+	if f.RecipientPersonID != nil {
+		q = q.Where("recipient_person_id = ?", *f.RecipientPersonID)
+	}
+	if f.FromCreatedAt != nil {
+		q = q.Where("created_at >= ?", f.FromCreatedAt.UTC())
+	}
+	if f.ToCreatedAt != nil {
+		q = q.Where("created_at <= ?", f.ToCreatedAt.UTC())
+	}
+	return q
+}
+
+func parcelQuery(f port.ListParcelFilters) string {
+	if f.Query == nil {
+		return ""
+	}
+	return strings.TrimSpace(*f.Query)
+}
+
+func parcelsToDomain(rows []DBParcel) []domain.Parcel {
+	out := make([]domain.Parcel, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].ToDomain())
+	}
+	return out
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	paymentdomain "ms-parcel-core/internal/parcel/parcel_payment/domain"
+)
+
+// DBPaymentTransaction representa el modelo de base de datos para PaymentTransaction
+type DBPaymentTransaction struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	TenantID        string    `gorm:"type:varchar(100);not null;index"`
+	ParcelID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	PaymentID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	Kind            string    `gorm:"type:varchar(20);not null"`
+	PaymentType     string    `gorm:"type:varchar(50);not null"`
+	Channel         string    `gorm:"type:varchar(50);not null"`
+	Amount          float64   `gorm:"type:decimal(10,2);not null"`
+	Currency        string    `gorm:"type:varchar(3);not null"`
+	OfficeID        *string   `gorm:"type:varchar(100)"`
+	CashboxID       *string   `gorm:"type:varchar(100)"`
+	SellerUserID    *string   `gorm:"type:varchar(100)"`
+	CreatedByUserID *string   `gorm:"type:varchar(100)"`
+	Notes           *string   `gorm:"type:text"`
+	CreatedAt       time.Time `gorm:"not null;index"`
+
+	Reason                *string    `gorm:"type:text"`
+	ApprovedByUserID      *string    `gorm:"type:varchar(100)"`
+	ReversesTransactionID *uuid.UUID `gorm:"type:uuid;index"`
+
+	CashboxMovementID      *string    `gorm:"type:varchar(100)"`
+	CashboxPostingStatus   string     `gorm:"type:varchar(20);index"`
+	CashboxPostingAttempts int        `gorm:"not null;default:0"`
+	CashboxPostingError    *string    `gorm:"type:text"`
+	CashboxNextAttemptAt   *time.Time `gorm:"index"`
+	CashboxPostedAt        *time.Time
+}
+
+func (DBPaymentTransaction) TableName() string {
+	return "parcel_payment_transactions"
+}
+
+// ToDomain convierte DBPaymentTransaction a paymentdomain.PaymentTransaction
+func (db *DBPaymentTransaction) ToDomain() paymentdomain.PaymentTransaction {
+	out := paymentdomain.PaymentTransaction{
+		ID:              db.ID.String(),
+		TenantID:        db.TenantID,
+		ParcelID:        db.ParcelID.String(),
+		PaymentID:       db.PaymentID.String(),
+		Kind:            paymentdomain.PaymentTransactionKind(db.Kind),
+		PaymentType:     paymentdomain.PaymentType(db.PaymentType),
+		Channel:         paymentdomain.PaymentChannel(db.Channel),
+		Amount:          db.Amount,
+		Currency:        paymentdomain.Currency(db.Currency),
+		OfficeID:        db.OfficeID,
+		CashboxID:       db.CashboxID,
+		SellerUserID:    db.SellerUserID,
+		CreatedByUserID: db.CreatedByUserID,
+		Notes:           db.Notes,
+		CreatedAt:       db.CreatedAt,
+
+		Reason:           db.Reason,
+		ApprovedByUserID: db.ApprovedByUserID,
+
+		CashboxMovementID:      db.CashboxMovementID,
+		CashboxPostingStatus:   paymentdomain.CashboxPostingStatus(db.CashboxPostingStatus),
+		CashboxPostingAttempts: db.CashboxPostingAttempts,
+		CashboxPostingError:    db.CashboxPostingError,
+		CashboxNextAttemptAt:   db.CashboxNextAttemptAt,
+		CashboxPostedAt:        db.CashboxPostedAt,
+	}
+	if db.ReversesTransactionID != nil {
+		s := db.ReversesTransactionID.String()
+		out.ReversesTransactionID = &s
+	}
+	return out
+}
+
+// FromDomain convierte paymentdomain.PaymentTransaction a DBPaymentTransaction
+func (db *DBPaymentTransaction) FromDomain(tx paymentdomain.PaymentTransaction) error {
+	id, err := uuid.Parse(tx.ID)
+	if err != nil && tx.ID != "" {
+		return err
+	}
+	if tx.ID == "" {
+		id = uuid.New()
+	}
+
+	parcelID, err := uuid.Parse(tx.ParcelID)
+	if err != nil {
+		return err
+	}
+	paymentID, err := uuid.Parse(tx.PaymentID)
+	if err != nil {
+		return err
+	}
+	var reverses *uuid.UUID
+	if tx.ReversesTransactionID != nil {
+		r, err := uuid.Parse(*tx.ReversesTransactionID)
+		if err != nil {
+			return err
+		}
+		reverses = &r
+	}
+
+	*db = DBPaymentTransaction{
+		ID:              id,
+		TenantID:        tx.TenantID,
+		ParcelID:        parcelID,
+		PaymentID:       paymentID,
+		Kind:            string(tx.Kind),
+		PaymentType:     string(tx.PaymentType),
+		Channel:         string(tx.Channel),
+		Amount:          tx.Amount,
+		Currency:        string(tx.Currency),
+		OfficeID:        tx.OfficeID,
+		CashboxID:       tx.CashboxID,
+		SellerUserID:    tx.SellerUserID,
+		CreatedByUserID: tx.CreatedByUserID,
+		Notes:           tx.Notes,
+		CreatedAt:       tx.CreatedAt,
+
+		Reason:                tx.Reason,
+		ApprovedByUserID:      tx.ApprovedByUserID,
+		ReversesTransactionID: reverses,
+
+		CashboxMovementID:      tx.CashboxMovementID,
+		CashboxPostingStatus:   string(tx.CashboxPostingStatus),
+		CashboxPostingAttempts: tx.CashboxPostingAttempts,
+		CashboxPostingError:    tx.CashboxPostingError,
+		CashboxNextAttemptAt:   tx.CashboxNextAttemptAt,
+		CashboxPostedAt:        tx.CashboxPostedAt,
+	}
+	return nil
+}
+
+// BeforeCreate hook de GORM
+func (db *DBPaymentTransaction) BeforeCreate(tx *gorm.DB) error {
+	if db.ID == uuid.Nil {
+		db.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"ms-parcel-core/internal/parcel/parcel_pricing/domain"
+	"ms-parcel-core/internal/parcel/parcel_pricing/port"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type PriceRulePostgresRepository struct {
+	db *gorm.DB
+}
+
+var _ port.PriceRuleRepository = (*PriceRulePostgresRepository)(nil)
+
+func NewPriceRulePostgresRepository(db *gorm.DB) *PriceRulePostgresRepository {
+	return &PriceRulePostgresRepository{db: db}
+}
+
+func (r *PriceRulePostgresRepository) Create(ctx context.Context, tenantID string, rule domain.PriceRule) (*domain.PriceRule, error) {
+	ctx, span := tracing.Start(ctx, "PriceRuleRepository.Create", tracing.TenantID(tenantID))
+	defer span.End()
+
+	now := time.Now().UTC()
+	rule.ID = ""
+	rule.TenantID = tenantID
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	var m DBPriceRule
+	if err := m.FromDomain(rule); err != nil {
+		return nil, err
+	}
+	if err := withTenant(ctx, r.db, tenantID).Create(&m).Error; err != nil {
+		return nil, err
+	}
+	out := m.ToDomain()
+	return &out, nil
+}
+
+func (r *PriceRulePostgresRepository) Update(ctx context.Context, tenantID string, id uuid.UUID, rule domain.PriceRule) (*domain.PriceRule, error) {
+	ctx, span := tracing.Start(ctx, "PriceRuleRepository.Update", tracing.TenantID(tenantID))
+	defer span.End()
+
+	res := withTenant(ctx, r.db, tenantID).Model(&DBPriceRule{}).Where("id = ?", id).Updates(map[string]any{
+		"shipment_type":         string(rule.ShipmentType),
+		"origin_office_id":      rule.OriginOfficeID,
+		"destination_office_id": rule.DestinationOfficeID,
+		"unit":                  string(rule.Unit),
+		"price":                 rule.Price,
+		"currency":              rule.Currency,
+		"priority":              rule.Priority,
+		"active":                rule.Active,
+		"updated_at":            time.Now().UTC(),
+	})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	var m DBPriceRule
+	if err := withTenant(ctx, r.db, tenantID).Where("id = ?", id).First(&m).Error; err != nil {
+		return nil, err
+	}
+	out := m.ToDomain()
+	return &out, nil
+}
+
+func (r *PriceRulePostgresRepository) List(ctx context.Context, tenantID string) ([]domain.PriceRule, error) {
+	ctx, span := tracing.Start(ctx, "PriceRuleRepository.List", tracing.TenantID(tenantID))
+	defer span.End()
+
+	var rows []DBPriceRule
+	if err := withTenant(ctx, r.db, tenantID).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return priceRulesToDomain(rows), nil
+}
+
+// FindMatch trae las candidatas activas (exactas o comodín) y elige con las mismas reglas de
+// especificidad y prioridad que el repositorio en memoria (domain.RuleScore / domain.Outranks).
+func (r *PriceRulePostgresRepository) FindMatch(ctx context.Context, tenantID string, shipmentType, originOfficeID, destinationOfficeID string) (*domain.PriceRule, error) {
+	ctx, span := tracing.Start(ctx, "PriceRuleRepository.FindMatch", tracing.TenantID(tenantID))
+	defer span.End()
+
+	var rows []DBPriceRule
+	err := withTenant(ctx, r.db, tenantID).
+		Where("active = ? AND shipment_type = ?", true, shipmentType).
+		Where("origin_office_id IN ?", []string{originOfficeID, domain.WildcardOffice}).
+		Where("destination_office_id IN ?", []string{destinationOfficeID, domain.WildcardOffice}).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	candidates := priceRulesToDomain(rows)
+	best := candidates[0]
+	bestScore := domain.RuleScore(best, originOfficeID, destinationOfficeID)
+	for i := 1; i < len(candidates); i++ {
+		score := domain.RuleScore(candidates[i], originOfficeID, destinationOfficeID)
+		if domain.Outranks(candidates[i], score, best, bestScore) {
+			best = candidates[i]
+			bestScore = score
+		}
+	}
+	return &best, nil
+}
+
+// FindByRoute busca la regla (activa o no) con la misma clave shipment_type + origen + destino.
+// Si hubiera duplicados, devuelve la de mayor prioridad.
+func (r *PriceRulePostgresRepository) FindByRoute(ctx context.Context, tenantID string, shipmentType, originOfficeID, destinationOfficeID string) (*domain.PriceRule, error) {
+	ctx, span := tracing.Start(ctx, "PriceRuleRepository.FindByRoute", tracing.TenantID(tenantID))
+	defer span.End()
+
+	var m DBPriceRule
+	err := withTenant(ctx, r.db, tenantID).
+		Where("shipment_type = ? AND origin_office_id = ? AND destination_office_id = ?", shipmentType, originOfficeID, destinationOfficeID).
+		Order("priority DESC").
+		First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := m.ToDomain()
+	return &out, nil
+}
+
+func priceRulesToDomain(rows []DBPriceRule) []domain.PriceRule {
+	out := make([]domain.PriceRule, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].ToDomain())
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"ms-parcel-core/internal/parcel/parcel_documents/domain"
+	"ms-parcel-core/internal/parcel/parcel_documents/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type PrintRecordPostgresRepository struct {
+	db *gorm.DB
+}
+
+var _ port.PrintRepository = (*PrintRecordPostgresRepository)(nil)
+
+func NewPrintRecordPostgresRepository(db *gorm.DB) *PrintRecordPostgresRepository {
+	return &PrintRecordPostgresRepository{db: db}
+}
+
+func (r *PrintRecordPostgresRepository) Add(ctx context.Context, tenantID string, rec domain.PrintRecord) (*domain.PrintRecord, error) {
+	ctx, span := tracing.Start(ctx, "PrintRepository.Add", tracing.TenantID(tenantID))
+	defer span.End()
+
+	parcelID, err := uuid.Parse(rec.ParcelID)
+	if err != nil {
+		return nil, apperror.NewBadRequest("validation_error", "parcel_id inválido", map[string]any{"field": "parcel_id"})
+	}
+	rec.ParcelID = parcelID.String()
+
+	var m DBPrintRecord
+	if err := m.FromDomain(rec); err != nil {
+		return nil, err
+	}
+	if err := withTenant(ctx, r.db, tenantID).Create(&m).Error; err != nil {
+		return nil, err
+	}
+	out := m.ToDomain()
+	return &out, nil
+}
+
+func (r *PrintRecordPostgresRepository) CountByParcelAndType(ctx context.Context, tenantID string, parcelID uuid.UUID, docType domain.DocumentType) (int, error) {
+	ctx, span := tracing.Start(ctx, "PrintRepository.CountByParcelAndType", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	var count int64
+	err := withTenant(ctx, r.db, tenantID).Model(&DBPrintRecord{}).
+		Where("parcel_id = ? AND document_type = ?", parcelID.String(), string(docType)).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *PrintRecordPostgresRepository) ListByParcel(ctx context.Context, tenantID string, parcelID uuid.UUID) ([]domain.PrintRecord, error) {
+	ctx, span := tracing.Start(ctx, "PrintRepository.ListByParcel", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	var rows []DBPrintRecord
+	err := withTenant(ctx, r.db, tenantID).
+		Where("parcel_id = ?", parcelID.String()).
+		Order("printed_at ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.PrintRecord, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].ToDomain())
+	}
+	return out, nil
+}
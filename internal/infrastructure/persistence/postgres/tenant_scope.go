@@ -1,6 +1,8 @@
 package postgres
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 )
 
@@ -37,3 +39,10 @@ func tenantScopeDeleteCallback(db *gorm.DB) {
 		db.Where("tenant_id = ?", tenantID)
 	}
 }
+
+// withTenant abre una sesión con el scope de tenant activo. Solo sirve para tablas con columna tenant_id
+// (items y tracking filtran por el parcel dueño). La sesión se puede reutilizar para varias consultas
+// y usa la transacción de la unidad de trabajo si hay una en curso.
+func withTenant(ctx context.Context, db *gorm.DB, tenantID string) *gorm.DB {
+	return conn(ctx, db).Set(TenantIDKey, tenantID).Session(&gorm.Session{})
+}
@@ -19,6 +19,17 @@ type DBTrackingEvent struct {
 	UserID     string    `gorm:"type:varchar(100);not null"`
 	UserName   string    `gorm:"type:varchar(255)"`
 	Metadata   *string   `gorm:"type:jsonb"`
+
+	OfficeID          *string  `gorm:"type:varchar(100);index"`
+	VehicleID         *string  `gorm:"type:varchar(100);index"`
+	Latitude          *float64 `gorm:"type:double precision"`
+	Longitude         *float64 `gorm:"type:double precision"`
+	LocationAccuracyM *float64 `gorm:"type:double precision;column:location_accuracy_m"`
+	Source            string   `gorm:"type:varchar(20);not null"`
+	CorrelationID     *string  `gorm:"type:varchar(128)"`
+
+	// Seq lo asigna la base al confirmar la transacción (migración 0007); GORM no lo escribe
+	Seq int64 `gorm:"->;column:seq"`
 }
 
 func (DBTrackingEvent) TableName() string {
@@ -32,15 +43,26 @@ func (db *DBTrackingEvent) ToDomain() trackingdomain.TrackingEvent {
 		_ = json.Unmarshal([]byte(*db.Metadata), &metadata)
 	}
 
+	var location *trackingdomain.GeoPoint
+	if db.Latitude != nil && db.Longitude != nil {
+		location = &trackingdomain.GeoPoint{Latitude: *db.Latitude, Longitude: *db.Longitude, AccuracyM: db.LocationAccuracyM}
+	}
+
 	id, _ := uuid.Parse(db.ID.String())
 	return trackingdomain.TrackingEvent{
-		ID:         id,
-		ParcelID:   db.ParcelID,
-		EventType:  db.EventType,
-		OccurredAt: db.OccurredAt,
-		UserID:     db.UserID,
-		UserName:   db.UserName,
-		Metadata:   metadata,
+		ID:            id,
+		Seq:           db.Seq,
+		ParcelID:      db.ParcelID,
+		EventType:     db.EventType,
+		OccurredAt:    db.OccurredAt,
+		UserID:        db.UserID,
+		UserName:      db.UserName,
+		Metadata:      metadata,
+		OfficeID:      db.OfficeID,
+		VehicleID:     db.VehicleID,
+		Location:      location,
+		Source:        trackingdomain.TrackingSource(db.Source),
+		CorrelationID: db.CorrelationID,
 	}
 }
 
@@ -56,13 +78,22 @@ func (db *DBTrackingEvent) FromDomain(evt trackingdomain.TrackingEvent) error {
 	}
 
 	*db = DBTrackingEvent{
-		ID:         evt.ID,
-		ParcelID:   evt.ParcelID,
-		EventType:  evt.EventType,
-		OccurredAt: evt.OccurredAt,
-		UserID:     evt.UserID,
-		UserName:   evt.UserName,
-		Metadata:   metadataJSON,
+		ID:            evt.ID,
+		ParcelID:      evt.ParcelID,
+		EventType:     evt.EventType,
+		OccurredAt:    evt.OccurredAt,
+		UserID:        evt.UserID,
+		UserName:      evt.UserName,
+		Metadata:      metadataJSON,
+		OfficeID:      evt.OfficeID,
+		VehicleID:     evt.VehicleID,
+		Source:        string(evt.Source),
+		CorrelationID: evt.CorrelationID,
+	}
+	if evt.Location != nil {
+		db.Latitude = &evt.Location.Latitude
+		db.Longitude = &evt.Location.Longitude
+		db.LocationAccuracyM = evt.Location.AccuracyM
 	}
 	return nil
 }
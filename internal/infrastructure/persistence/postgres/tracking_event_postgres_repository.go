@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"ms-parcel-core/internal/parcel/parcel_tracking/domain"
+	"ms-parcel-core/internal/parcel/parcel_tracking/port"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+// TrackingEventPostgresRepository filtra por tenant a través del parcel: tracking_events no tiene tenant_id.
+type TrackingEventPostgresRepository struct {
+	db *gorm.DB
+}
+
+var _ port.TrackingRepository = (*TrackingEventPostgresRepository)(nil)
+
+func NewTrackingEventPostgresRepository(db *gorm.DB) *TrackingEventPostgresRepository {
+	return &TrackingEventPostgresRepository{db: db}
+}
+
+func (r *TrackingEventPostgresRepository) Append(ctx context.Context, tenantID string, ev domain.TrackingEvent) error {
+	ctx, span := tracing.Start(ctx, "TrackingRepository.Append", tracing.TenantID(tenantID))
+	defer span.End()
+
+	var m DBTrackingEvent
+	if err := m.FromDomain(ev); err != nil {
+		return err
+	}
+	return conn(ctx, r.db).Create(&m).Error
+}
+
+func (r *TrackingEventPostgresRepository) ListByParcelID(ctx context.Context, tenantID string, parcelID string) ([]domain.TrackingEvent, error) {
+	ctx, span := tracing.Start(ctx, "TrackingRepository.ListByParcelID", tracing.TenantID(tenantID), tracing.ParcelID(parcelID))
+	defer span.End()
+
+	var rows []DBTrackingEvent
+	err := conn(ctx, r.db).
+		Where("parcel_id = ?", parcelID).
+		Where("EXISTS (SELECT 1 FROM parcels p WHERE p.id::text = tracking_events.parcel_id AND p.tenant_id = ?)", tenantID).
+		Order("occurred_at ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.TrackingEvent, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].ToDomain())
+	}
+	return out, nil
+}
+
+func (r *TrackingEventPostgresRepository) ListSince(ctx context.Context, tenantID string, afterSeq int64, limit int) ([]domain.TrackingEvent, error) {
+	ctx, span := tracing.Start(ctx, "TrackingRepository.ListSince", tracing.TenantID(tenantID))
+	defer span.End()
+
+	q := conn(ctx, r.db).
+		Where("seq > ?", afterSeq).
+		Where("EXISTS (SELECT 1 FROM parcels p WHERE p.id::text = tracking_events.parcel_id AND p.tenant_id = ?)", tenantID).
+		Order("seq ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	var rows []DBTrackingEvent
+	if err := q.Find(&rows).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	out := make([]domain.TrackingEvent, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].ToDomain())
+	}
+	return out, nil
+}
+
+func (r *TrackingEventPostgresRepository) LatestSeq(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "TrackingRepository.LatestSeq")
+	defer span.End()
+
+	var seq int64
+	if err := conn(ctx, r.db).Model(&DBTrackingEvent{}).Select("COALESCE(MAX(seq), 0)").Scan(&seq).Error; err != nil {
+		tracing.RecordError(span, err)
+		return 0, err
+	}
+	return seq, nil
+}
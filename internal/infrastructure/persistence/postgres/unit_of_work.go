@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type txKey struct{}
+
+// PostgresUnitOfWork abre una transacción de base y la deja en el ctx de fn; los repositorios de
+// este paquete la toman con conn, así que todo lo que se escriba dentro de fn se confirma o revierte junto.
+type PostgresUnitOfWork struct {
+	db *gorm.DB
+}
+
+var _ port.UnitOfWork = (*PostgresUnitOfWork)(nil)
+
+func NewPostgresUnitOfWork(db *gorm.DB) *PostgresUnitOfWork {
+	return &PostgresUnitOfWork{db: db}
+}
+
+func (u *PostgresUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	ctx, span := tracing.Start(ctx, "UnitOfWork.Do")
+	defer span.End()
+
+	ctx, runCommitHooks := port.WithCommitHooks(ctx)
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	runCommitHooks()
+	return nil
+}
+
+// conn devuelve la transacción de la unidad de trabajo en curso o, si no hay, db.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	webhookdomain "ms-parcel-core/internal/parcel/parcel_webhook/domain"
+)
+
+// DBWebhookDelivery representa el modelo de base de datos para WebhookDelivery
+type DBWebhookDelivery struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key"`
+	TenantID       string    `gorm:"type:varchar(100);not null;index"`
+	SubscriptionID uuid.UUID `gorm:"type:uuid;not null"`
+	EventID        uuid.UUID `gorm:"type:uuid;not null"`
+	EventType      string    `gorm:"type:varchar(100);not null"`
+	ParcelID       string    `gorm:"type:varchar(100);not null"`
+	Payload        string    `gorm:"type:text;not null"`
+	Status         string    `gorm:"type:varchar(20);not null"`
+	Attempts       int       `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time `gorm:"not null"`
+	LastStatusCode *int
+	LastError      *string `gorm:"type:text"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time `gorm:"not null"`
+	UpdatedAt      time.Time `gorm:"not null"`
+}
+
+func (DBWebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// ToDomain convierte DBWebhookDelivery a webhookdomain.WebhookDelivery
+func (db *DBWebhookDelivery) ToDomain() webhookdomain.WebhookDelivery {
+	return webhookdomain.WebhookDelivery{
+		ID:             db.ID.String(),
+		TenantID:       db.TenantID,
+		SubscriptionID: db.SubscriptionID.String(),
+		EventID:        db.EventID.String(),
+		EventType:      db.EventType,
+		ParcelID:       db.ParcelID,
+		Payload:        db.Payload,
+		Status:         webhookdomain.DeliveryStatus(db.Status),
+		Attempts:       db.Attempts,
+		NextAttemptAt:  db.NextAttemptAt,
+		LastStatusCode: db.LastStatusCode,
+		LastError:      db.LastError,
+		DeliveredAt:    db.DeliveredAt,
+		CreatedAt:      db.CreatedAt,
+		UpdatedAt:      db.UpdatedAt,
+	}
+}
+
+// FromDomain convierte webhookdomain.WebhookDelivery a DBWebhookDelivery
+func (db *DBWebhookDelivery) FromDomain(d webhookdomain.WebhookDelivery) error {
+	id, err := uuid.Parse(d.ID)
+	if err != nil {
+		return err
+	}
+	subID, err := uuid.Parse(d.SubscriptionID)
+	if err != nil {
+		return err
+	}
+	eventID, err := uuid.Parse(d.EventID)
+	if err != nil {
+		return err
+	}
+
+	*db = DBWebhookDelivery{
+		ID:             id,
+		TenantID:       d.TenantID,
+		SubscriptionID: subID,
+		EventID:        eventID,
+		EventType:      d.EventType,
+		ParcelID:       d.ParcelID,
+		Payload:        d.Payload,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	"ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type WebhookDeliveryPostgresRepository struct {
+	db *gorm.DB
+}
+
+var _ port.WebhookDeliveryRepository = (*WebhookDeliveryPostgresRepository)(nil)
+
+func NewWebhookDeliveryPostgresRepository(db *gorm.DB) *WebhookDeliveryPostgresRepository {
+	return &WebhookDeliveryPostgresRepository{db: db}
+}
+
+func (r *WebhookDeliveryPostgresRepository) Add(ctx context.Context, d domain.WebhookDelivery) error {
+	ctx, span := tracing.Start(ctx, "WebhookDeliveryRepository.Add", tracing.TenantID(d.TenantID))
+	defer span.End()
+
+	var m DBWebhookDelivery
+	if err := m.FromDomain(d); err != nil {
+		return apperror.NewInternal("internal_error", "entrega de webhook inválida", map[string]any{"event_type": d.EventType})
+	}
+	return withTenant(ctx, r.db, d.TenantID).Create(&m).Error
+}
+
+func (r *WebhookDeliveryPostgresRepository) GetByID(ctx context.Context, tenantID string, subscriptionID uuid.UUID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookDeliveryRepository.GetByID", tracing.TenantID(tenantID))
+	defer span.End()
+
+	var m DBWebhookDelivery
+	err := withTenant(ctx, r.db, tenantID).Where("id = ? AND subscription_id = ?", id, subscriptionID).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := m.ToDomain()
+	return &out, nil
+}
+
+func (r *WebhookDeliveryPostgresRepository) ListBySubscription(ctx context.Context, tenantID string, subscriptionID uuid.UUID, f port.ListWebhookDeliveryFilters) ([]domain.WebhookDelivery, int, error) {
+	ctx, span := tracing.Start(ctx, "WebhookDeliveryRepository.ListBySubscription", tracing.TenantID(tenantID))
+	defer span.End()
+
+	q := withTenant(ctx, r.db, tenantID).Model(&DBWebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if f.Status != nil {
+		q = q.Where("status = ?", string(*f.Status))
+	}
+	if f.ParcelID != nil {
+		q = q.Where("parcel_id = ?", *f.ParcelID)
+	}
+	q = q.Session(&gorm.Session{})
+
+	var count int64
+	if err := q.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	offset := f.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	var rows []DBWebhookDelivery
+	if err := q.Order("created_at DESC").Limit(limit).Offset(offset).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	out := make([]domain.WebhookDelivery, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].ToDomain())
+	}
+	return out, int(count), nil
+}
+
+// ClaimDue reclama el lote igual que el outbox: UPDATE sobre un SELECT ... FOR UPDATE SKIP LOCKED.
+func (r *WebhookDeliveryPostgresRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookDeliveryRepository.ClaimDue")
+	defer span.End()
+
+	if limit <= 0 {
+		limit = 50
+	}
+	db := conn(ctx, r.db)
+	due := db.Model(&DBWebhookDelivery{}).Select("id").
+		Where(webhookDeliveryDueSQL, now).
+		Order("created_at ASC").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked})
+
+	var rows []DBWebhookDelivery
+	err := db.Model(&rows).Clauses(clause.Returning{}).
+		Where("id IN (?)", due).
+		Updates(map[string]any{
+			"status":          string(domain.DeliveryStatusDelivering),
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": now.Add(lease),
+			"updated_at":      now,
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// RETURNING no garantiza orden
+	out := make([]domain.WebhookDelivery, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].ToDomain())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (r *WebhookDeliveryPostgresRepository) SaveDelivery(ctx context.Context, d domain.WebhookDelivery) error {
+	ctx, span := tracing.Start(ctx, "WebhookDeliveryRepository.SaveDelivery", tracing.TenantID(d.TenantID))
+	defer span.End()
+
+	id, err := uuid.Parse(d.ID)
+	if err != nil {
+		return apperror.New("not_found", "entrega no encontrada", map[string]any{"delivery_id": d.ID}, 404)
+	}
+
+	// Solo se actualizan los campos de entrega; el evento y su payload son inmutables. La condición
+	// sobre estado e intentos descarta el resultado si el lease venció y otro dispatcher la retomó.
+	res := withTenant(ctx, r.db, d.TenantID).Model(&DBWebhookDelivery{}).
+		Where("id = ? AND status = ? AND attempts = ?", id, string(domain.DeliveryStatusDelivering), d.Attempts).
+		Updates(map[string]any{
+			"status":           string(d.Status),
+			"attempts":         d.Attempts,
+			"next_attempt_at":  d.NextAttemptAt,
+			"last_status_code": d.LastStatusCode,
+			"last_error":       d.LastError,
+			"delivered_at":     d.DeliveredAt,
+			"updated_at":       time.Now().UTC(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return port.ErrDeliveryChanged
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryPostgresRepository) Requeue(ctx context.Context, tenantID string, id uuid.UUID, now time.Time) error {
+	ctx, span := tracing.Start(ctx, "WebhookDeliveryRepository.Requeue", tracing.TenantID(tenantID))
+	defer span.End()
+
+	res := withTenant(ctx, r.db, tenantID).Model(&DBWebhookDelivery{}).
+		Where("id = ? AND status = ?", id, string(domain.DeliveryStatusDead)).
+		Updates(map[string]any{
+			"status":          string(domain.DeliveryStatusPending),
+			"attempts":        0,
+			"next_attempt_at": now,
+			"updated_at":      now,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return port.ErrDeliveryChanged
+	}
+	return nil
+}
+
+// webhookDeliveryDueSQL es el equivalente SQL de webhookDeliveryDue del repositorio en memoria.
+var webhookDeliveryDueSQL = "status IN ('" + string(domain.DeliveryStatusPending) + "', '" + string(domain.DeliveryStatusDelivering) + "')" +
+	" AND next_attempt_at <= ?"
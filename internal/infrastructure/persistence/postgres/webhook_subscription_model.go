@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	webhookdomain "ms-parcel-core/internal/parcel/parcel_webhook/domain"
+)
+
+// DBWebhookSubscription representa el modelo de base de datos para WebhookSubscription
+type DBWebhookSubscription struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key"`
+	TenantID        string    `gorm:"type:varchar(100);not null;index"`
+	URL             string    `gorm:"type:varchar(500);not null"`
+	Secret          string    `gorm:"type:varchar(100);not null"`
+	EventTypes      string    `gorm:"type:jsonb;not null"`
+	Active          bool      `gorm:"not null;default:true"`
+	Description     *string   `gorm:"type:varchar(200)"`
+	CreatedByUserID *string   `gorm:"type:varchar(100)"`
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
+
+func (DBWebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// ToDomain convierte DBWebhookSubscription a webhookdomain.WebhookSubscription
+func (db *DBWebhookSubscription) ToDomain() webhookdomain.WebhookSubscription {
+	var types []string
+	if db.EventTypes != "" {
+		_ = json.Unmarshal([]byte(db.EventTypes), &types)
+	}
+	return webhookdomain.WebhookSubscription{
+		ID:              db.ID.String(),
+		TenantID:        db.TenantID,
+		URL:             db.URL,
+		Secret:          db.Secret,
+		EventTypes:      types,
+		Active:          db.Active,
+		Description:     db.Description,
+		CreatedByUserID: db.CreatedByUserID,
+		CreatedAt:       db.CreatedAt,
+		UpdatedAt:       db.UpdatedAt,
+	}
+}
+
+// FromDomain convierte webhookdomain.WebhookSubscription a DBWebhookSubscription
+func (db *DBWebhookSubscription) FromDomain(s webhookdomain.WebhookSubscription) error {
+	id, err := uuid.Parse(s.ID)
+	if err != nil {
+		return err
+	}
+	types, err := marshalEventTypes(s.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	*db = DBWebhookSubscription{
+		ID:              id,
+		TenantID:        s.TenantID,
+		URL:             s.URL,
+		Secret:          s.Secret,
+		EventTypes:      types,
+		Active:          s.Active,
+		Description:     s.Description,
+		CreatedByUserID: s.CreatedByUserID,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+	return nil
+}
+
+func marshalEventTypes(types []string) (string, error) {
+	if types == nil {
+		types = []string{}
+	}
+	data, err := json.Marshal(types)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	"ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type WebhookSubscriptionPostgresRepository struct {
+	db *gorm.DB
+}
+
+var _ port.WebhookSubscriptionRepository = (*WebhookSubscriptionPostgresRepository)(nil)
+
+func NewWebhookSubscriptionPostgresRepository(db *gorm.DB) *WebhookSubscriptionPostgresRepository {
+	return &WebhookSubscriptionPostgresRepository{db: db}
+}
+
+func (r *WebhookSubscriptionPostgresRepository) Create(ctx context.Context, tenantID string, s domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookSubscriptionRepository.Create", tracing.TenantID(tenantID))
+	defer span.End()
+
+	now := time.Now().UTC()
+	s.ID = uuid.NewString()
+	s.TenantID = tenantID
+	s.CreatedAt = now
+	s.UpdatedAt = now
+
+	var m DBWebhookSubscription
+	if err := m.FromDomain(s); err != nil {
+		return nil, err
+	}
+	if err := withTenant(ctx, r.db, tenantID).Create(&m).Error; err != nil {
+		return nil, err
+	}
+	out := m.ToDomain()
+	return &out, nil
+}
+
+func (r *WebhookSubscriptionPostgresRepository) Update(ctx context.Context, tenantID string, s domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookSubscriptionRepository.Update", tracing.TenantID(tenantID))
+	defer span.End()
+
+	id, err := uuid.Parse(s.ID)
+	if err != nil {
+		return nil, nil
+	}
+	types, err := marshalEventTypes(s.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := withTenant(ctx, r.db, tenantID).Model(&DBWebhookSubscription{}).Where("id = ?", id).Updates(map[string]any{
+		"url":         s.URL,
+		"secret":      s.Secret,
+		"event_types": types,
+		"active":      s.Active,
+		"description": s.Description,
+		"updated_at":  time.Now().UTC(),
+	})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	return r.GetByID(ctx, tenantID, id)
+}
+
+func (r *WebhookSubscriptionPostgresRepository) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookSubscriptionRepository.GetByID", tracing.TenantID(tenantID))
+	defer span.End()
+
+	var m DBWebhookSubscription
+	err := withTenant(ctx, r.db, tenantID).Where("id = ?", id).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := m.ToDomain()
+	return &out, nil
+}
+
+func (r *WebhookSubscriptionPostgresRepository) List(ctx context.Context, tenantID string) ([]domain.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookSubscriptionRepository.List", tracing.TenantID(tenantID))
+	defer span.End()
+
+	return r.find(withTenant(ctx, r.db, tenantID))
+}
+
+func (r *WebhookSubscriptionPostgresRepository) ListActive(ctx context.Context, tenantID string) ([]domain.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookSubscriptionRepository.ListActive", tracing.TenantID(tenantID))
+	defer span.End()
+
+	return r.find(withTenant(ctx, r.db, tenantID).Where("active = ?", true))
+}
+
+func (r *WebhookSubscriptionPostgresRepository) Delete(ctx context.Context, tenantID string, id uuid.UUID) (bool, error) {
+	ctx, span := tracing.Start(ctx, "WebhookSubscriptionRepository.Delete", tracing.TenantID(tenantID))
+	defer span.End()
+
+	res := withTenant(ctx, r.db, tenantID).Where("id = ?", id).Delete(&DBWebhookSubscription{})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *WebhookSubscriptionPostgresRepository) find(q *gorm.DB) ([]domain.WebhookSubscription, error) {
+	var rows []DBWebhookSubscription
+	if err := q.Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.WebhookSubscription, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].ToDomain())
+	}
+	return out, nil
+}
@@ -0,0 +1,73 @@
+// Package persistence elige la implementación de los repositorios (en memoria o PostgreSQL)
+// para los puntos de entrada: el servidor HTTP y parcelctl.
+package persistence
+
+import (
+	"gorm.io/gorm"
+
+	"ms-parcel-core/internal/infrastructure/persistence/memory"
+	"ms-parcel-core/internal/infrastructure/persistence/postgres"
+	parcelrepo "ms-parcel-core/internal/parcel/parcel_core/infrastructure/repository"
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	docrepo "ms-parcel-core/internal/parcel/parcel_documents/infrastructure/repository"
+	docport "ms-parcel-core/internal/parcel/parcel_documents/port"
+	itemrepo "ms-parcel-core/internal/parcel/parcel_item/infrastructure/repository"
+	itemport "ms-parcel-core/internal/parcel/parcel_item/port"
+	outboxrepo "ms-parcel-core/internal/parcel/parcel_outbox/infrastructure/repository"
+	outboxport "ms-parcel-core/internal/parcel/parcel_outbox/port"
+	paymentrepo "ms-parcel-core/internal/parcel/parcel_payment/infrastructure/repository"
+	paymentport "ms-parcel-core/internal/parcel/parcel_payment/port"
+	pricingrepo "ms-parcel-core/internal/parcel/parcel_pricing/infrastructure/repository"
+	pricingport "ms-parcel-core/internal/parcel/parcel_pricing/port"
+	trackingrepo "ms-parcel-core/internal/parcel/parcel_tracking/infrastructure/repository"
+	trackingport "ms-parcel-core/internal/parcel/parcel_tracking/port"
+	webhookrepo "ms-parcel-core/internal/parcel/parcel_webhook/infrastructure/repository"
+	webhookport "ms-parcel-core/internal/parcel/parcel_webhook/port"
+)
+
+type Repositories struct {
+	Parcels    coreport.ParcelRepository
+	Tracking   trackingport.TrackingRepository
+	Items      itemport.ParcelItemRepository
+	Payments   paymentport.ParcelPaymentRepository
+	PriceRules pricingport.PriceRuleRepository
+	Prints     docport.PrintRepository
+	// Outbox guarda los eventos de integración; se escribe en la unidad de trabajo del cambio de estado
+	Outbox outboxport.OutboxRepository
+	// WebhookSubscriptions y WebhookDeliveries: las entregas se encolan junto con el evento de tracking
+	WebhookSubscriptions webhookport.WebhookSubscriptionRepository
+	WebhookDeliveries    webhookport.WebhookDeliveryRepository
+	// UnitOfWork agrupa escrituras de varios repositorios en una transacción
+	UnitOfWork coreport.UnitOfWork
+}
+
+// NewInMemoryRepositories se usa cuando no hay base configurada (desarrollo); los datos se pierden al reiniciar.
+func NewInMemoryRepositories() Repositories {
+	return Repositories{
+		Parcels:              parcelrepo.NewInMemoryParcelRepository(),
+		Tracking:             trackingrepo.NewInMemoryTrackingRepository(),
+		Items:                itemrepo.NewInMemoryParcelItemRepository(),
+		Payments:             paymentrepo.NewInMemoryParcelPaymentRepository(),
+		PriceRules:           pricingrepo.NewInMemoryPriceRuleRepository(),
+		Prints:               docrepo.NewInMemoryPrintRepository(),
+		Outbox:               outboxrepo.NewInMemoryOutboxRepository(),
+		WebhookSubscriptions: webhookrepo.NewInMemoryWebhookSubscriptionRepository(),
+		WebhookDeliveries:    webhookrepo.NewInMemoryWebhookDeliveryRepository(),
+		UnitOfWork:           memory.NewInMemoryUnitOfWork(),
+	}
+}
+
+func NewPostgresRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Parcels:              postgres.NewParcelPostgresRepository(db),
+		Tracking:             postgres.NewTrackingEventPostgresRepository(db),
+		Items:                postgres.NewParcelItemPostgresRepository(db),
+		Payments:             postgres.NewParcelPaymentPostgresRepository(db),
+		PriceRules:           postgres.NewPriceRulePostgresRepository(db),
+		Prints:               postgres.NewPrintRecordPostgresRepository(db),
+		Outbox:               postgres.NewOutboxEventPostgresRepository(db),
+		WebhookSubscriptions: postgres.NewWebhookSubscriptionPostgresRepository(db),
+		WebhookDeliveries:    postgres.NewWebhookDeliveryPostgresRepository(db),
+		UnitOfWork:           postgres.NewPostgresUnitOfWork(db),
+	}
+}
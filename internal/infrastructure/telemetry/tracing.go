@@ -0,0 +1,96 @@
+// Package telemetry configura el exporter de trazas OpenTelemetry del proceso.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+type TracingConfig struct {
+	// Exporter: none (default, no-op), stdout u otlp (OTLP/HTTP; endpoint y headers se toman
+	// de las variables estándar OTEL_EXPORTER_OTLP_*).
+	Exporter string
+	// ServiceName: service.name de los spans.
+	ServiceName string
+}
+
+// TracingConfigFromEnv lee OTEL_TRACES_EXPORTER y OTEL_SERVICE_NAME.
+// El muestreo se configura con las variables estándar OTEL_TRACES_SAMPLER / OTEL_TRACES_SAMPLER_ARG.
+func TracingConfigFromEnv() TracingConfig {
+	cfg := TracingConfig{
+		Exporter:    strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER"))),
+		ServiceName: strings.TrimSpace(os.Getenv("OTEL_SERVICE_NAME")),
+	}
+	if cfg.Exporter == "" {
+		cfg.Exporter = ExporterNone
+	}
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = tracing.InstrumentationName
+	}
+	return cfg
+}
+
+// SetupTracing registra el propagador W3C (traceparent/tracestate y baggage) y, si hay exporter,
+// el TracerProvider global. Devuelve la función que vacía y cierra el exporter al apagar el proceso.
+// Con exporter none los spans son no-op pero el contexto entrante se sigue propagando a los clientes.
+func SetupTracing(ctx context.Context, cfg TracingConfig, logger *slog.Logger) (func(context.Context) error, error) {
+	logger = logging.OrDiscard(logger)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exp, err := stdouttrace.New()
+		if err != nil {
+			return nil, fmt.Errorf("tracing: exporter stdout: %w", err)
+		}
+		exporter = exp
+	case ExporterOTLP:
+		exp, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("tracing: exporter otlp: %w", err)
+		}
+		exporter = exp
+	default:
+		return nil, fmt.Errorf("tracing: OTEL_TRACES_EXPORTER inválido %q (none, stdout, otlp)", cfg.Exporter)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("tracing: resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Warn("error de OpenTelemetry", "error", err)
+	}))
+	logger.Info("tracing habilitado", "exporter", cfg.Exporter, "service_name", cfg.ServiceName)
+
+	return tp.Shutdown, nil
+}
@@ -0,0 +1,242 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type CashboxHTTPClientConfig struct {
+	BaseURL          string
+	Timeout          time.Duration
+	MaxRetries       int
+	RetryBackoff     time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// CashboxHTTPClientConfigFromEnv lee la configuración de ms-cashbox.
+// Sin CASHBOX_BASE_URL el composition root usa el stub.
+func CashboxHTTPClientConfigFromEnv() CashboxHTTPClientConfig {
+	return CashboxHTTPClientConfig{
+		BaseURL:          strings.TrimRight(strings.TrimSpace(os.Getenv("CASHBOX_BASE_URL")), "/"),
+		Timeout:          envDurationMs("CASHBOX_TIMEOUT_MS", 2*time.Second),
+		MaxRetries:       envInt("CASHBOX_MAX_RETRIES", 2),
+		RetryBackoff:     envDurationMs("CASHBOX_RETRY_BACKOFF_MS", 100*time.Millisecond),
+		BreakerThreshold: envInt("CASHBOX_BREAKER_THRESHOLD", 5),
+		BreakerCooldown:  envDurationMs("CASHBOX_BREAKER_COOLDOWN_MS", 30*time.Second),
+	}
+}
+
+// CashboxHTTPClient consulta ms-cashbox por HTTP.
+// Los errores (timeout, 5xx, circuito abierto) se devuelven tal cual: la política
+// fail open / fail closed la decide el caso de uso según las opciones del tenant.
+type CashboxHTTPClient struct {
+	baseURL string
+	http    *http.Client
+	retry   httpRetryPolicy
+	breaker *circuitBreaker
+}
+
+var _ port.CashboxClient = (*CashboxHTTPClient)(nil)
+
+// NewCashboxHTTPClient crea el cliente. httpClient es opcional (p.ej. el de un httptest.Server);
+// si es nil se crea uno con cfg.Timeout por intento y transporte instrumentado con OpenTelemetry.
+func NewCashboxHTTPClient(cfg CashboxHTTPClientConfig, httpClient *http.Client) *CashboxHTTPClient {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 2 * time.Second
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: cfg.Timeout, Transport: otelhttp.NewTransport(http.DefaultTransport)}
+	}
+	return &CashboxHTTPClient{
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		http:    httpClient,
+		retry:   httpRetryPolicy{MaxRetries: cfg.MaxRetries, BaseBackoff: cfg.RetryBackoff, MaxBackoff: 2 * time.Second},
+		breaker: newCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+	}
+}
+
+// Ping verifica que ms-cashbox responda en /health; lo usa el chequeo de readiness.
+func (c *CashboxHTTPClient) Ping(ctx context.Context) error {
+	if err := pingService(ctx, c.http, c.baseURL+"/health"); err != nil {
+		return fmt.Errorf("cashbox: %w", err)
+	}
+	return nil
+}
+
+type cashboxStatusResponse struct {
+	Data struct {
+		IsOpen bool `json:"is_open"`
+	} `json:"data"`
+}
+
+func (c *CashboxHTTPClient) IsOpen(ctx context.Context, tenantID string, cashboxID string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "CashboxClient.IsOpen", tracing.TenantID(tenantID))
+	defer span.End()
+	open, err := c.isOpen(ctx, tenantID, cashboxID)
+	tracing.RecordError(span, err)
+	return open, err
+}
+
+func (c *CashboxHTTPClient) isOpen(ctx context.Context, tenantID string, cashboxID string) (bool, error) {
+	if !c.breaker.allow() {
+		return false, fmt.Errorf("cashbox: %w", ErrCircuitOpen)
+	}
+
+	endpoint := c.baseURL + "/api/v1/cashboxes/" + url.PathEscape(cashboxID) + "/status"
+	resp, err := doWithRetry(ctx, c.http, c.retry, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		setServiceHeaders(ctx, req, tenantID)
+		return req, nil
+	})
+	if err != nil {
+		c.breaker.failed(ctx)
+		return false, fmt.Errorf("cashbox: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		// caja inexistente para el tenant: equivale a no abierta
+		c.breaker.success()
+		return false, nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		c.breaker.failure()
+		return false, fmt.Errorf("cashbox: status %d", resp.StatusCode)
+	case resp.StatusCode >= 400:
+		// el servicio responde; el problema es del request (auth, etc.)
+		c.breaker.success()
+		return false, fmt.Errorf("cashbox: status %d", resp.StatusCode)
+	}
+
+	var out cashboxStatusResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&out); err != nil {
+		c.breaker.failed(ctx)
+		return false, fmt.Errorf("cashbox: respuesta inválida: %w", err)
+	}
+	c.breaker.success()
+	return out.Data.IsOpen, nil
+}
+
+type cashboxMovementRequest struct {
+	Type            string  `json:"type"`
+	OfficeID        string  `json:"office_id,omitempty"`
+	ParcelID        string  `json:"parcel_id"`
+	Amount          float64 `json:"amount"`
+	Currency        string  `json:"currency"`
+	PaymentType     string  `json:"payment_type"`
+	SellerUserID    string  `json:"seller_user_id,omitempty"`
+	CreatedByUserID string  `json:"created_by_user_id,omitempty"`
+	Reference       string  `json:"reference"`
+	Notes           string  `json:"notes,omitempty"`
+}
+
+type cashboxMovementResponse struct {
+	Data struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// RegisterMovement registra un ingreso/egreso. Se envía Idempotency-Key para que los reintentos
+// (propios o del job de reintentos) no dupliquen el movimiento; un 409 con ID se toma como ya registrado.
+func (c *CashboxHTTPClient) RegisterMovement(ctx context.Context, tenantID string, in port.CashboxMovementDTO) (string, error) {
+	ctx, span := tracing.Start(ctx, "CashboxClient.RegisterMovement", tracing.TenantID(tenantID), tracing.ParcelID(in.ParcelID))
+	defer span.End()
+	id, err := c.registerMovement(ctx, tenantID, in)
+	tracing.RecordError(span, err)
+	return id, err
+}
+
+func (c *CashboxHTTPClient) registerMovement(ctx context.Context, tenantID string, in port.CashboxMovementDTO) (string, error) {
+	if !c.breaker.allow() {
+		return "", fmt.Errorf("cashbox: %w", ErrCircuitOpen)
+	}
+
+	body, err := json.Marshal(cashboxMovementRequest{
+		Type:            string(in.Type),
+		OfficeID:        in.OfficeID,
+		ParcelID:        in.ParcelID,
+		Amount:          in.Amount,
+		Currency:        in.Currency,
+		PaymentType:     in.PaymentType,
+		SellerUserID:    in.SellerUserID,
+		CreatedByUserID: in.CreatedByUserID,
+		Reference:       in.IdempotencyKey,
+		Notes:           in.Notes,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := c.baseURL + "/api/v1/cashboxes/" + url.PathEscape(in.CashboxID) + "/movements"
+	resp, err := doWithRetry(ctx, c.http, c.retry, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		setServiceHeaders(ctx, req, tenantID)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", in.IdempotencyKey)
+		return req, nil
+	})
+	if err != nil {
+		c.breaker.failed(ctx)
+		return "", fmt.Errorf("cashbox: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		c.breaker.failure()
+		return "", fmt.Errorf("cashbox: status %d", resp.StatusCode)
+	}
+	c.breaker.success()
+
+	var out cashboxMovementResponse
+	decodeErr := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&out)
+	if resp.StatusCode == http.StatusConflict && decodeErr == nil && out.Data.ID != "" {
+		return out.Data.ID, nil
+	}
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("cashbox: status %d", resp.StatusCode)
+	}
+	if decodeErr != nil || out.Data.ID == "" {
+		return "", fmt.Errorf("cashbox: respuesta sin id de movimiento")
+	}
+	return out.Data.ID, nil
+}
+
+func envInt(key string, def int) int {
+	v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func envDurationMs(key string, def time.Duration) time.Duration {
+	v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || v <= 0 {
+		return def
+	}
+	return time.Duration(v) * time.Millisecond
+}
@@ -2,14 +2,20 @@ package clients
 
 import (
 	"context"
+	"sync"
+
+	"github.com/google/uuid"
 
 	"ms-parcel-core/internal/parcel/parcel_core/port"
 )
 
-type CashboxStubClient struct{}
+type CashboxStubClient struct {
+	mu        sync.Mutex
+	movements map[string]string // idempotency key -> movement id
+}
 
 func NewCashboxStubClient() *CashboxStubClient {
-	return &CashboxStubClient{}
+	return &CashboxStubClient{movements: map[string]string{}}
 }
 
 var _ port.CashboxClient = (*CashboxStubClient)(nil)
@@ -18,6 +24,23 @@ func (c *CashboxStubClient) IsOpen(ctx context.Context, tenantID string, cashbox
 	_ = ctx
 	_ = tenantID
 	_ = cashboxID
-	// TODO: llamar ms-cashbox por HTTP
 	return true, nil
 }
+
+func (c *CashboxStubClient) RegisterMovement(ctx context.Context, tenantID string, in port.CashboxMovementDTO) (string, error) {
+	_ = ctx
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.movements == nil {
+		c.movements = map[string]string{}
+	}
+	key := tenantID + "|" + in.IdempotencyKey
+	if id, ok := c.movements[key]; ok {
+		return id, nil
+	}
+	id := uuid.NewString()
+	c.movements[key] = id
+	return id, nil
+}
@@ -0,0 +1,82 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen indica que el circuito está abierto y no se intentó la llamada.
+var ErrCircuitOpen = errors.New("circuit breaker abierto")
+
+// circuitBreaker corta las llamadas a un servicio tras N fallas consecutivas.
+// Pasado el cooldown deja pasar una sola llamada de prueba (half-open):
+// si funciona se cierra, si falla vuelve a abrirse.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		threshold = 5
+	}
+	if cooldown <= 0 {
+		cooldown = 30 * time.Second
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if time.Now().Before(b.openUntil) || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	b.failures = 0
+	b.probing = false
+	b.mu.Unlock()
+}
+
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	b.failures++
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+	b.mu.Unlock()
+}
+
+// release libera la llamada de prueba sin contarla como éxito ni como falla.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	b.probing = false
+	b.mu.Unlock()
+}
+
+// failed cuenta una falla de la llamada, salvo que la haya cortado el propio llamador (cancelación o
+// deadline de ctx): eso no dice nada de la salud del servicio y solo libera la llamada de prueba.
+func (b *circuitBreaker) failed(ctx context.Context) {
+	if ctx.Err() != nil {
+		b.release()
+		return
+	}
+	b.failure()
+}
@@ -0,0 +1,113 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"ms-parcel-core/internal/pkg/util/requestctx"
+)
+
+type httpRetryPolicy struct {
+	MaxRetries  int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// doWithRetry ejecuta el request reintentando errores de red y respuestas 429/5xx
+// con backoff exponencial + jitter. newReq se invoca en cada intento (el body no se reutiliza).
+// Si se agotan los reintentos con una respuesta 5xx, devuelve esa respuesta para que el caller decida.
+func doWithRetry(ctx context.Context, hc *http.Client, policy httpRetryPolicy, newReq func(ctx context.Context) (*http.Request, error)) (*http.Response, error) {
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		req, err := newReq(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := hc.Do(req)
+		last := attempt >= policy.MaxRetries
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = err
+			if last {
+				return nil, lastErr
+			}
+		case isRetryableStatus(resp.StatusCode) && !last:
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			_ = resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retryBackoff(policy, attempt)):
+		}
+	}
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func retryBackoff(policy httpRetryPolicy, attempt int) time.Duration {
+	base := policy.BaseBackoff
+	if base <= 0 {
+		base = 100 * time.Millisecond
+	}
+	d := base << attempt
+	if policy.MaxBackoff > 0 && d > policy.MaxBackoff {
+		d = policy.MaxBackoff
+	}
+	// jitter +-20% para no sincronizar reintentos de varias instancias
+	jitter := time.Duration(rand.Int64N(int64(d)/5+1)) * 2
+	return d - d/5 + jitter
+}
+
+// setServiceHeaders agrega tenant, el token del caller (si hay) y el contexto de traza W3C
+// (traceparent) a un request saliente.
+func setServiceHeaders(ctx context.Context, req *http.Request, tenantID string) {
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Tenant-ID", tenantID)
+	if token := requestctx.AuthToken(ctx); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
+
+// pingService hace un GET a endpoint en un solo intento (sin reintentos ni circuit breaker) para los
+// chequeos de readiness. Cualquier respuesta menor a 500 cuenta como servicio alcanzable.
+func pingService(ctx context.Context, hc *http.Client, endpoint string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package clients
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+)
+
+// CachedOfficeDirectoryConfigFromEnv: los datos de oficinas cambian poco, el TTL es más largo que el
+// de opciones de tenant.
+func CachedOfficeDirectoryConfigFromEnv() CachedTenantOptionsConfig {
+	return CachedTenantOptionsConfig{
+		TTL:        envDurationMs("OFFICE_DIRECTORY_CACHE_TTL_MS", 10*time.Minute),
+		StaleTTL:   envDurationMs("OFFICE_DIRECTORY_CACHE_STALE_MS", time.Hour),
+		MaxEntries: envInt("OFFICE_DIRECTORY_CACHE_MAX_ENTRIES", 5000),
+	}
+}
+
+// CachedOfficeDirectory cachea las oficinas por tenant (incluidas las inexistentes, como nil) para que
+// el seguimiento público no consulte ms-tenant-config en cada request.
+type CachedOfficeDirectory struct {
+	inner port.OfficeDirectory
+	cache *swrCache[*port.OfficeInfo]
+}
+
+var _ port.OfficeDirectory = (*CachedOfficeDirectory)(nil)
+
+func NewCachedOfficeDirectory(inner port.OfficeDirectory, cfg CachedTenantOptionsConfig, logger *slog.Logger) *CachedOfficeDirectory {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 10 * time.Minute
+	}
+	if cfg.StaleTTL < 0 {
+		cfg.StaleTTL = 0
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = 5000
+	}
+	return &CachedOfficeDirectory{inner: inner, cache: newSWRCache[*port.OfficeInfo](cfg, "office_directory", logger)}
+}
+
+func (d *CachedOfficeDirectory) GetOffice(ctx context.Context, tenantID string, officeID string) (*port.OfficeInfo, error) {
+	return d.cache.get(ctx, tenantID+"|"+officeID, func(ctx context.Context) (*port.OfficeInfo, error) {
+		return d.inner.GetOffice(ctx, tenantID, officeID)
+	})
+}
@@ -0,0 +1,131 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type PersonHTTPClientConfig struct {
+	BaseURL          string
+	Timeout          time.Duration
+	MaxRetries       int
+	RetryBackoff     time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// PersonHTTPClientConfigFromEnv lee la configuración de ms-persons.
+// Sin PERSONS_BASE_URL el composition root usa el stub (sin verificación de destinatario).
+func PersonHTTPClientConfigFromEnv() PersonHTTPClientConfig {
+	return PersonHTTPClientConfig{
+		BaseURL:          strings.TrimRight(strings.TrimSpace(os.Getenv("PERSONS_BASE_URL")), "/"),
+		Timeout:          envDurationMs("PERSONS_TIMEOUT_MS", 2*time.Second),
+		MaxRetries:       envInt("PERSONS_MAX_RETRIES", 1),
+		RetryBackoff:     envDurationMs("PERSONS_RETRY_BACKOFF_MS", 100*time.Millisecond),
+		BreakerThreshold: envInt("PERSONS_BREAKER_THRESHOLD", 5),
+		BreakerCooldown:  envDurationMs("PERSONS_BREAKER_COOLDOWN_MS", 30*time.Second),
+	}
+}
+
+// PersonHTTPClient consulta ms-persons por HTTP.
+type PersonHTTPClient struct {
+	baseURL string
+	http    *http.Client
+	retry   httpRetryPolicy
+	breaker *circuitBreaker
+}
+
+var _ port.PersonDirectory = (*PersonHTTPClient)(nil)
+
+// NewPersonHTTPClient crea el cliente. httpClient es opcional (p.ej. el de un httptest.Server);
+// si es nil se crea uno con cfg.Timeout por intento y transporte instrumentado con OpenTelemetry.
+func NewPersonHTTPClient(cfg PersonHTTPClientConfig, httpClient *http.Client) *PersonHTTPClient {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 2 * time.Second
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: cfg.Timeout, Transport: otelhttp.NewTransport(http.DefaultTransport)}
+	}
+	return &PersonHTTPClient{
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		http:    httpClient,
+		retry:   httpRetryPolicy{MaxRetries: cfg.MaxRetries, BaseBackoff: cfg.RetryBackoff, MaxBackoff: 2 * time.Second},
+		breaker: newCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+	}
+}
+
+// Ping verifica que ms-persons responda en /health; lo usa el chequeo de readiness.
+func (c *PersonHTTPClient) Ping(ctx context.Context) error {
+	if err := pingService(ctx, c.http, c.baseURL+"/health"); err != nil {
+		return fmt.Errorf("persons: %w", err)
+	}
+	return nil
+}
+
+type personResponse struct {
+	Data *struct {
+		DocumentNumber string `json:"document_number"`
+	} `json:"data"`
+}
+
+func (c *PersonHTTPClient) GetDocumentNumber(ctx context.Context, tenantID string, personID string) (string, error) {
+	ctx, span := tracing.Start(ctx, "PersonClient.GetDocumentNumber", tracing.TenantID(tenantID))
+	defer span.End()
+
+	if !c.breaker.allow() {
+		return "", fmt.Errorf("persons: %w", ErrCircuitOpen)
+	}
+
+	endpoint := c.baseURL + "/api/v1/persons/" + url.PathEscape(personID)
+	resp, err := doWithRetry(ctx, c.http, c.retry, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		setServiceHeaders(ctx, req, tenantID)
+		return req, nil
+	})
+	if err != nil {
+		c.breaker.failed(ctx)
+		tracing.RecordError(span, err)
+		return "", fmt.Errorf("persons: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		c.breaker.success()
+		return "", nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		c.breaker.failure()
+		return "", fmt.Errorf("persons: status %d", resp.StatusCode)
+	case resp.StatusCode >= 400:
+		c.breaker.success()
+		return "", fmt.Errorf("persons: status %d", resp.StatusCode)
+	}
+	c.breaker.success()
+
+	var out personResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&out); err != nil {
+		return "", fmt.Errorf("persons: respuesta inválida: %w", err)
+	}
+	if out.Data == nil {
+		return "", nil
+	}
+	return strings.TrimSpace(out.Data.DocumentNumber), nil
+}
@@ -0,0 +1,24 @@
+package clients
+
+import (
+	"context"
+
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+)
+
+// PersonStubClient no conoce personas: sin PERSONS_BASE_URL el seguimiento público no puede
+// verificar al destinatario y muestra solo la vista básica.
+type PersonStubClient struct{}
+
+var _ port.PersonDirectory = PersonStubClient{}
+
+func NewPersonStubClient() PersonStubClient {
+	return PersonStubClient{}
+}
+
+func (PersonStubClient) GetDocumentNumber(ctx context.Context, tenantID string, personID string) (string, error) {
+	_ = ctx
+	_ = tenantID
+	_ = personID
+	return "", nil
+}
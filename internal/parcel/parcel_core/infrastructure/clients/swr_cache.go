@@ -0,0 +1,241 @@
+package clients
+
+import (
+	"container/list"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"ms-parcel-core/internal/pkg/util/logging"
+)
+
+// CacheStats son los contadores acumulados de un cache.
+type CacheStats struct {
+	Hits       uint64 `json:"hits"`
+	StaleHits  uint64 `json:"stale_hits"`
+	Misses     uint64 `json:"misses"`
+	Refreshes  uint64 `json:"refreshes"`
+	Evictions  uint64 `json:"evictions"`
+	Entries    int    `json:"entries"`
+	MaxEntries int    `json:"max_entries"`
+}
+
+type swrCacheEntry[V any] struct {
+	key        string
+	value      V
+	expiresAt  time.Time
+	staleUntil time.Time
+}
+
+// swrCache es un cache LRU acotado con TTL, stale-while-revalidate y coalescing (singleflight) por clave.
+type swrCache[V any] struct {
+	cfg CachedTenantOptionsConfig
+	// name identifica el cache en los logs
+	name   string
+	logger *slog.Logger
+
+	// sf coalesce las consultas concurrentes al origen por clave
+	sf singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]*list.Element // clave -> elemento en lru
+	lru     *list.List               // frente = más reciente
+	// gens se incrementa al invalidar: un fetch iniciado antes no repuebla el cache
+	gens map[string]uint64
+	// inflight cuenta los fetch en curso por clave, para invalidar también claves que todavía no
+	// están en el cache (primer acceso o desalojadas)
+	inflight map[string]int
+
+	hits      atomic.Uint64
+	staleHits atomic.Uint64
+	misses    atomic.Uint64
+	refreshes atomic.Uint64
+	evictions atomic.Uint64
+}
+
+func newSWRCache[V any](cfg CachedTenantOptionsConfig, name string, logger *slog.Logger) *swrCache[V] {
+	return &swrCache[V]{
+		cfg:      cfg,
+		name:     name,
+		logger:   logging.OrDiscard(logger),
+		entries:  map[string]*list.Element{},
+		lru:      list.New(),
+		gens:     map[string]uint64{},
+		inflight: map[string]int{},
+	}
+}
+
+func (c *swrCache[V]) get(ctx context.Context, key string, fetch func(ctx context.Context) (V, error)) (V, error) {
+	now := time.Now().UTC()
+
+	c.mu.Lock()
+	e, ok := c.lookup(key)
+	c.mu.Unlock()
+
+	if ok && now.Before(e.expiresAt) {
+		c.hits.Add(1)
+		return e.value, nil
+	}
+	if ok && now.Before(e.staleUntil) {
+		c.staleHits.Add(1)
+		ch := c.fetchShared(ctx, key, fetch, &c.refreshes)
+		go func() {
+			if res := <-ch; res.Err != nil {
+				// se sigue sirviendo stale hasta staleUntil
+				c.logger.WarnContext(ctx, "falló el refresco en segundo plano del cache", "cache", c.name, "key", key, "error", res.Err)
+			}
+		}()
+		return e.value, nil
+	}
+
+	c.misses.Add(1)
+	ch := c.fetchShared(ctx, key, fetch, nil)
+	select {
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			var zero V
+			return zero, res.Err
+		}
+		return res.Val.(V), nil
+	}
+}
+
+// fetchShared consulta el origen una sola vez por clave aunque haya llamadas concurrentes.
+// El fetch se desacopla de la cancelación del primer caller (los demás esperan el mismo resultado)
+// pero conserva los valores del context (token del caller).
+func (c *swrCache[V]) fetchShared(ctx context.Context, key string, fetch func(ctx context.Context) (V, error), counter *atomic.Uint64) <-chan singleflight.Result {
+	return c.sf.DoChan(key, func() (any, error) {
+		if counter != nil {
+			counter.Add(1)
+		}
+		c.mu.Lock()
+		gen := c.gens[key]
+		c.inflight[key]++
+		c.mu.Unlock()
+		defer c.done(key)
+
+		fctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		v, err := fetch(fctx)
+		if err != nil {
+			return v, err
+		}
+
+		now := time.Now().UTC()
+		c.mu.Lock()
+		if c.gens[key] == gen {
+			c.store(swrCacheEntry[V]{
+				key:        key,
+				value:      v,
+				expiresAt:  now.Add(c.cfg.TTL),
+				staleUntil: now.Add(c.cfg.TTL + c.cfg.StaleTTL),
+			})
+		}
+		c.mu.Unlock()
+		return v, nil
+	})
+}
+
+func (c *swrCache[V]) invalidate(key string) {
+	c.mu.Lock()
+	c.remove(key)
+	c.mu.Unlock()
+}
+
+func (c *swrCache[V]) invalidatePrefix(prefix string) {
+	c.mu.Lock()
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(key)
+		}
+	}
+	for key := range c.inflight {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(key)
+		}
+	}
+	c.mu.Unlock()
+}
+
+func (c *swrCache[V]) invalidateAll() {
+	c.mu.Lock()
+	for key := range c.entries {
+		c.remove(key)
+	}
+	for key := range c.inflight {
+		c.remove(key)
+	}
+	c.mu.Unlock()
+}
+
+func (c *swrCache[V]) stats() CacheStats {
+	c.mu.Lock()
+	entries := c.lru.Len()
+	c.mu.Unlock()
+
+	return CacheStats{
+		Hits:       c.hits.Load(),
+		StaleHits:  c.staleHits.Load(),
+		Misses:     c.misses.Load(),
+		Refreshes:  c.refreshes.Load(),
+		Evictions:  c.evictions.Load(),
+		Entries:    entries,
+		MaxEntries: c.cfg.MaxEntries,
+	}
+}
+
+// done descuenta un fetch terminado. Sin fetch en curso la generación ya no protege nada y se
+// descarta, así gens no crece con cada clave invalidada.
+func (c *swrCache[V]) done(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.inflight[key]--; c.inflight[key] <= 0 {
+		delete(c.inflight, key)
+		delete(c.gens, key)
+	}
+}
+
+// lookup, store y remove requieren c.mu tomado.
+func (c *swrCache[V]) lookup(key string) (swrCacheEntry[V], bool) {
+	el, ok := c.entries[key]
+	if !ok {
+		return swrCacheEntry[V]{}, false
+	}
+	c.lru.MoveToFront(el)
+	return *el.Value.(*swrCacheEntry[V]), true
+}
+
+func (c *swrCache[V]) store(e swrCacheEntry[V]) {
+	if el, ok := c.entries[e.key]; ok {
+		*el.Value.(*swrCacheEntry[V]) = e
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[e.key] = c.lru.PushFront(&e)
+	for c.lru.Len() > c.cfg.MaxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*swrCacheEntry[V]).key)
+		c.evictions.Add(1)
+	}
+}
+
+func (c *swrCache[V]) remove(key string) {
+	if el, ok := c.entries[key]; ok {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+	}
+	// Solo un fetch en curso puede repoblar la clave con el valor viejo
+	if c.inflight[key] > 0 {
+		c.gens[key]++
+	}
+	c.sf.Forget(key)
+}
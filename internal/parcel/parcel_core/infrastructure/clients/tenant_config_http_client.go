@@ -0,0 +1,300 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type TenantConfigHTTPClientConfig struct {
+	BaseURL          string
+	Timeout          time.Duration
+	MaxRetries       int
+	RetryBackoff     time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// TenantConfigHTTPClientConfigFromEnv lee la configuración de ms-tenant-config.
+// Sin TENANT_CONFIG_BASE_URL el composition root usa el stub.
+func TenantConfigHTTPClientConfigFromEnv() TenantConfigHTTPClientConfig {
+	return TenantConfigHTTPClientConfig{
+		BaseURL:          strings.TrimRight(strings.TrimSpace(os.Getenv("TENANT_CONFIG_BASE_URL")), "/"),
+		Timeout:          envDurationMs("TENANT_CONFIG_TIMEOUT_MS", 2*time.Second),
+		MaxRetries:       envInt("TENANT_CONFIG_MAX_RETRIES", 2),
+		RetryBackoff:     envDurationMs("TENANT_CONFIG_RETRY_BACKOFF_MS", 100*time.Millisecond),
+		BreakerThreshold: envInt("TENANT_CONFIG_BREAKER_THRESHOLD", 5),
+		BreakerCooldown:  envDurationMs("TENANT_CONFIG_BREAKER_COOLDOWN_MS", 30*time.Second),
+	}
+}
+
+// TenantConfigHTTPClient implementa TenantConfigClient y TenantOptionsProvider contra ms-tenant-config.
+// Guarda el último valor válido por tenant (last-known-good): si el servicio no responde o devuelve
+// opciones inválidas, se sirve ese valor; solo falla si nunca se obtuvo uno.
+type TenantConfigHTTPClient struct {
+	baseURL string
+	http    *http.Client
+	retry   httpRetryPolicy
+	breaker *circuitBreaker
+
+	mu           sync.Mutex
+	lastOptions  map[string]port.ParcelOptions
+	lastOffices  map[string]*port.ParcelOptionsOverride // tenant|office -> override
+	lastFeatures map[string]bool                        // tenant|feature -> enabled
+
+	logger *slog.Logger
+}
+
+var _ port.TenantConfigClient = (*TenantConfigHTTPClient)(nil)
+var _ port.TenantOptionsProvider = (*TenantConfigHTTPClient)(nil)
+var _ port.OfficeOptionsProvider = (*TenantConfigHTTPClient)(nil)
+var _ port.OfficeDirectory = (*TenantConfigHTTPClient)(nil)
+
+// NewTenantConfigHTTPClient crea el cliente. httpClient es opcional (p.ej. el de un httptest.Server);
+// si es nil se crea uno con transporte instrumentado con OpenTelemetry.
+func NewTenantConfigHTTPClient(cfg TenantConfigHTTPClientConfig, httpClient *http.Client, logger *slog.Logger) *TenantConfigHTTPClient {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 2 * time.Second
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: cfg.Timeout, Transport: otelhttp.NewTransport(http.DefaultTransport)}
+	}
+	return &TenantConfigHTTPClient{
+		baseURL:      strings.TrimRight(cfg.BaseURL, "/"),
+		http:         httpClient,
+		retry:        httpRetryPolicy{MaxRetries: cfg.MaxRetries, BaseBackoff: cfg.RetryBackoff, MaxBackoff: 2 * time.Second},
+		breaker:      newCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+		lastOptions:  map[string]port.ParcelOptions{},
+		lastOffices:  map[string]*port.ParcelOptionsOverride{},
+		lastFeatures: map[string]bool{},
+		logger:       logging.OrDiscard(logger),
+	}
+}
+
+// tenantParcelOptionsResponse es el contrato de ms-tenant-config (tenant y oficina). Los campos
+// ausentes heredan del nivel anterior; un tipo incorrecto (p.ej. "6000" como string) hace fallar el decode.
+type tenantParcelOptionsResponse struct {
+	Data *port.ParcelOptionsOverride `json:"data"`
+}
+
+type tenantOfficeResponse struct {
+	Data *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		City string `json:"city"`
+	} `json:"data"`
+}
+
+type tenantFeatureResponse struct {
+	Data *struct {
+		Enabled *bool `json:"enabled"`
+	} `json:"data"`
+}
+
+// Ping verifica que ms-tenant-config responda en /health; lo usa el chequeo de readiness.
+func (c *TenantConfigHTTPClient) Ping(ctx context.Context) error {
+	if err := pingService(ctx, c.http, c.baseURL+"/health"); err != nil {
+		return fmt.Errorf("tenant-config: %w", err)
+	}
+	return nil
+}
+
+func (c *TenantConfigHTTPClient) GetParcelOptions(ctx context.Context, tenantID string) (port.ParcelOptions, error) {
+	ctx, span := tracing.Start(ctx, "TenantConfigClient.GetParcelOptions", tracing.TenantID(tenantID))
+	defer span.End()
+
+	opts, err := c.fetchParcelOptions(ctx, tenantID)
+	if err == nil {
+		c.mu.Lock()
+		c.lastOptions[tenantID] = opts
+		c.mu.Unlock()
+		return opts, nil
+	}
+
+	c.mu.Lock()
+	lkg, ok := c.lastOptions[tenantID]
+	c.mu.Unlock()
+	if ok {
+		c.logger.WarnContext(ctx, "tenant-config no disponible, se usan las últimas opciones conocidas", "tenant_id", tenantID, "error", err)
+		span.AddEvent("tenant_config.last_known_good")
+		return lkg, nil
+	}
+	tracing.RecordError(span, err)
+	return port.ParcelOptions{}, err
+}
+
+// GetOfficeParcelOptions devuelve los overrides de la oficina (nil si no tiene).
+// La validación del resultado combinado la hace el resolver de opciones.
+func (c *TenantConfigHTTPClient) GetOfficeParcelOptions(ctx context.Context, tenantID string, officeID string) (*port.ParcelOptionsOverride, error) {
+	ctx, span := tracing.Start(ctx, "TenantConfigClient.GetOfficeParcelOptions", tracing.TenantID(tenantID))
+	defer span.End()
+
+	key := tenantID + "|" + officeID
+	endpoint := c.baseURL + "/api/v1/tenants/" + url.PathEscape(tenantID) + "/offices/" + url.PathEscape(officeID) + "/parcel-options"
+	var out tenantParcelOptionsResponse
+	found, err := c.getJSON(ctx, tenantID, endpoint, &out)
+	if err == nil {
+		var ov *port.ParcelOptionsOverride
+		if found {
+			ov = out.Data
+		}
+		c.mu.Lock()
+		c.lastOffices[key] = ov
+		c.mu.Unlock()
+		return ov, nil
+	}
+
+	c.mu.Lock()
+	lkg, ok := c.lastOffices[key]
+	c.mu.Unlock()
+	if ok {
+		c.logger.WarnContext(ctx, "tenant-config no disponible, se usan los últimos overrides conocidos de la oficina", "tenant_id", tenantID, "office_id", officeID, "error", err)
+		span.AddEvent("tenant_config.last_known_good")
+		return lkg, nil
+	}
+	tracing.RecordError(span, err)
+	return nil, err
+}
+
+// GetOffice devuelve nombre y ciudad de la oficina (nil si no existe). Sin last-known-good: lo cachea
+// CachedOfficeDirectory.
+func (c *TenantConfigHTTPClient) GetOffice(ctx context.Context, tenantID string, officeID string) (*port.OfficeInfo, error) {
+	ctx, span := tracing.Start(ctx, "TenantConfigClient.GetOffice", tracing.TenantID(tenantID))
+	defer span.End()
+
+	endpoint := c.baseURL + "/api/v1/tenants/" + url.PathEscape(tenantID) + "/offices/" + url.PathEscape(officeID)
+	var out tenantOfficeResponse
+	found, err := c.getJSON(ctx, tenantID, endpoint, &out)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if !found || out.Data == nil {
+		return nil, nil
+	}
+	return &port.OfficeInfo{ID: officeID, Name: out.Data.Name, City: out.Data.City}, nil
+}
+
+func (c *TenantConfigHTTPClient) IsEnabled(ctx context.Context, tenantID string, featureKey string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "TenantConfigClient.IsEnabled", tracing.TenantID(tenantID))
+	defer span.End()
+
+	key := tenantID + "|" + featureKey
+	enabled, err := c.fetchFeature(ctx, tenantID, featureKey)
+	if errors.Is(err, port.ErrFeatureFlagNotFound) {
+		c.mu.Lock()
+		delete(c.lastFeatures, key)
+		c.mu.Unlock()
+		return false, err
+	}
+	if err == nil {
+		c.mu.Lock()
+		c.lastFeatures[key] = enabled
+		c.mu.Unlock()
+		return enabled, nil
+	}
+
+	c.mu.Lock()
+	lkg, ok := c.lastFeatures[key]
+	c.mu.Unlock()
+	if ok {
+		c.logger.WarnContext(ctx, "tenant-config no disponible, se usa el último valor conocido del flag", "tenant_id", tenantID, "feature", featureKey, "error", err)
+		span.AddEvent("tenant_config.last_known_good")
+		return lkg, nil
+	}
+	tracing.RecordError(span, err)
+	return false, err
+}
+
+func (c *TenantConfigHTTPClient) fetchParcelOptions(ctx context.Context, tenantID string) (port.ParcelOptions, error) {
+	endpoint := c.baseURL + "/api/v1/tenants/" + url.PathEscape(tenantID) + "/parcel-options"
+	var out tenantParcelOptionsResponse
+	found, err := c.getJSON(ctx, tenantID, endpoint, &out)
+	if err != nil {
+		return port.ParcelOptions{}, err
+	}
+	if !found || out.Data == nil {
+		// tenant sin configuración propia: valores por defecto
+		return port.DefaultParcelOptions(), nil
+	}
+
+	opts, _ := out.Data.ApplyTo(port.DefaultParcelOptions())
+	if err := opts.Validate(); err != nil {
+		return port.ParcelOptions{}, fmt.Errorf("tenant-config: %w", err)
+	}
+	return opts, nil
+}
+
+func (c *TenantConfigHTTPClient) fetchFeature(ctx context.Context, tenantID string, featureKey string) (bool, error) {
+	endpoint := c.baseURL + "/api/v1/tenants/" + url.PathEscape(tenantID) + "/features/" + url.PathEscape(featureKey)
+	var out tenantFeatureResponse
+	found, err := c.getJSON(ctx, tenantID, endpoint, &out)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return false, port.ErrFeatureFlagNotFound
+	}
+	if out.Data == nil || out.Data.Enabled == nil {
+		return false, fmt.Errorf("tenant-config: respuesta sin enabled para %q", featureKey)
+	}
+	return *out.Data.Enabled, nil
+}
+
+// getJSON hace GET con reintentos y circuit breaker y decodifica el body en dst.
+// Devuelve found=false ante 404.
+func (c *TenantConfigHTTPClient) getJSON(ctx context.Context, tenantID string, endpoint string, dst any) (bool, error) {
+	if !c.breaker.allow() {
+		return false, fmt.Errorf("tenant-config: %w", ErrCircuitOpen)
+	}
+
+	resp, err := doWithRetry(ctx, c.http, c.retry, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		setServiceHeaders(ctx, req, tenantID)
+		return req, nil
+	})
+	if err != nil {
+		c.breaker.failed(ctx)
+		return false, fmt.Errorf("tenant-config: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		c.breaker.success()
+		return false, nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		c.breaker.failure()
+		return false, fmt.Errorf("tenant-config: status %d", resp.StatusCode)
+	case resp.StatusCode >= 400:
+		c.breaker.success()
+		return false, fmt.Errorf("tenant-config: status %d", resp.StatusCode)
+	}
+	c.breaker.success()
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(dst); err != nil {
+		return false, fmt.Errorf("tenant-config: respuesta inválida: %w", err)
+	}
+	return true, nil
+}
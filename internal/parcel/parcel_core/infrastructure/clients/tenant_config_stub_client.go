@@ -2,40 +2,57 @@ package clients
 
 import (
 	"context"
+	"os"
+	"strings"
 
 	"ms-parcel-core/internal/parcel/parcel_core/port"
 )
 
-type TenantConfigStubClient struct{}
+// TenantConfigStubClient habilita todas las funcionalidades salvo las listadas en
+// FEATURE_FLAGS_DISABLED (separadas por coma), útil para probar el gate en desarrollo.
+type TenantConfigStubClient struct {
+	disabled map[string]bool
+}
 
 func NewTenantConfigStubClient() *TenantConfigStubClient {
-	return &TenantConfigStubClient{}
+	disabled := map[string]bool{}
+	for _, k := range strings.Split(os.Getenv("FEATURE_FLAGS_DISABLED"), ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			disabled[k] = true
+		}
+	}
+	return &TenantConfigStubClient{disabled: disabled}
 }
 
 var _ port.TenantOptionsProvider = (*TenantConfigStubClient)(nil)
 var _ port.TenantConfigClient = (*TenantConfigStubClient)(nil)
+var _ port.OfficeOptionsProvider = (*TenantConfigStubClient)(nil)
+var _ port.OfficeDirectory = (*TenantConfigStubClient)(nil)
 
 func (c *TenantConfigStubClient) IsEnabled(ctx context.Context, tenantID string, featureKey string) (bool, error) {
 	_ = ctx
 	_ = tenantID
-	_ = featureKey
-	return false, nil
+	return !c.disabled[featureKey], nil
 }
 
 func (c *TenantConfigStubClient) GetParcelOptions(ctx context.Context, tenantID string) (port.ParcelOptions, error) {
 	_ = ctx
 	_ = tenantID
 
-	return port.ParcelOptions{
-		RequirePackageKey:       true,
-		UsePriceTable:           true,
-		UseVolumetricWeight:     false,
-		VolumetricDivisor:       6000,
-		AllowManualPrice:        false,
-		AllowOverridePriceTable: true,
-		AllowPayInDestination:   false,
-		MaxPrints:               1,
-		AllowReprint:            false,
-		ReprintFeeEnabled:       false,
-	}, nil
+	return port.DefaultParcelOptions(), nil
+}
+
+func (c *TenantConfigStubClient) GetOfficeParcelOptions(ctx context.Context, tenantID string, officeID string) (*port.ParcelOptionsOverride, error) {
+	_ = ctx
+	_ = tenantID
+	_ = officeID
+	return nil, nil
+}
+
+// GetOffice no conoce oficinas: el seguimiento público muestra solo los ids.
+func (c *TenantConfigStubClient) GetOffice(ctx context.Context, tenantID string, officeID string) (*port.OfficeInfo, error) {
+	_ = ctx
+	_ = tenantID
+	_ = officeID
+	return nil, nil
 }
@@ -2,60 +2,97 @@ package clients
 
 import (
 	"context"
-	"sync"
+	"log/slog"
 	"time"
 
 	"ms-parcel-core/internal/parcel/parcel_core/port"
 )
 
-type tenantOptionsCacheEntry struct {
-	opts      port.ParcelOptions
-	expiresAt time.Time
+type CachedTenantOptionsConfig struct {
+	// TTL: tiempo en que la entrada se considera fresca.
+	TTL time.Duration
+	// StaleTTL: ventana adicional en que se sirve la entrada vencida mientras se refresca en segundo plano
+	// (y también si el refresh falla). 0 = sin stale-while-revalidate.
+	StaleTTL time.Duration
+	// MaxEntries: máximo de entradas en cache; al superarlo se desaloja la menos usada (LRU).
+	MaxEntries int
 }
 
+// CachedTenantOptionsConfigFromEnv lee TTL, ventana stale y tamaño máximo del cache.
+func CachedTenantOptionsConfigFromEnv() CachedTenantOptionsConfig {
+	return CachedTenantOptionsConfig{
+		TTL:        envDurationMs("TENANT_OPTIONS_CACHE_TTL_MS", 60*time.Second),
+		StaleTTL:   envDurationMs("TENANT_OPTIONS_CACHE_STALE_MS", 5*time.Minute),
+		MaxEntries: envInt("TENANT_OPTIONS_CACHE_MAX_ENTRIES", 1000),
+	}
+}
+
+// TenantOptionsCacheStats separa los contadores de opciones de tenant y overrides de oficina.
+type TenantOptionsCacheStats struct {
+	Tenants CacheStats `json:"tenants"`
+	Offices CacheStats `json:"offices"`
+}
+
+// CachedTenantOptionsProvider cachea las opciones por tenant y, si el inner también
+// implementa OfficeOptionsProvider, los overrides por oficina.
 type CachedTenantOptionsProvider struct {
-	inner port.TenantOptionsProvider
-	ttl   time.Duration
+	inner   port.TenantOptionsProvider
+	offices port.OfficeOptionsProvider
 
-	mu    sync.Mutex
-	cache map[string]tenantOptionsCacheEntry
+	tenantCache *swrCache[port.ParcelOptions]
+	officeCache *swrCache[*port.ParcelOptionsOverride]
 }
 
 var _ port.TenantOptionsProvider = (*CachedTenantOptionsProvider)(nil)
+var _ port.OfficeOptionsProvider = (*CachedTenantOptionsProvider)(nil)
+var _ port.TenantOptionsInvalidator = (*CachedTenantOptionsProvider)(nil)
 
-func NewCachedTenantOptionsProvider(inner port.TenantOptionsProvider, ttl time.Duration) *CachedTenantOptionsProvider {
-	if ttl <= 0 {
-		ttl = 60 * time.Second
+func NewCachedTenantOptionsProvider(inner port.TenantOptionsProvider, cfg CachedTenantOptionsConfig, logger *slog.Logger) *CachedTenantOptionsProvider {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 60 * time.Second
+	}
+	if cfg.StaleTTL < 0 {
+		cfg.StaleTTL = 0
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = 1000
+	}
+	offices, _ := inner.(port.OfficeOptionsProvider)
+	return &CachedTenantOptionsProvider{
+		inner:       inner,
+		offices:     offices,
+		tenantCache: newSWRCache[port.ParcelOptions](cfg, "tenant_options", logger),
+		officeCache: newSWRCache[*port.ParcelOptionsOverride](cfg, "office_options", logger),
 	}
-	return &CachedTenantOptionsProvider{inner: inner, ttl: ttl, cache: map[string]tenantOptionsCacheEntry{}}
 }
 
 func (p *CachedTenantOptionsProvider) GetParcelOptions(ctx context.Context, tenantID string) (port.ParcelOptions, error) {
-	now := time.Now().UTC()
-
-	p.mu.Lock()
-	if p.cache != nil {
-		if e, ok := p.cache[tenantID]; ok {
-			if now.Before(e.expiresAt) {
-				opts := e.opts
-				p.mu.Unlock()
-				return opts, nil
-			}
-		}
-	}
-	p.mu.Unlock()
+	return p.tenantCache.get(ctx, tenantID, func(ctx context.Context) (port.ParcelOptions, error) {
+		return p.inner.GetParcelOptions(ctx, tenantID)
+	})
+}
 
-	opts, err := p.inner.GetParcelOptions(ctx, tenantID)
-	if err != nil {
-		return port.ParcelOptions{}, err
+func (p *CachedTenantOptionsProvider) GetOfficeParcelOptions(ctx context.Context, tenantID string, officeID string) (*port.ParcelOptionsOverride, error) {
+	if p.offices == nil {
+		return nil, nil
 	}
+	return p.officeCache.get(ctx, tenantID+"|"+officeID, func(ctx context.Context) (*port.ParcelOptionsOverride, error) {
+		return p.offices.GetOfficeParcelOptions(ctx, tenantID, officeID)
+	})
+}
 
-	p.mu.Lock()
-	if p.cache == nil {
-		p.cache = map[string]tenantOptionsCacheEntry{}
-	}
-	p.cache[tenantID] = tenantOptionsCacheEntry{opts: opts, expiresAt: now.Add(p.ttl)}
-	p.mu.Unlock()
+// Invalidate descarta las opciones del tenant y de sus oficinas; la próxima consulta va al servicio.
+func (p *CachedTenantOptionsProvider) Invalidate(tenantID string) {
+	p.tenantCache.invalidate(tenantID)
+	p.officeCache.invalidatePrefix(tenantID + "|")
+}
+
+// InvalidateAll vacía el cache.
+func (p *CachedTenantOptionsProvider) InvalidateAll() {
+	p.tenantCache.invalidateAll()
+	p.officeCache.invalidateAll()
+}
 
-	return opts, nil
+func (p *CachedTenantOptionsProvider) Stats() TenantOptionsCacheStats {
+	return TenantOptionsCacheStats{Tenants: p.tenantCache.stats(), Offices: p.officeCache.stats()}
 }
@@ -0,0 +1,117 @@
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+)
+
+type FeatureGateConfig struct {
+	// FailOpen: si el servicio de flags no responde se permite la operación (true)
+	// o se rechaza con feature_disabled (false).
+	FailOpen bool
+	// CacheTTL: cuánto se reutiliza una respuesta; evita consultar dos veces el mismo flag
+	// en middleware y caso de uso. 0 = sin cache.
+	CacheTTL time.Duration
+}
+
+// FeatureGateConfigFromEnv lee FEATURE_FLAGS_FAIL_OPEN (default true) y FEATURE_FLAGS_CACHE_TTL_MS (default 30s).
+func FeatureGateConfigFromEnv() FeatureGateConfig {
+	cfg := FeatureGateConfig{FailOpen: true, CacheTTL: 30 * time.Second}
+	if v := strings.TrimSpace(os.Getenv("FEATURE_FLAGS_FAIL_OPEN")); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.FailOpen = b
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv("FEATURE_FLAGS_CACHE_TTL_MS")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.CacheTTL = time.Duration(n) * time.Millisecond
+		}
+	}
+	return cfg
+}
+
+type cachedFlag struct {
+	enabled   bool
+	expiresAt time.Time
+}
+
+// FeatureGate consulta los flags en TENANT-CONFIG. Un flag no definido cuenta como habilitado:
+// las funcionalidades se deshabilitan explícitamente por tenant.
+type FeatureGate struct {
+	client port.TenantConfigClient
+	cfg    FeatureGateConfig
+	logger *slog.Logger
+
+	mu    sync.Mutex
+	cache map[string]cachedFlag
+}
+
+var _ port.FeatureGate = (*FeatureGate)(nil)
+
+func NewFeatureGate(client port.TenantConfigClient, cfg FeatureGateConfig, logger *slog.Logger) *FeatureGate {
+	if cfg.CacheTTL < 0 {
+		cfg.CacheTTL = 0
+	}
+	return &FeatureGate{client: client, cfg: cfg, logger: logging.OrDiscard(logger), cache: map[string]cachedFlag{}}
+}
+
+func (g *FeatureGate) Require(ctx context.Context, tenantID string, feature string) error {
+	enabled, err := g.isEnabled(ctx, tenantID, feature)
+	if err != nil {
+		g.logger.WarnContext(ctx, "servicio de flags no disponible", "tenant_id", tenantID, "feature", feature, "fail_open", g.cfg.FailOpen, "error", err)
+		if g.cfg.FailOpen {
+			return nil
+		}
+		return featureDisabled(feature, "flag_service_unavailable")
+	}
+	if !enabled {
+		return featureDisabled(feature, "disabled_for_tenant")
+	}
+	return nil
+}
+
+func (g *FeatureGate) isEnabled(ctx context.Context, tenantID string, feature string) (bool, error) {
+	if g.client == nil {
+		return true, nil
+	}
+
+	key := tenantID + "|" + feature
+	now := time.Now()
+	if g.cfg.CacheTTL > 0 {
+		g.mu.Lock()
+		e, ok := g.cache[key]
+		g.mu.Unlock()
+		if ok && now.Before(e.expiresAt) {
+			return e.enabled, nil
+		}
+	}
+
+	enabled, err := g.client.IsEnabled(ctx, tenantID, feature)
+	if errors.Is(err, port.ErrFeatureFlagNotFound) {
+		enabled, err = true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if g.cfg.CacheTTL > 0 {
+		g.mu.Lock()
+		g.cache[key] = cachedFlag{enabled: enabled, expiresAt: now.Add(g.cfg.CacheTTL)}
+		g.mu.Unlock()
+	}
+	return enabled, nil
+}
+
+func featureDisabled(feature string, reason string) error {
+	return apperror.New("feature_disabled", "funcionalidad deshabilitada", map[string]any{"feature": feature, "reason": reason}, 403)
+}
@@ -0,0 +1,94 @@
+package options
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+// ParcelOptionsResolver combina defaults, opciones del tenant y overrides de oficina.
+// Cada nivel se valida; si un nivel no está disponible o es inválido se ignora y se queda
+// con el anterior (y se registra en el log).
+type ParcelOptionsResolver struct {
+	tenants port.TenantOptionsProvider
+	offices port.OfficeOptionsProvider
+	logger  *slog.Logger
+}
+
+var _ port.ParcelOptionsResolver = (*ParcelOptionsResolver)(nil)
+
+func NewParcelOptionsResolver(tenants port.TenantOptionsProvider, offices port.OfficeOptionsProvider, logger *slog.Logger) *ParcelOptionsResolver {
+	return &ParcelOptionsResolver{tenants: tenants, offices: offices, logger: logging.OrDiscard(logger)}
+}
+
+func (r *ParcelOptionsResolver) Resolve(ctx context.Context, tenantID string, officeID string) port.ParcelOptions {
+	return r.ResolveDetailed(ctx, tenantID, officeID).Options
+}
+
+func (r *ParcelOptionsResolver) ResolveDetailed(ctx context.Context, tenantID string, officeID string) port.ResolvedParcelOptions {
+	tenantID = strings.TrimSpace(tenantID)
+	officeID = strings.TrimSpace(officeID)
+	ctx, span := tracing.Start(ctx, "ParcelOptionsResolver.Resolve", tracing.TenantID(tenantID))
+	defer span.End()
+
+	defaults := port.DefaultParcelOptions()
+	res := port.ResolvedParcelOptions{
+		TenantID:  tenantID,
+		OfficeID:  officeID,
+		Options:   defaults,
+		Sources:   map[string]port.ParcelOptionsSource{},
+		Fallbacks: make([]string, 0),
+	}
+	for _, f := range port.ParcelOptionFieldNames() {
+		res.Sources[f] = port.ParcelOptionsSourceDefault
+	}
+
+	if r.tenants != nil && tenantID != "" {
+		o, err := r.tenants.GetParcelOptions(ctx, tenantID)
+		switch {
+		case err != nil:
+			r.fallback(ctx, &res, port.ParcelOptionsFallbackTenantUnavailable, err)
+		case o.Validate() != nil:
+			r.fallback(ctx, &res, port.ParcelOptionsFallbackTenantInvalid, o.Validate())
+		default:
+			res.Options = o
+			for _, f := range o.DiffFields(defaults) {
+				res.Sources[f] = port.ParcelOptionsSourceTenant
+			}
+		}
+	}
+
+	if r.offices != nil && tenantID != "" && officeID != "" {
+		ov, err := r.offices.GetOfficeParcelOptions(ctx, tenantID, officeID)
+		switch {
+		case err != nil:
+			r.fallback(ctx, &res, port.ParcelOptionsFallbackOfficeUnavailable, err)
+		case ov != nil:
+			merged, applied := ov.ApplyTo(res.Options)
+			if verr := merged.Validate(); verr != nil {
+				r.fallback(ctx, &res, port.ParcelOptionsFallbackOfficeInvalid, verr)
+				break
+			}
+			res.Options = merged
+			for _, f := range applied {
+				res.Sources[f] = port.ParcelOptionsSourceOffice
+			}
+		}
+	}
+
+	span.SetAttributes(attribute.String("office.id", officeID), attribute.Int("parcel_options.fallbacks", len(res.Fallbacks)))
+	return res
+}
+
+// fallback registra que se ignoró un nivel. Al cliente solo llega el código: el error puede traer
+// URLs o respuestas del servicio de configuración y queda en el log.
+func (r *ParcelOptionsResolver) fallback(ctx context.Context, res *port.ResolvedParcelOptions, reason string, err error) {
+	res.Fallbacks = append(res.Fallbacks, reason)
+	r.logger.WarnContext(ctx, "opciones de envío con fallback", "tenant_id", res.TenantID, "office_id", res.OfficeID, "fallback", reason, "error", err)
+}
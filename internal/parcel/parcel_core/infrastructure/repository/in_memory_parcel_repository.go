@@ -9,9 +9,11 @@ import (
 
 	"github.com/google/uuid"
 
+	"ms-parcel-core/internal/infrastructure/persistence/memory"
 	"ms-parcel-core/internal/parcel/parcel_core/domain"
 	"ms-parcel-core/internal/parcel/parcel_core/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type InMemoryParcelRepository struct {
@@ -20,13 +22,16 @@ type InMemoryParcelRepository struct {
 }
 
 var _ port.ParcelRepository = (*InMemoryParcelRepository)(nil)
+var _ port.TrackingCodeChecker = (*InMemoryParcelRepository)(nil)
+var _ port.TrackingCodeResolver = (*InMemoryParcelRepository)(nil)
 
 func NewInMemoryParcelRepository() *InMemoryParcelRepository {
 	return &InMemoryParcelRepository{data: map[string]map[uuid.UUID]domain.Parcel{}}
 }
 
 func (r *InMemoryParcelRepository) Create(ctx context.Context, p domain.Parcel) (uuid.UUID, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelRepository.Create", tracing.TenantID(p.TenantID))
+	defer span.End()
 
 	id, err := uuid.Parse(p.ID)
 	if err != nil {
@@ -43,12 +48,33 @@ func (r *InMemoryParcelRepository) Create(ctx context.Context, p domain.Parcel)
 		r.data[p.TenantID] = map[uuid.UUID]domain.Parcel{}
 	}
 
+	prev, existed := r.data[p.TenantID][id]
+	r.restoreOnRollback(ctx, p.TenantID, id, prev, existed)
 	r.data[p.TenantID][id] = p
 	return id, nil
 }
 
+// restoreOnRollback deja el parcel como estaba antes de la escritura si se revierte la unidad de trabajo de ctx.
+func (r *InMemoryParcelRepository) restoreOnRollback(ctx context.Context, tenantID string, id uuid.UUID, prev domain.Parcel, existed bool) {
+	memory.OnRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if existed {
+			r.data[tenantID][id] = prev
+		} else {
+			delete(r.data[tenantID], id)
+		}
+	})
+}
+
+// LockByID no necesita bloquear: InMemoryUnitOfWork ya serializa las unidades de trabajo.
+func (r *InMemoryParcelRepository) LockByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.Parcel, error) {
+	return r.GetByID(ctx, tenantID, id)
+}
+
 func (r *InMemoryParcelRepository) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.Parcel, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelRepository.GetByID", tracing.TenantID(tenantID), tracing.ParcelID(id.String()))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -72,7 +98,8 @@ func (r *InMemoryParcelRepository) GetByID(ctx context.Context, tenantID string,
 }
 
 func (r *InMemoryParcelRepository) UpdateRegistered(ctx context.Context, tenantID string, id uuid.UUID, registeredAtUTC time.Time, userID string, userName string) (*domain.Parcel, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelRepository.UpdateRegistered", tracing.TenantID(tenantID), tracing.ParcelID(id.String()))
+	defer span.End()
 	_ = userID
 	_ = userName
 
@@ -96,6 +123,7 @@ func (r *InMemoryParcelRepository) UpdateRegistered(ctx context.Context, tenantI
 	p.RegisteredAt = &registeredAtUTC
 	// TODO: guardar también quién registró si se requiere en el futuro.
 
+	r.restoreOnRollback(ctx, tenantID, id, byTenant[id], true)
 	byTenant[id] = p
 	r.data[tenantID] = byTenant
 
@@ -104,7 +132,8 @@ func (r *InMemoryParcelRepository) UpdateRegistered(ctx context.Context, tenantI
 }
 
 func (r *InMemoryParcelRepository) UpdateBoarded(ctx context.Context, tenantID string, id uuid.UUID, boardedAtUTC time.Time, vehicleID string, tripID *string, departureAt *time.Time, boardedByUserID *string) (*domain.Parcel, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelRepository.UpdateBoarded", tracing.TenantID(tenantID), tracing.ParcelID(id.String()))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -129,6 +158,7 @@ func (r *InMemoryParcelRepository) UpdateBoarded(ctx context.Context, tenantID s
 	p.BoardedDepartureAt = departureAt
 	p.BoardedByUserID = boardedByUserID
 
+	r.restoreOnRollback(ctx, tenantID, id, byTenant[id], true)
 	byTenant[id] = p
 	r.data[tenantID] = byTenant
 
@@ -137,7 +167,8 @@ func (r *InMemoryParcelRepository) UpdateBoarded(ctx context.Context, tenantID s
 }
 
 func (r *InMemoryParcelRepository) UpdateDelivered(ctx context.Context, tenantID string, id uuid.UUID, deliveredAtUTC time.Time, deliveredByUserID *string) (*domain.Parcel, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelRepository.UpdateDelivered", tracing.TenantID(tenantID), tracing.ParcelID(id.String()))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -159,6 +190,7 @@ func (r *InMemoryParcelRepository) UpdateDelivered(ctx context.Context, tenantID
 	p.DeliveredAt = &deliveredAtUTC
 	p.DeliveredByUserID = deliveredByUserID
 
+	r.restoreOnRollback(ctx, tenantID, id, byTenant[id], true)
 	byTenant[id] = p
 	r.data[tenantID] = byTenant
 
@@ -167,7 +199,8 @@ func (r *InMemoryParcelRepository) UpdateDelivered(ctx context.Context, tenantID
 }
 
 func (r *InMemoryParcelRepository) UpdateArrivedDestination(ctx context.Context, tenantID string, id uuid.UUID, arrivedAtUTC time.Time, arrivedByUserID *string) (*domain.Parcel, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelRepository.UpdateArrivedDestination", tracing.TenantID(tenantID), tracing.ParcelID(id.String()))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -189,6 +222,7 @@ func (r *InMemoryParcelRepository) UpdateArrivedDestination(ctx context.Context,
 	p.ArrivedAt = &arrivedAtUTC
 	p.ArrivedByUserID = arrivedByUserID
 
+	r.restoreOnRollback(ctx, tenantID, id, byTenant[id], true)
 	byTenant[id] = p
 	r.data[tenantID] = byTenant
 
@@ -197,7 +231,8 @@ func (r *InMemoryParcelRepository) UpdateArrivedDestination(ctx context.Context,
 }
 
 func (r *InMemoryParcelRepository) UpdateInTransit(ctx context.Context, tenantID string, id uuid.UUID, departedAtUTC time.Time, departedByUserID *string, vehicleID *string) (*domain.Parcel, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelRepository.UpdateInTransit", tracing.TenantID(tenantID), tracing.ParcelID(id.String()))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -224,6 +259,7 @@ func (r *InMemoryParcelRepository) UpdateInTransit(ctx context.Context, tenantID
 		p.BoardedVehicleID = vehicleID
 	}
 
+	r.restoreOnRollback(ctx, tenantID, id, byTenant[id], true)
 	byTenant[id] = p
 	r.data[tenantID] = byTenant
 
@@ -232,7 +268,8 @@ func (r *InMemoryParcelRepository) UpdateInTransit(ctx context.Context, tenantID
 }
 
 func (r *InMemoryParcelRepository) ListByFilters(ctx context.Context, tenantID string, f port.ListParcelFilters) ([]domain.Parcel, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelRepository.ListByFilters", tracing.TenantID(tenantID))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -282,7 +319,8 @@ func (r *InMemoryParcelRepository) ListByFilters(ctx context.Context, tenantID s
 }
 
 func (r *InMemoryParcelRepository) List(ctx context.Context, tenantID string, f port.ListParcelFilters) ([]domain.Parcel, int, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelRepository.List", tracing.TenantID(tenantID))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -384,8 +422,31 @@ func (r *InMemoryParcelRepository) List(ctx context.Context, tenantID string, f
 	return paged, count, nil
 }
 
+func (r *InMemoryParcelRepository) FindByTrackingCode(ctx context.Context, code string) (*domain.Parcel, error) {
+	_, span := tracing.Start(ctx, "ParcelRepository.FindByTrackingCode")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.data == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio parcels no inicializado", nil)
+	}
+
+	for _, byParcel := range r.data {
+		for _, p := range byParcel {
+			if p.TrackingCode == code {
+				cp := p
+				return &cp, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
 func (r *InMemoryParcelRepository) ExistsTrackingCode(ctx context.Context, code string) (bool, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelRepository.ExistsTrackingCode")
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
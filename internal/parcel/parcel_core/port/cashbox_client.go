@@ -2,6 +2,31 @@ package port
 
 import "context"
 
+type CashboxMovementType string
+
+const (
+	CashboxMovementIncome CashboxMovementType = "INCOME"
+	CashboxMovementRefund CashboxMovementType = "REFUND"
+)
+
+// CashboxMovementDTO es un ingreso o egreso a registrar en la caja.
+// IdempotencyKey identifica el movimiento de forma única: reenviarlo no debe duplicarlo.
+type CashboxMovementDTO struct {
+	IdempotencyKey  string
+	CashboxID       string
+	OfficeID        string
+	Type            CashboxMovementType
+	ParcelID        string
+	Amount          float64
+	Currency        string
+	PaymentType     string
+	SellerUserID    string
+	CreatedByUserID string
+	Notes           string
+}
+
 type CashboxClient interface {
 	IsOpen(ctx context.Context, tenantID string, cashboxID string) (bool, error)
+	// RegisterMovement registra el movimiento y devuelve su ID en ms-cashbox.
+	RegisterMovement(ctx context.Context, tenantID string, in CashboxMovementDTO) (string, error)
 }
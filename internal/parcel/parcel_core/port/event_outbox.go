@@ -0,0 +1,59 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// DomainEventDTO es un evento de integración para otros servicios (notificaciones, caja, BI).
+// Data debe ser serializable a JSON y no incluir datos sensibles (p.ej. la clave del paquete).
+type DomainEventDTO struct {
+	Type          string
+	AggregateType string
+	AggregateID   string
+	OccurredAt    time.Time
+	Data          map[string]any
+}
+
+// Tipos de evento publicados. Son parte del contrato con los consumidores: no se renombran,
+// un cambio incompatible en Data se publica con otra versión del sobre.
+const (
+	DomainEventParcelCreated     = "parcel.created"
+	DomainEventParcelRegistered  = "parcel.registered"
+	DomainEventParcelBoarded     = "parcel.boarded"
+	DomainEventParcelInTransit   = "parcel.in_transit"
+	DomainEventParcelArrived     = "parcel.arrived"
+	DomainEventParcelDelivered   = "parcel.delivered"
+	DomainEventParcelItemAdded   = "parcel.item_added"
+	DomainEventParcelItemRemoved = "parcel.item_removed"
+
+	DomainEventPaymentCharged  = "payment.charged"
+	DomainEventPaymentPaid     = "payment.paid"
+	DomainEventPaymentRefunded = "payment.refunded"
+	DomainEventPaymentVoided   = "payment.voided"
+)
+
+// Tipos de agregado de los eventos.
+const (
+	AggregateParcel  = "parcel"
+	AggregatePayment = "payment"
+)
+
+// EventOutbox encola eventos para publicarlos después del commit. Debe llamarse con el ctx de la
+// unidad de trabajo que hace el cambio de estado, para que el evento se guarde en la misma transacción.
+type EventOutbox interface {
+	Enqueue(ctx context.Context, tenantID string, ev DomainEventDTO) error
+}
+
+// NopEventOutbox descarta los eventos; se usa cuando no hay outbox configurado.
+type NopEventOutbox struct{}
+
+func (NopEventOutbox) Enqueue(context.Context, string, DomainEventDTO) error { return nil }
+
+// EventOutboxOrNop devuelve o o, si es nil, NopEventOutbox.
+func EventOutboxOrNop(o EventOutbox) EventOutbox {
+	if o == nil {
+		return NopEventOutbox{}
+	}
+	return o
+}
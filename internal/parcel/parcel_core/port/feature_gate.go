@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+	"errors"
+)
+
+// Funcionalidades que se pueden deshabilitar por tenant desde TENANT-CONFIG.
+const (
+	FeatureParcelCreate     = "parcel_core.create"
+	FeatureShipmentCarguero = "parcel_core.shipment_carguero"
+	FeaturePayments         = "parcel_core.payments"
+	FeatureDocuments        = "parcel_core.documents"
+)
+
+// ErrFeatureFlagNotFound indica que el tenant no tiene definido el flag consultado.
+var ErrFeatureFlagNotFound = errors.New("feature flag no definido")
+
+// FeatureGate decide si una funcionalidad está habilitada para el tenant.
+type FeatureGate interface {
+	// Require devuelve un error feature_disabled si la funcionalidad no está habilitada.
+	Require(ctx context.Context, tenantID string, feature string) error
+}
+
+// RequireFeature aplica el gate si está configurado; sin gate todo está habilitado.
+func RequireFeature(ctx context.Context, gate FeatureGate, tenantID string, feature string) error {
+	if gate == nil {
+		return nil
+	}
+	return gate.Require(ctx, tenantID, feature)
+}
@@ -0,0 +1,15 @@
+package port
+
+import "context"
+
+type OfficeInfo struct {
+	ID   string
+	Name string
+	City string
+}
+
+// OfficeDirectory resuelve los datos públicos de una oficina (nombre y ciudad). Devuelve nil si la
+// oficina no existe o no hay directorio configurado.
+type OfficeDirectory interface {
+	GetOffice(ctx context.Context, tenantID string, officeID string) (*OfficeInfo, error)
+}
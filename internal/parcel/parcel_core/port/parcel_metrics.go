@@ -0,0 +1,30 @@
+package port
+
+// ParcelMetrics registra contadores de dominio. Las implementaciones deben mantener acotada
+// la cardinalidad de las etiquetas (tenants, tipos, códigos).
+type ParcelMetrics interface {
+	ParcelCreated(tenantID string, shipmentType string)
+	// StatusTransition cuenta una transición exitosa (register, board, depart, arrive, deliver) al estado destino.
+	StatusTransition(transition string, toStatus string)
+	// TransitionFailed cuenta un intento de transición rechazado, por código de apperror.
+	TransitionFailed(transition string, code string)
+	DocumentPrinted(documentType string, reprint bool)
+	PaymentMarkedPaid(paymentType string)
+}
+
+// NopParcelMetrics descarta todo; se usa cuando no hay métricas configuradas.
+type NopParcelMetrics struct{}
+
+func (NopParcelMetrics) ParcelCreated(string, string)    {}
+func (NopParcelMetrics) StatusTransition(string, string) {}
+func (NopParcelMetrics) TransitionFailed(string, string) {}
+func (NopParcelMetrics) DocumentPrinted(string, bool)    {}
+func (NopParcelMetrics) PaymentMarkedPaid(string)        {}
+
+// MetricsOrNop devuelve m o, si es nil, NopParcelMetrics.
+func MetricsOrNop(m ParcelMetrics) ParcelMetrics {
+	if m == nil {
+		return NopParcelMetrics{}
+	}
+	return m
+}
@@ -0,0 +1,163 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidParcelOptions indica valores de opciones que romperían cálculos o reglas de negocio.
+var ErrInvalidParcelOptions = errors.New("opciones de envío inválidas")
+
+// DefaultParcelOptions son los valores base para tenants/oficinas sin configuración propia.
+func DefaultParcelOptions() ParcelOptions {
+	return ParcelOptions{
+		RequirePackageKey:       true,
+		UsePriceTable:           true,
+		UseVolumetricWeight:     false,
+		VolumetricDivisor:       6000,
+		AllowManualPrice:        false,
+		AllowOverridePriceTable: true,
+		AllowPayInDestination:   false,
+		MaxPrints:               1,
+		AllowReprint:            false,
+		ReprintFeeEnabled:       false,
+		CashboxFailOpen:         true,
+	}
+}
+
+// Validate revisa los valores numéricos de las opciones.
+func (o ParcelOptions) Validate() error {
+	problems := make([]string, 0)
+	if o.VolumetricDivisor <= 0 {
+		problems = append(problems, "volumetric_divisor debe ser > 0")
+	}
+	if o.MaxPrints < 1 {
+		problems = append(problems, "max_prints debe ser >= 1")
+	}
+	if len(problems) > 0 {
+		return errors.Join(ErrInvalidParcelOptions, errors.New(strings.Join(problems, "; ")))
+	}
+	return nil
+}
+
+// ParcelOptionsOverride es una configuración parcial (p.ej. de una oficina): nil = hereda.
+type ParcelOptionsOverride struct {
+	RequirePackageKey       *bool `json:"require_package_key,omitempty"`
+	UsePriceTable           *bool `json:"use_price_table,omitempty"`
+	UseVolumetricWeight     *bool `json:"use_volumetric_weight,omitempty"`
+	VolumetricDivisor       *int  `json:"volumetric_divisor,omitempty"`
+	AllowManualPrice        *bool `json:"allow_manual_price,omitempty"`
+	AllowOverridePriceTable *bool `json:"allow_override_price_table,omitempty"`
+	AllowPayInDestination   *bool `json:"allow_pay_in_destination,omitempty"`
+	MaxPrints               *int  `json:"max_prints,omitempty"`
+	AllowReprint            *bool `json:"allow_reprint,omitempty"`
+	ReprintFeeEnabled       *bool `json:"reprint_fee_enabled,omitempty"`
+	CashboxFailOpen         *bool `json:"cashbox_fail_open,omitempty"`
+}
+
+// OfficeOptionsProvider obtiene los overrides de opciones de una oficina (nil = sin overrides).
+type OfficeOptionsProvider interface {
+	GetOfficeParcelOptions(ctx context.Context, tenantID string, officeID string) (*ParcelOptionsOverride, error)
+}
+
+type ParcelOptionsSource string
+
+const (
+	ParcelOptionsSourceDefault ParcelOptionsSource = "DEFAULT"
+	ParcelOptionsSourceTenant  ParcelOptionsSource = "TENANT"
+	ParcelOptionsSourceOffice  ParcelOptionsSource = "OFFICE"
+)
+
+// Motivos de fallback: códigos estables para el cliente; el error de fondo solo va al log.
+const (
+	ParcelOptionsFallbackTenantUnavailable = "tenant_options_unavailable"
+	ParcelOptionsFallbackTenantInvalid     = "tenant_options_invalid"
+	ParcelOptionsFallbackOfficeUnavailable = "office_options_unavailable"
+	ParcelOptionsFallbackOfficeInvalid     = "office_options_invalid"
+)
+
+// ResolvedParcelOptions son las opciones efectivas con el origen de cada campo
+// y los fallbacks aplicados (ParcelOptionsFallback*: configuración no disponible o inválida).
+type ResolvedParcelOptions struct {
+	TenantID  string
+	OfficeID  string
+	Options   ParcelOptions
+	Sources   map[string]ParcelOptionsSource
+	Fallbacks []string
+}
+
+// ParcelOptionsResolver resuelve las opciones efectivas: defaults <- tenant <- oficina.
+// Nunca falla: ante errores o valores inválidos usa el nivel anterior y lo registra.
+type ParcelOptionsResolver interface {
+	Resolve(ctx context.Context, tenantID string, officeID string) ParcelOptions
+	ResolveDetailed(ctx context.Context, tenantID string, officeID string) ResolvedParcelOptions
+}
+
+// ApplyTo aplica los campos definidos sobre base y devuelve el resultado y los campos aplicados.
+func (ov ParcelOptionsOverride) ApplyTo(base ParcelOptions) (ParcelOptions, []string) {
+	applied := make([]string, 0)
+	setBool := func(name string, dst *bool, v *bool) {
+		if v != nil {
+			*dst = *v
+			applied = append(applied, name)
+		}
+	}
+	setInt := func(name string, dst *int, v *int) {
+		if v != nil {
+			*dst = *v
+			applied = append(applied, name)
+		}
+	}
+	setBool("require_package_key", &base.RequirePackageKey, ov.RequirePackageKey)
+	setBool("use_price_table", &base.UsePriceTable, ov.UsePriceTable)
+	setBool("use_volumetric_weight", &base.UseVolumetricWeight, ov.UseVolumetricWeight)
+	setInt("volumetric_divisor", &base.VolumetricDivisor, ov.VolumetricDivisor)
+	setBool("allow_manual_price", &base.AllowManualPrice, ov.AllowManualPrice)
+	setBool("allow_override_price_table", &base.AllowOverridePriceTable, ov.AllowOverridePriceTable)
+	setBool("allow_pay_in_destination", &base.AllowPayInDestination, ov.AllowPayInDestination)
+	setInt("max_prints", &base.MaxPrints, ov.MaxPrints)
+	setBool("allow_reprint", &base.AllowReprint, ov.AllowReprint)
+	setBool("reprint_fee_enabled", &base.ReprintFeeEnabled, ov.ReprintFeeEnabled)
+	setBool("cashbox_fail_open", &base.CashboxFailOpen, ov.CashboxFailOpen)
+	return base, applied
+}
+
+// DiffFields devuelve los nombres (json) de los campos en que o difiere de other.
+func (o ParcelOptions) DiffFields(other ParcelOptions) []string {
+	out := make([]string, 0)
+	add := func(name string, differs bool) {
+		if differs {
+			out = append(out, name)
+		}
+	}
+	add("require_package_key", o.RequirePackageKey != other.RequirePackageKey)
+	add("use_price_table", o.UsePriceTable != other.UsePriceTable)
+	add("use_volumetric_weight", o.UseVolumetricWeight != other.UseVolumetricWeight)
+	add("volumetric_divisor", o.VolumetricDivisor != other.VolumetricDivisor)
+	add("allow_manual_price", o.AllowManualPrice != other.AllowManualPrice)
+	add("allow_override_price_table", o.AllowOverridePriceTable != other.AllowOverridePriceTable)
+	add("allow_pay_in_destination", o.AllowPayInDestination != other.AllowPayInDestination)
+	add("max_prints", o.MaxPrints != other.MaxPrints)
+	add("allow_reprint", o.AllowReprint != other.AllowReprint)
+	add("reprint_fee_enabled", o.ReprintFeeEnabled != other.ReprintFeeEnabled)
+	add("cashbox_fail_open", o.CashboxFailOpen != other.CashboxFailOpen)
+	return out
+}
+
+// ParcelOptionFieldNames lista los campos (json) de ParcelOptions en orden estable.
+func ParcelOptionFieldNames() []string {
+	return []string{
+		"require_package_key",
+		"use_price_table",
+		"use_volumetric_weight",
+		"volumetric_divisor",
+		"allow_manual_price",
+		"allow_override_price_table",
+		"allow_pay_in_destination",
+		"max_prints",
+		"allow_reprint",
+		"reprint_fee_enabled",
+		"cashbox_fail_open",
+	}
+}
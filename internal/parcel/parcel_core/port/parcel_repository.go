@@ -26,6 +26,9 @@ type ListParcelFilters struct {
 type ParcelRepository interface {
 	Create(ctx context.Context, p domain.Parcel) (uuid.UUID, error)
 	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.Parcel, error)
+	// LockByID lee el parcel y, dentro de una unidad de trabajo, lo bloquea hasta el commit: las
+	// transiciones validan el estado sobre esta lectura para que dos concurrentes no pasen ambas.
+	LockByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.Parcel, error)
 	UpdateRegistered(ctx context.Context, tenantID string, id uuid.UUID, registeredAtUTC time.Time, userID string, userName string) (*domain.Parcel, error)
 	UpdateBoarded(ctx context.Context, tenantID string, id uuid.UUID, boardedAtUTC time.Time, vehicleID string, tripID *string, departureAt *time.Time, boardedByUserID *string) (*domain.Parcel, error)
 	ListByFilters(ctx context.Context, tenantID string, f ListParcelFilters) ([]domain.Parcel, error)
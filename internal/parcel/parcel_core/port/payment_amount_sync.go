@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// PaymentAmountSync avisa al módulo de pagos que cambiaron los items del envío
+// para que recalcule el monto o lo marque desactualizado.
+type PaymentAmountSync interface {
+	ItemsChanged(ctx context.Context, tenantID string, parcelID uuid.UUID) error
+}
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ParcelPaymentBalanceDTO struct {
+	Amount     float64
+	PaidAmount float64
+	Balance    float64
+	Currency   string
+	Status     string
+}
+
+// PaymentBalanceReader expone el saldo del pago del envío al core (p.ej. para bloquear la entrega).
+// Devuelve nil si el envío no tiene pago registrado.
+type PaymentBalanceReader interface {
+	GetBalance(ctx context.Context, tenantID string, parcelID uuid.UUID) (*ParcelPaymentBalanceDTO, error)
+}
@@ -0,0 +1,9 @@
+package port
+
+import "context"
+
+// PersonDirectory consulta el documento de identidad de una persona; se usa solo para verificar al
+// destinatario en el seguimiento público. Devuelve "" si la persona no existe o no hay directorio.
+type PersonDirectory interface {
+	GetDocumentNumber(ctx context.Context, tenantID string, personID string) (string, error)
+}
@@ -13,6 +13,9 @@ type ParcelOptions struct {
 	MaxPrints               int
 	AllowReprint            bool
 	ReprintFeeEnabled       bool
+	// CashboxFailOpen permite cobrar si ms-cashbox no responde (la caja se asume abierta).
+	// Las devoluciones siempre fallan cerrado.
+	CashboxFailOpen bool
 }
 
 type TenantOptionsProvider interface {
@@ -0,0 +1,7 @@
+package port
+
+// TenantOptionsInvalidator descarta las opciones cacheadas de un tenant
+// (p.ej. al recibir un evento de cambio de configuración).
+type TenantOptionsInvalidator interface {
+	Invalidate(tenantID string)
+}
@@ -0,0 +1,9 @@
+package port
+
+import "context"
+
+// TrackingCodeChecker lo implementan los repositorios de parcels para verificar unicidad
+// global del tracking_code antes de asignarlo.
+type TrackingCodeChecker interface {
+	ExistsTrackingCode(ctx context.Context, code string) (bool, error)
+}
@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"ms-parcel-core/internal/parcel/parcel_core/domain"
+)
+
+// TrackingCodeResolver lo implementan los repositorios de parcels para ubicar un envío por su
+// tracking code sin conocer el tenant (el tracking_code es único en todos los tenants). Solo lo usa
+// el seguimiento público; devuelve nil si no existe.
+type TrackingCodeResolver interface {
+	FindByTrackingCode(ctx context.Context, code string) (*domain.Parcel, error)
+}
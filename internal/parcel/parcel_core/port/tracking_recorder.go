@@ -11,7 +11,23 @@ type TrackingEventDTO struct {
 	OccurredAt time.Time
 	UserID     string
 	UserName   string
-	Metadata   map[string]any
+	// Metadata debe respetar el schema del tipo de evento; el recorder rechaza campos desconocidos
+	Metadata map[string]any
+
+	// OfficeID y VehicleID son la oficina y el vehículo involucrados ("" si no aplica)
+	OfficeID  string
+	VehicleID string
+	// Location, Source y CorrelationID son opcionales: si faltan se toman del request
+	// (X-Device-Location, X-Event-Source, X-Request-ID) o, fuera de un request, Source es SYSTEM
+	Location      *TrackingLocation
+	Source        string
+	CorrelationID string
+}
+
+type TrackingLocation struct {
+	Latitude  float64
+	Longitude float64
+	AccuracyM *float64
 }
 
 const (
@@ -21,6 +37,11 @@ const (
 	EventTypeParcelInTransit          = "PARCEL_IN_TRANSIT"
 	EventTypeParcelArrivedDestination = "PARCEL_ARRIVED_DESTINATION"
 	EventTypeParcelDelivered          = "PARCEL_DELIVERED"
+	EventTypeParcelItemAdded          = "PARCEL_ITEM_ADDED"
+	EventTypeParcelItemRemoved        = "PARCEL_ITEM_REMOVED"
+
+	EventTypePaymentRefunded = "PAYMENT_REFUNDED"
+	EventTypePaymentVoided   = "PAYMENT_VOIDED"
 )
 
 type TrackingRecorder interface {
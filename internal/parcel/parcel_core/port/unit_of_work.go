@@ -0,0 +1,82 @@
+package port
+
+import (
+	"context"
+	"sync"
+)
+
+// UnitOfWork ejecuta fn como una sola transacción sobre todos los repositorios: los que se usen con el
+// ctx que recibe fn escriben en la misma transacción, que se confirma si fn devuelve nil y se revierte
+// ante cualquier error (o panic). Un Do anidado se suma a la transacción en curso.
+//
+// fn no debe llamar a servicios externos (caja, tenant-config): mantiene la transacción abierta y sus
+// efectos no se revierten.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// DirectUnitOfWork ejecuta fn sin transacción; cada escritura se confirma por separado.
+type DirectUnitOfWork struct{}
+
+func (DirectUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// UnitOfWorkOrDirect devuelve uow o, si es nil, DirectUnitOfWork.
+func UnitOfWorkOrDirect(uow UnitOfWork) UnitOfWork {
+	if uow == nil {
+		return DirectUnitOfWork{}
+	}
+	return uow
+}
+
+// WithinUnitOfWork ejecuta fn dentro de uow y devuelve su resultado solo si la transacción se confirmó.
+func WithinUnitOfWork[T any](ctx context.Context, uow UnitOfWork, fn func(ctx context.Context) (T, error)) (T, error) {
+	var out T
+	err := uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = fn(ctx)
+		return err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return out, nil
+}
+
+type commitHooksKey struct{}
+
+type commitHooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+// AfterCommit ejecuta fn cuando se confirme la unidad de trabajo de ctx y nunca si se revierte; fuera
+// de una unidad de trabajo corre en el momento. Es para efectos que no deben verse antes del commit
+// (p.ej. avisar a los streams de tracking) y que no pueden fallar.
+func AfterCommit(ctx context.Context, fn func()) {
+	h, ok := ctx.Value(commitHooksKey{}).(*commitHooks)
+	if !ok {
+		fn()
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fns = append(h.fns, fn)
+}
+
+// WithCommitHooks lo usan las implementaciones de UnitOfWork: devuelve el ctx que recibe fn y la
+// función que corre los AfterCommit registrados, que se llama solo después de confirmar.
+func WithCommitHooks(ctx context.Context) (context.Context, func()) {
+	h := &commitHooks{}
+	return context.WithValue(ctx, commitHooksKey{}, h), func() {
+		h.mu.Lock()
+		fns := h.fns
+		h.fns = nil
+		h.mu.Unlock()
+		for _, fn := range fns {
+			fn()
+		}
+	}
+}
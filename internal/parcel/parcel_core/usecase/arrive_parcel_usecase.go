@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_core/domain"
 	"ms-parcel-core/internal/parcel/parcel_core/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type ArriveParcelInput struct {
@@ -23,13 +26,28 @@ type ArriveParcelInput struct {
 type ArriveParcelUseCase struct {
 	repo     port.ParcelRepository
 	tracking port.TrackingRecorder
+	uow      port.UnitOfWork
+	events   port.EventOutbox
+	metrics  port.ParcelMetrics
+	logger   *slog.Logger
 }
 
-func NewArriveParcelUseCase(repo port.ParcelRepository, tracking port.TrackingRecorder) *ArriveParcelUseCase {
-	return &ArriveParcelUseCase{repo: repo, tracking: tracking}
+func NewArriveParcelUseCase(repo port.ParcelRepository, tracking port.TrackingRecorder, uow port.UnitOfWork, events port.EventOutbox, metrics port.ParcelMetrics, logger *slog.Logger) *ArriveParcelUseCase {
+	return &ArriveParcelUseCase{repo: repo, tracking: tracking, uow: port.UnitOfWorkOrDirect(uow), events: port.EventOutboxOrNop(events), metrics: port.MetricsOrNop(metrics), logger: logging.OrDiscard(logger)}
 }
 
 func (u *ArriveParcelUseCase) Execute(ctx context.Context, in ArriveParcelInput) (*domain.Parcel, error) {
+	ctx, span := tracing.StartUseCase(ctx, "ArriveParcel", tracing.TenantID(in.TenantID), tracing.ParcelID(in.ParcelID.String()))
+	defer span.End()
+	updated, err := port.WithinUnitOfWork(ctx, u.uow, func(ctx context.Context) (*domain.Parcel, error) {
+		return u.arrive(ctx, in)
+	})
+	tracing.RecordError(span, err)
+	observeTransition(u.metrics, transitionArrive, updated, err)
+	return updated, err
+}
+
+func (u *ArriveParcelUseCase) arrive(ctx context.Context, in ArriveParcelInput) (*domain.Parcel, error) {
 	if strings.TrimSpace(in.TenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
@@ -43,7 +61,7 @@ func (u *ArriveParcelUseCase) Execute(ctx context.Context, in ArriveParcelInput)
 		return nil, apperror.NewBadRequest("validation_error", "destination_office_id requerido", map[string]any{"field": "destination_office_id"})
 	}
 
-	p, err := u.repo.GetByID(ctx, in.TenantID, in.ParcelID)
+	p, err := u.repo.LockByID(ctx, in.TenantID, in.ParcelID)
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +90,11 @@ func (u *ArriveParcelUseCase) Execute(ctx context.Context, in ArriveParcelInput)
 		return nil, apperror.New("not_found", "parcel no encontrado", map[string]any{"id": in.ParcelID.String()}, 404)
 	}
 
+	md := map[string]any{
+		"destination_office_id": in.DestinationOfficeID,
+		"arrived_at":            arrivedAt.UTC().Format(time.RFC3339),
+		"arrived_by_user_id":    by,
+	}
 	if u.tracking != nil {
 		if err := u.tracking.RecordEvent(ctx, in.TenantID, port.TrackingEventDTO{
 			ParcelID:   in.ParcelID.String(),
@@ -79,15 +102,17 @@ func (u *ArriveParcelUseCase) Execute(ctx context.Context, in ArriveParcelInput)
 			OccurredAt: arrivedAt,
 			UserID:     in.UserID,
 			UserName:   in.UserName,
-			Metadata: map[string]any{
-				"destination_office_id": in.DestinationOfficeID,
-				"arrived_at":            arrivedAt.UTC().Format(time.RFC3339),
-				"arrived_by_user_id":    by,
-			},
+			Metadata:   md,
+			OfficeID:   in.DestinationOfficeID,
+			VehicleID:  stringOrEmpty(updated.BoardedVehicleID),
 		}); err != nil {
-			// TODO: logger
+			u.logger.ErrorContext(ctx, "no se pudo registrar evento de tracking, se revierte la transición", "parcel_id", in.ParcelID.String(), "event_type", port.EventTypeParcelArrivedDestination, "error", err)
+			return nil, err
 		}
 	}
+	if err := enqueueParcelEvent(ctx, u.logger, u.events, in.TenantID, port.DomainEventParcelArrived, updated, arrivedAt, in.UserID, md); err != nil {
+		return nil, err
+	}
 
 	return updated, nil
 }
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_core/domain"
 	"ms-parcel-core/internal/parcel/parcel_core/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type BoardParcelInput struct {
@@ -25,13 +28,28 @@ type BoardParcelInput struct {
 type BoardParcelUseCase struct {
 	repo     port.ParcelRepository
 	tracking port.TrackingRecorder
+	uow      port.UnitOfWork
+	events   port.EventOutbox
+	metrics  port.ParcelMetrics
+	logger   *slog.Logger
 }
 
-func NewBoardParcelUseCase(repo port.ParcelRepository, tracking port.TrackingRecorder) *BoardParcelUseCase {
-	return &BoardParcelUseCase{repo: repo, tracking: tracking}
+func NewBoardParcelUseCase(repo port.ParcelRepository, tracking port.TrackingRecorder, uow port.UnitOfWork, events port.EventOutbox, metrics port.ParcelMetrics, logger *slog.Logger) *BoardParcelUseCase {
+	return &BoardParcelUseCase{repo: repo, tracking: tracking, uow: port.UnitOfWorkOrDirect(uow), events: port.EventOutboxOrNop(events), metrics: port.MetricsOrNop(metrics), logger: logging.OrDiscard(logger)}
 }
 
 func (u *BoardParcelUseCase) Execute(ctx context.Context, in BoardParcelInput) (*domain.Parcel, error) {
+	ctx, span := tracing.StartUseCase(ctx, "BoardParcel", tracing.TenantID(in.TenantID), tracing.ParcelID(in.ParcelID.String()))
+	defer span.End()
+	updated, err := port.WithinUnitOfWork(ctx, u.uow, func(ctx context.Context) (*domain.Parcel, error) {
+		return u.board(ctx, in)
+	})
+	tracing.RecordError(span, err)
+	observeTransition(u.metrics, transitionBoard, updated, err)
+	return updated, err
+}
+
+func (u *BoardParcelUseCase) board(ctx context.Context, in BoardParcelInput) (*domain.Parcel, error) {
 	if strings.TrimSpace(in.TenantID) == "" || strings.TrimSpace(in.UserID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
@@ -42,7 +60,7 @@ func (u *BoardParcelUseCase) Execute(ctx context.Context, in BoardParcelInput) (
 		return nil, apperror.NewBadRequest("validation_error", "vehicle_id inválido", map[string]any{"field": "vehicle_id"})
 	}
 
-	p, err := u.repo.GetByID(ctx, in.TenantID, in.ParcelID)
+	p, err := u.repo.LockByID(ctx, in.TenantID, in.ParcelID)
 	if err != nil {
 		return nil, err
 	}
@@ -73,14 +91,14 @@ func (u *BoardParcelUseCase) Execute(ctx context.Context, in BoardParcelInput) (
 		return nil, apperror.New("not_found", "parcel no encontrado", map[string]any{"id": in.ParcelID.String()}, 404)
 	}
 
+	md := map[string]any{"vehicle_id": vehicleIDStr}
+	if tripIDStr != nil {
+		md["trip_id"] = *tripIDStr
+	}
+	if in.DepartureAt != nil {
+		md["departure_at"] = in.DepartureAt.UTC().Format(time.RFC3339)
+	}
 	if u.tracking != nil {
-		md := map[string]any{"vehicle_id": vehicleIDStr}
-		if tripIDStr != nil {
-			md["trip_id"] = *tripIDStr
-		}
-		if in.DepartureAt != nil {
-			md["departure_at"] = in.DepartureAt.UTC().Format(time.RFC3339)
-		}
 		if err := u.tracking.RecordEvent(ctx, in.TenantID, port.TrackingEventDTO{
 			ParcelID:   in.ParcelID.String(),
 			EventType:  port.EventTypeParcelBoarded,
@@ -88,10 +106,16 @@ func (u *BoardParcelUseCase) Execute(ctx context.Context, in BoardParcelInput) (
 			UserID:     in.UserID,
 			UserName:   in.UserName,
 			Metadata:   md,
+			OfficeID:   updated.OriginOfficeID,
+			VehicleID:  vehicleIDStr,
 		}); err != nil {
-			// TODO: logger
+			u.logger.ErrorContext(ctx, "no se pudo registrar evento de tracking, se revierte la transición", "parcel_id", in.ParcelID.String(), "event_type", port.EventTypeParcelBoarded, "error", err)
+			return nil, err
 		}
 	}
+	if err := enqueueParcelEvent(ctx, u.logger, u.events, in.TenantID, port.DomainEventParcelBoarded, updated, boardedAt, in.UserID, md); err != nil {
+		return nil, err
+	}
 
 	return updated, nil
 }
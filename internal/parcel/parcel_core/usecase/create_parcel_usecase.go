@@ -5,15 +5,17 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"log/slog"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"ms-parcel-core/internal/parcel/parcel_core/domain"
-	repository "ms-parcel-core/internal/parcel/parcel_core/infrastructure/repository"
 	"ms-parcel-core/internal/parcel/parcel_core/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type CreateParcelInput struct {
@@ -32,13 +34,17 @@ type CreateParcelInput struct {
 
 type CreateParcelUseCase struct {
 	repo            port.ParcelRepository
-	tenantConfig    port.TenantConfigClient
+	features        port.FeatureGate
 	tracking        port.TrackingRecorder
-	optionsProvider port.TenantOptionsProvider
+	uow             port.UnitOfWork
+	events          port.EventOutbox
+	optionsProvider port.ParcelOptionsResolver
+	metrics         port.ParcelMetrics
+	logger          *slog.Logger
 }
 
-func NewCreateParcelUseCase(repo port.ParcelRepository, tenantConfig port.TenantConfigClient, tracking port.TrackingRecorder, optionsProvider port.TenantOptionsProvider) *CreateParcelUseCase {
-	return &CreateParcelUseCase{repo: repo, tenantConfig: tenantConfig, tracking: tracking, optionsProvider: optionsProvider}
+func NewCreateParcelUseCase(repo port.ParcelRepository, features port.FeatureGate, tracking port.TrackingRecorder, uow port.UnitOfWork, events port.EventOutbox, optionsProvider port.ParcelOptionsResolver, metrics port.ParcelMetrics, logger *slog.Logger) *CreateParcelUseCase {
+	return &CreateParcelUseCase{repo: repo, features: features, tracking: tracking, uow: port.UnitOfWorkOrDirect(uow), events: port.EventOutboxOrNop(events), optionsProvider: optionsProvider, metrics: port.MetricsOrNop(metrics), logger: logging.OrDiscard(logger)}
 }
 
 func buildYearCode(now time.Time) string {
@@ -71,6 +77,9 @@ func randomCrockford(n int) (string, error) {
 }
 
 func (u *CreateParcelUseCase) Execute(ctx context.Context, in CreateParcelInput) (uuid.UUID, error) {
+	ctx, span := tracing.StartUseCase(ctx, "CreateParcel", tracing.TenantID(in.TenantID))
+	defer span.End()
+
 	if strings.TrimSpace(in.PackageKey) == "" || strings.TrimSpace(in.PackageKeyConfirm) == "" {
 		return uuid.Nil, apperror.NewBadRequest("validation_error", "package_key y package_key_confirm son requeridos", map[string]any{"field": "package_key"})
 	}
@@ -81,25 +90,20 @@ func (u *CreateParcelUseCase) Execute(ctx context.Context, in CreateParcelInput)
 		return uuid.Nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
 
-	// TODO: consultar flags reales desde TENANT-CONFIG si aplica para este flujo.
-	_, _ = u.tenantConfig.IsEnabled(ctx, in.TenantID, "parcel_core.create")
-
-	defaults := port.ParcelOptions{
-		RequirePackageKey:       true,
-		UsePriceTable:           true,
-		AllowManualPrice:        false,
-		AllowOverridePriceTable: true,
-		AllowPayInDestination:   false,
+	if err := port.RequireFeature(ctx, u.features, in.TenantID, port.FeatureParcelCreate); err != nil {
+		return uuid.Nil, err
 	}
-	opts := defaults
-	if u.optionsProvider != nil {
-		if o, err := u.optionsProvider.GetParcelOptions(ctx, in.TenantID); err == nil {
-			opts = o
-		} else {
-			// TODO: logger
+	if in.ShipmentType == domain.ShipmentTypeCarguero {
+		if err := port.RequireFeature(ctx, u.features, in.TenantID, port.FeatureShipmentCarguero); err != nil {
+			return uuid.Nil, err
 		}
 	}
 
+	opts := port.DefaultParcelOptions()
+	if u.optionsProvider != nil {
+		opts = u.optionsProvider.Resolve(ctx, in.TenantID, strings.TrimSpace(in.OriginOfficeID))
+	}
+
 	p := domain.Parcel{
 		ID:                  uuid.NewString(),
 		TenantID:            in.TenantID,
@@ -136,7 +140,7 @@ func (u *CreateParcelUseCase) Execute(ctx context.Context, in CreateParcelInput)
 		now := time.Now().UTC()
 		assigned := false
 
-		checker, ok := u.repo.(*repository.InMemoryParcelRepository)
+		checker, ok := u.repo.(port.TrackingCodeChecker)
 		if !ok {
 			return uuid.Nil, apperror.NewInternal("internal_error", "repositorio no soporta verificación de tracking_code", nil)
 		}
@@ -162,27 +166,41 @@ func (u *CreateParcelUseCase) Execute(ctx context.Context, in CreateParcelInput)
 		}
 	}
 
-	id, err := u.repo.Create(ctx, p)
+	id, err := port.WithinUnitOfWork(ctx, u.uow, func(ctx context.Context) (uuid.UUID, error) {
+		id, err := u.repo.Create(ctx, p)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if u.tracking != nil {
+			if err := u.tracking.RecordEvent(ctx, in.TenantID, port.TrackingEventDTO{
+				ParcelID:   id.String(),
+				EventType:  port.EventTypeParcelCreated,
+				OccurredAt: time.Now().UTC(),
+				UserID:     in.UserID,
+				UserName:   in.UserName,
+				Metadata: map[string]any{
+					"shipment_type":         string(in.ShipmentType),
+					"origin_office_id":      in.OriginOfficeID,
+					"destination_office_id": in.DestinationOfficeID,
+				},
+				OfficeID: in.OriginOfficeID,
+			}); err != nil {
+				u.logger.ErrorContext(ctx, "no se pudo registrar evento de tracking, se revierte el alta", "parcel_id", id.String(), "event_type", port.EventTypeParcelCreated, "error", err)
+				return uuid.Nil, err
+			}
+		}
+		created := p
+		created.ID = id.String()
+		if err := enqueueParcelEvent(ctx, u.logger, u.events, in.TenantID, port.DomainEventParcelCreated, &created, created.CreatedAt, in.UserID, nil); err != nil {
+			return uuid.Nil, err
+		}
+		return id, nil
+	})
 	if err != nil {
 		return uuid.Nil, err
 	}
-
-	if u.tracking != nil {
-		if err := u.tracking.RecordEvent(ctx, in.TenantID, port.TrackingEventDTO{
-			ParcelID:   id.String(),
-			EventType:  port.EventTypeParcelCreated,
-			OccurredAt: time.Now().UTC(),
-			UserID:     in.UserID,
-			UserName:   in.UserName,
-			Metadata: map[string]any{
-				"shipment_type":         string(in.ShipmentType),
-				"origin_office_id":      in.OriginOfficeID,
-				"destination_office_id": in.DestinationOfficeID,
-			},
-		}); err != nil {
-			// TODO: logger
-		}
-	}
+	span.SetAttributes(tracing.ParcelID(id.String()))
+	u.metrics.ParcelCreated(in.TenantID, string(in.ShipmentType))
 
 	return id, nil
 }
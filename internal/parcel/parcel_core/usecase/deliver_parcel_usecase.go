@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_core/domain"
 	"ms-parcel-core/internal/parcel/parcel_core/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type DeliverParcelInput struct {
@@ -25,13 +28,29 @@ type DeliverParcelInput struct {
 type DeliverParcelUseCase struct {
 	repo     port.ParcelRepository
 	tracking port.TrackingRecorder
+	payments port.PaymentBalanceReader
+	uow      port.UnitOfWork
+	events   port.EventOutbox
+	metrics  port.ParcelMetrics
+	logger   *slog.Logger
 }
 
-func NewDeliverParcelUseCase(repo port.ParcelRepository, tracking port.TrackingRecorder) *DeliverParcelUseCase {
-	return &DeliverParcelUseCase{repo: repo, tracking: tracking}
+func NewDeliverParcelUseCase(repo port.ParcelRepository, tracking port.TrackingRecorder, payments port.PaymentBalanceReader, uow port.UnitOfWork, events port.EventOutbox, metrics port.ParcelMetrics, logger *slog.Logger) *DeliverParcelUseCase {
+	return &DeliverParcelUseCase{repo: repo, tracking: tracking, payments: payments, uow: port.UnitOfWorkOrDirect(uow), events: port.EventOutboxOrNop(events), metrics: port.MetricsOrNop(metrics), logger: logging.OrDiscard(logger)}
 }
 
 func (u *DeliverParcelUseCase) Execute(ctx context.Context, in DeliverParcelInput) (*domain.Parcel, error) {
+	ctx, span := tracing.StartUseCase(ctx, "DeliverParcel", tracing.TenantID(in.TenantID), tracing.ParcelID(in.ParcelID.String()))
+	defer span.End()
+	updated, err := port.WithinUnitOfWork(ctx, u.uow, func(ctx context.Context) (*domain.Parcel, error) {
+		return u.deliver(ctx, in)
+	})
+	tracing.RecordError(span, err)
+	observeTransition(u.metrics, transitionDeliver, updated, err)
+	return updated, err
+}
+
+func (u *DeliverParcelUseCase) deliver(ctx context.Context, in DeliverParcelInput) (*domain.Parcel, error) {
 	if strings.TrimSpace(in.TenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
@@ -45,7 +64,7 @@ func (u *DeliverParcelUseCase) Execute(ctx context.Context, in DeliverParcelInpu
 		return nil, apperror.NewBadRequest("validation_error", "package_key requerido", map[string]any{"field": "package_key"})
 	}
 
-	p, err := u.repo.GetByID(ctx, in.TenantID, in.ParcelID)
+	p, err := u.repo.LockByID(ctx, in.TenantID, in.ParcelID)
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +84,23 @@ func (u *DeliverParcelUseCase) Execute(ctx context.Context, in DeliverParcelInpu
 		return nil, apperror.New("invalid_package_key", "package_key inválido", nil, 403)
 	}
 
+	// No se entrega con saldo pendiente de pago
+	if u.payments != nil {
+		bal, err := u.payments.GetBalance(ctx, in.TenantID, in.ParcelID)
+		if err != nil {
+			return nil, err
+		}
+		if bal != nil && bal.Balance > 0 {
+			return nil, apperror.New("outstanding_balance", "el envío tiene saldo pendiente de pago", map[string]any{
+				"amount":      bal.Amount,
+				"paid_amount": bal.PaidAmount,
+				"balance":     bal.Balance,
+				"currency":    bal.Currency,
+				"status":      bal.Status,
+			}, 409)
+		}
+	}
+
 	deliveredAt := time.Now().UTC()
 	by := strings.TrimSpace(in.UserID)
 	byPtr := &by
@@ -77,6 +113,10 @@ func (u *DeliverParcelUseCase) Execute(ctx context.Context, in DeliverParcelInpu
 		return nil, apperror.New("not_found", "parcel no encontrado", map[string]any{"id": in.ParcelID.String()}, 404)
 	}
 
+	md := map[string]any{
+		"delivered_at":         deliveredAt.UTC().Format(time.RFC3339),
+		"delivered_by_user_id": by,
+	}
 	if u.tracking != nil {
 		if err := u.tracking.RecordEvent(ctx, in.TenantID, port.TrackingEventDTO{
 			ParcelID:   in.ParcelID.String(),
@@ -84,14 +124,16 @@ func (u *DeliverParcelUseCase) Execute(ctx context.Context, in DeliverParcelInpu
 			OccurredAt: deliveredAt,
 			UserID:     in.UserID,
 			UserName:   in.UserName,
-			Metadata: map[string]any{
-				"delivered_at":         deliveredAt.UTC().Format(time.RFC3339),
-				"delivered_by_user_id": by,
-			},
+			Metadata:   md,
+			OfficeID:   updated.DestinationOfficeID,
 		}); err != nil {
-			// TODO: logger
+			u.logger.ErrorContext(ctx, "no se pudo registrar evento de tracking, se revierte la transición", "parcel_id", in.ParcelID.String(), "event_type", port.EventTypeParcelDelivered, "error", err)
+			return nil, err
 		}
 	}
+	if err := enqueueParcelEvent(ctx, u.logger, u.events, in.TenantID, port.DomainEventParcelDelivered, updated, deliveredAt, in.UserID, md); err != nil {
+		return nil, err
+	}
 
 	return updated, nil
 }
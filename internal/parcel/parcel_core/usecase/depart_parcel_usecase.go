@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_core/domain"
 	"ms-parcel-core/internal/parcel/parcel_core/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type DepartParcelInput struct {
@@ -25,13 +28,28 @@ type DepartParcelInput struct {
 type DepartParcelUseCase struct {
 	repo     port.ParcelRepository
 	tracking port.TrackingRecorder
+	uow      port.UnitOfWork
+	events   port.EventOutbox
+	metrics  port.ParcelMetrics
+	logger   *slog.Logger
 }
 
-func NewDepartParcelUseCase(repo port.ParcelRepository, tracking port.TrackingRecorder) *DepartParcelUseCase {
-	return &DepartParcelUseCase{repo: repo, tracking: tracking}
+func NewDepartParcelUseCase(repo port.ParcelRepository, tracking port.TrackingRecorder, uow port.UnitOfWork, events port.EventOutbox, metrics port.ParcelMetrics, logger *slog.Logger) *DepartParcelUseCase {
+	return &DepartParcelUseCase{repo: repo, tracking: tracking, uow: port.UnitOfWorkOrDirect(uow), events: port.EventOutboxOrNop(events), metrics: port.MetricsOrNop(metrics), logger: logging.OrDiscard(logger)}
 }
 
 func (u *DepartParcelUseCase) Execute(ctx context.Context, in DepartParcelInput) (*domain.Parcel, error) {
+	ctx, span := tracing.StartUseCase(ctx, "DepartParcel", tracing.TenantID(in.TenantID), tracing.ParcelID(in.ParcelID.String()))
+	defer span.End()
+	updated, err := port.WithinUnitOfWork(ctx, u.uow, func(ctx context.Context) (*domain.Parcel, error) {
+		return u.depart(ctx, in)
+	})
+	tracing.RecordError(span, err)
+	observeTransition(u.metrics, transitionDepart, updated, err)
+	return updated, err
+}
+
+func (u *DepartParcelUseCase) depart(ctx context.Context, in DepartParcelInput) (*domain.Parcel, error) {
 	if strings.TrimSpace(in.TenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
@@ -45,7 +63,7 @@ func (u *DepartParcelUseCase) Execute(ctx context.Context, in DepartParcelInput)
 		return nil, apperror.NewBadRequest("validation_error", "departure_office_id requerido", map[string]any{"field": "departure_office_id"})
 	}
 
-	p, err := u.repo.GetByID(ctx, in.TenantID, in.ParcelID)
+	p, err := u.repo.LockByID(ctx, in.TenantID, in.ParcelID)
 	if err != nil {
 		return nil, err
 	}
@@ -89,16 +107,15 @@ func (u *DepartParcelUseCase) Execute(ctx context.Context, in DepartParcelInput)
 		return nil, apperror.New("not_found", "parcel no encontrado", map[string]any{"id": in.ParcelID.String()}, 404)
 	}
 
+	md := map[string]any{
+		"departure_office_id": in.DepartureOfficeID,
+		"departed_at":         departedAt.UTC().Format(time.RFC3339),
+		"departed_by_user_id": by,
+	}
+	if vehicleIDStr != nil {
+		md["vehicle_id"] = *vehicleIDStr
+	}
 	if u.tracking != nil {
-		md := map[string]any{
-			"departure_office_id": in.DepartureOfficeID,
-			"departed_at":         departedAt.UTC().Format(time.RFC3339),
-			"departed_by_user_id": by,
-		}
-		if vehicleIDStr != nil {
-			md["vehicle_id"] = *vehicleIDStr
-		}
-
 		if err := u.tracking.RecordEvent(ctx, in.TenantID, port.TrackingEventDTO{
 			ParcelID:   in.ParcelID.String(),
 			EventType:  port.EventTypeParcelInTransit,
@@ -106,10 +123,24 @@ func (u *DepartParcelUseCase) Execute(ctx context.Context, in DepartParcelInput)
 			UserID:     in.UserID,
 			UserName:   in.UserName,
 			Metadata:   md,
+			OfficeID:   in.DepartureOfficeID,
+			VehicleID:  stringOrEmpty(updated.BoardedVehicleID),
 		}); err != nil {
-			// TODO: logger
+			u.logger.ErrorContext(ctx, "no se pudo registrar evento de tracking, se revierte la transición", "parcel_id", in.ParcelID.String(), "event_type", port.EventTypeParcelInTransit, "error", err)
+			return nil, err
 		}
 	}
+	if err := enqueueParcelEvent(ctx, u.logger, u.events, in.TenantID, port.DomainEventParcelInTransit, updated, departedAt, in.UserID, md); err != nil {
+		return nil, err
+	}
 
 	return updated, nil
 }
+
+// stringOrEmpty desreferencia campos opcionales del parcel para el evento de tracking.
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"ms-parcel-core/internal/parcel/parcel_core/domain"
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+)
+
+// enqueueParcelEvent encola el evento de integración de un cambio de estado del envío. Se llama dentro
+// de la unidad de trabajo: si falla, el cambio se revierte igual que cuando falla el tracking.
+// extra agrega datos propios del evento (vehículo, oficina, etc.) a los del envío.
+func enqueueParcelEvent(ctx context.Context, logger *slog.Logger, events port.EventOutbox, tenantID string, eventType string, p *domain.Parcel, occurredAt time.Time, userID string, extra map[string]any) error {
+	data := map[string]any{
+		"parcel_id":             p.ID,
+		"tracking_code":         p.TrackingCode,
+		"status":                string(p.Status),
+		"shipment_type":         string(p.ShipmentType),
+		"origin_office_id":      p.OriginOfficeID,
+		"destination_office_id": p.DestinationOfficeID,
+		"sender_person_id":      p.SenderPersonID,
+		"recipient_person_id":   p.RecipientPersonID,
+		"user_id":               userID,
+	}
+	for k, v := range extra {
+		data[k] = v
+	}
+	if err := events.Enqueue(ctx, tenantID, port.DomainEventDTO{
+		Type:          eventType,
+		AggregateType: port.AggregateParcel,
+		AggregateID:   p.ID,
+		OccurredAt:    occurredAt,
+		Data:          data,
+	}); err != nil {
+		logger.ErrorContext(ctx, "no se pudo encolar evento de integración, se revierte el cambio", "parcel_id", p.ID, "event_type", eventType, "error", err)
+		return err
+	}
+	return nil
+}
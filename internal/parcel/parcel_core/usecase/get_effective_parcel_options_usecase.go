@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type GetEffectiveParcelOptionsInput struct {
+	TenantID string
+	// OfficeID opcional: sin oficina se devuelven las opciones a nivel tenant
+	OfficeID string
+}
+
+type GetEffectiveParcelOptionsUseCase struct {
+	resolver port.ParcelOptionsResolver
+}
+
+func NewGetEffectiveParcelOptionsUseCase(resolver port.ParcelOptionsResolver) *GetEffectiveParcelOptionsUseCase {
+	return &GetEffectiveParcelOptionsUseCase{resolver: resolver}
+}
+
+func (u *GetEffectiveParcelOptionsUseCase) Execute(ctx context.Context, in GetEffectiveParcelOptionsInput) (*port.ResolvedParcelOptions, error) {
+	ctx, span := tracing.StartUseCase(ctx, "GetEffectiveParcelOptions", tracing.TenantID(in.TenantID))
+	defer span.End()
+
+	if strings.TrimSpace(in.TenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	officeID := strings.TrimSpace(in.OfficeID)
+	if officeID != "" {
+		if _, err := uuid.Parse(officeID); err != nil {
+			return nil, apperror.NewBadRequest("validation_error", "office_id inválido", map[string]any{"field": "office_id"})
+		}
+	}
+
+	res := u.resolver.ResolveDetailed(ctx, in.TenantID, officeID)
+	return &res, nil
+}
@@ -14,6 +14,7 @@ import (
 	trackingdomain "ms-parcel-core/internal/parcel/parcel_tracking/domain"
 	trackingport "ms-parcel-core/internal/parcel/parcel_tracking/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 const DefaultTrackingLimit = 20
@@ -37,6 +38,9 @@ func NewGetParcelSummaryUseCase(parcelRepo coreport.ParcelReader, itemRepo itemp
 }
 
 func (u *GetParcelSummaryUseCase) Execute(ctx context.Context, tenantID string, parcelID uuid.UUID) (*GetParcelSummaryResult, error) {
+	ctx, span := tracing.StartUseCase(ctx, "GetParcelSummary", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
 	if strings.TrimSpace(tenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
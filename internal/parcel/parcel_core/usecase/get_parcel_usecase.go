@@ -9,6 +9,7 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_core/domain"
 	"ms-parcel-core/internal/parcel/parcel_core/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type GetParcelInput struct {
@@ -25,6 +26,9 @@ func NewGetParcelUseCase(repo port.ParcelRepository) *GetParcelUseCase {
 }
 
 func (u *GetParcelUseCase) Execute(ctx context.Context, in GetParcelInput) (*domain.Parcel, error) {
+	ctx, span := tracing.StartUseCase(ctx, "GetParcel", tracing.TenantID(in.TenantID), tracing.ParcelID(in.ParcelID.String()))
+	defer span.End()
+
 	if strings.TrimSpace(in.TenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"log/slog"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/parcel/parcel_core/domain"
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	itemport "ms-parcel-core/internal/parcel/parcel_item/port"
+	trackingdomain "ms-parcel-core/internal/parcel/parcel_tracking/domain"
+	trackingport "ms-parcel-core/internal/parcel/parcel_tracking/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/ratelimit"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+// publicTrackingCodeRe: "QB" + letra de año + 5 caracteres del alfabeto de randomCrockford.
+var publicTrackingCodeRe = regexp.MustCompile(`^QB[A-Z][A-HJKMNP-TV-Z2-9]{5}$`)
+
+var documentLast4Re = regexp.MustCompile(`^[0-9]{4}$`)
+
+// publicTimelineEvents son los únicos eventos que ve el público; los de items y pagos son internos.
+var publicTimelineEvents = map[string]bool{
+	coreport.EventTypeParcelCreated:            true,
+	coreport.EventTypeParcelRegistered:         true,
+	coreport.EventTypeParcelBoarded:            true,
+	coreport.EventTypeParcelInTransit:          true,
+	coreport.EventTypeParcelArrivedDestination: true,
+	coreport.EventTypeParcelDelivered:          true,
+}
+
+type GetPublicTrackingInput struct {
+	TrackingCode string
+	// DocumentLast4 es el segundo factor opcional: últimos 4 dígitos del documento del destinatario.
+	DocumentLast4 string
+}
+
+type PublicTrackingOffice struct {
+	Name string
+	City string
+}
+
+type PublicTrackingEvent struct {
+	EventType  string
+	OccurredAt time.Time
+	Office     *PublicTrackingOffice
+}
+
+type PublicTrackingBalance struct {
+	Currency string
+	Balance  float64
+	Status   string
+}
+
+// PublicTrackingDetail solo se completa cuando el destinatario se verificó.
+type PublicTrackingDetail struct {
+	ShipmentType  string
+	DepartureAt   *time.Time
+	DeliveredAt   *time.Time
+	ItemsCount    int
+	TotalWeightKg float64
+	Payment       *PublicTrackingBalance
+}
+
+// GetPublicTrackingResult es la vista redactada del envío: sin ids internos, usuarios, tenant ni
+// metadata de tracking.
+type GetPublicTrackingResult struct {
+	TrackingCode string
+	Status       string
+	Origin       *PublicTrackingOffice
+	Destination  *PublicTrackingOffice
+	CreatedAt    time.Time
+	Timeline     []PublicTrackingEvent
+	Verified     bool
+	Detail       *PublicTrackingDetail
+}
+
+type GetPublicTrackingUseCase struct {
+	parcels        coreport.ParcelReader
+	trackingRepo   trackingport.TrackingRepository
+	itemRepo       itemport.ParcelItemRepository
+	balances       coreport.PaymentBalanceReader
+	offices        coreport.OfficeDirectory
+	persons        coreport.PersonDirectory
+	verifyFailures *ratelimit.Limiter
+	logger         *slog.Logger
+}
+
+// NewGetPublicTrackingUseCase: parcels debe implementar coreport.TrackingCodeResolver. verifyFailures
+// cuenta los intentos fallidos de verificación por tracking code; al agotarse se bloquea la
+// verificación de ese envío hasta que venza la ventana.
+func NewGetPublicTrackingUseCase(parcels coreport.ParcelReader, trackingRepo trackingport.TrackingRepository, itemRepo itemport.ParcelItemRepository, balances coreport.PaymentBalanceReader, offices coreport.OfficeDirectory, persons coreport.PersonDirectory, verifyFailures *ratelimit.Limiter, logger *slog.Logger) *GetPublicTrackingUseCase {
+	return &GetPublicTrackingUseCase{parcels: parcels, trackingRepo: trackingRepo, itemRepo: itemRepo, balances: balances, offices: offices, persons: persons, verifyFailures: verifyFailures, logger: logging.OrDiscard(logger)}
+}
+
+// NormalizeTrackingCode pasa a mayúsculas y quita espacios y guiones ("qb-a 12345" -> "QBA12345").
+func NormalizeTrackingCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
+
+func (u *GetPublicTrackingUseCase) Execute(ctx context.Context, in GetPublicTrackingInput) (*GetPublicTrackingResult, error) {
+	ctx, span := tracing.StartUseCase(ctx, "GetPublicTracking")
+	defer span.End()
+
+	code := NormalizeTrackingCode(in.TrackingCode)
+	last4 := strings.TrimSpace(in.DocumentLast4)
+	if last4 != "" && !documentLast4Re.MatchString(last4) {
+		return nil, apperror.NewBadRequest("validation_error", "document_last4 debe tener 4 dígitos", map[string]any{"field": "document_last4"})
+	}
+
+	// Formato inválido y código inexistente responden igual para no dar pistas al enumerar.
+	notFound := apperror.New("not_found", "envío no encontrado", nil, 404)
+	if !publicTrackingCodeRe.MatchString(code) {
+		return nil, notFound
+	}
+
+	resolver, ok := u.parcels.(coreport.TrackingCodeResolver)
+	if !ok {
+		return nil, apperror.NewInternal("internal_error", "repositorio no soporta búsqueda por tracking_code", nil)
+	}
+	p, err := resolver.FindByTrackingCode(ctx, code)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if p == nil {
+		return nil, notFound
+	}
+	span.SetAttributes(tracing.TenantID(p.TenantID), tracing.ParcelID(p.ID))
+
+	verified := false
+	if last4 != "" {
+		if err := u.verifyRecipient(ctx, p, code, last4); err != nil {
+			return nil, err
+		}
+		verified = true
+	}
+
+	events, err := u.trackingRepo.ListByParcelID(ctx, p.TenantID, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	offices := map[string]*PublicTrackingOffice{}
+	office := func(id string) *PublicTrackingOffice {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			return nil
+		}
+		if o, ok := offices[id]; ok {
+			return o
+		}
+		o := u.lookupOffice(ctx, p.TenantID, id)
+		offices[id] = o
+		return o
+	}
+
+	timeline := make([]PublicTrackingEvent, 0, len(events))
+	for _, e := range events {
+		if !publicTimelineEvents[e.EventType] {
+			continue
+		}
+		timeline = append(timeline, PublicTrackingEvent{
+			EventType:  e.EventType,
+			OccurredAt: e.OccurredAt.UTC(),
+			Office:     office(publicEventOfficeID(p, e)),
+		})
+	}
+
+	out := &GetPublicTrackingResult{
+		TrackingCode: p.TrackingCode,
+		Status:       string(p.Status),
+		Origin:       office(p.OriginOfficeID),
+		Destination:  office(p.DestinationOfficeID),
+		CreatedAt:    p.CreatedAt.UTC(),
+		Timeline:     timeline,
+		Verified:     verified,
+	}
+	if verified {
+		detail, err := u.detail(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		out.Detail = detail
+	}
+	return out, nil
+}
+
+// verifyRecipient compara en tiempo constante los últimos 4 dígitos del documento del destinatario.
+// Cada fallo (incluido un destinatario sin documento) consume un intento del tracking code.
+func (u *GetPublicTrackingUseCase) verifyRecipient(ctx context.Context, p *domain.Parcel, code string, last4 string) error {
+	if exceeded, retryAfter := u.verifyFailures.Exceeded(code); exceeded {
+		return apperror.New("too_many_attempts", "demasiados intentos de verificación, intente más tarde", map[string]any{"retry_after_seconds": int(math.Ceil(retryAfter.Seconds()))}, 429)
+	}
+
+	doc, err := u.persons.GetDocumentNumber(ctx, p.TenantID, p.RecipientPersonID)
+	if err != nil {
+		u.logger.WarnContext(ctx, "no se pudo consultar el documento del destinatario", "tenant_id", p.TenantID, "parcel_id", p.ID, "error", err)
+		return apperror.New("verification_unavailable", "no se pudo verificar al destinatario, intente más tarde", nil, 503)
+	}
+
+	digits := onlyDigits(doc)
+	if len(digits) < 4 || subtle.ConstantTimeCompare([]byte(digits[len(digits)-4:]), []byte(last4)) != 1 {
+		u.verifyFailures.Allow(code)
+		return apperror.New("verification_failed", "no se pudo verificar al destinatario", nil, 403)
+	}
+	return nil
+}
+
+func (u *GetPublicTrackingUseCase) detail(ctx context.Context, p *domain.Parcel) (*PublicTrackingDetail, error) {
+	d := &PublicTrackingDetail{
+		ShipmentType: string(p.ShipmentType),
+		DepartureAt:  p.BoardedDepartureAt,
+		DeliveredAt:  p.DeliveredAt,
+	}
+
+	parcelID, err := uuid.Parse(p.ID)
+	if err != nil {
+		return d, nil
+	}
+
+	items, err := u.itemRepo.ListByParcelID(ctx, p.TenantID, parcelID)
+	if err != nil {
+		return nil, err
+	}
+	d.ItemsCount = len(items)
+	for _, it := range items {
+		d.TotalWeightKg += it.WeightKg
+	}
+
+	if u.balances != nil {
+		bal, err := u.balances.GetBalance(ctx, p.TenantID, parcelID)
+		if err != nil {
+			return nil, err
+		}
+		if bal != nil {
+			d.Payment = &PublicTrackingBalance{Currency: bal.Currency, Balance: bal.Balance, Status: bal.Status}
+		}
+	}
+	return d, nil
+}
+
+// lookupOffice degrada a nil si el directorio falla: la vista pública no debe caerse por eso.
+func (u *GetPublicTrackingUseCase) lookupOffice(ctx context.Context, tenantID string, officeID string) *PublicTrackingOffice {
+	if u.offices == nil {
+		return nil
+	}
+	info, err := u.offices.GetOffice(ctx, tenantID, officeID)
+	if err != nil {
+		u.logger.WarnContext(ctx, "no se pudo resolver la oficina para el seguimiento público", "tenant_id", tenantID, "office_id", officeID, "error", err)
+		return nil
+	}
+	if info == nil {
+		return nil
+	}
+	return &PublicTrackingOffice{Name: info.Name, City: info.City}
+}
+
+// publicEventOfficeID ubica cada evento en su oficina; si no la tiene, la de origen hasta la salida y
+// la de destino desde la llegada.
+func publicEventOfficeID(p *domain.Parcel, e trackingdomain.TrackingEvent) string {
+	if e.OfficeID != nil && strings.TrimSpace(*e.OfficeID) != "" {
+		return *e.OfficeID
+	}
+	switch e.EventType {
+	case coreport.EventTypeParcelArrivedDestination, coreport.EventTypeParcelDelivered:
+		return p.DestinationOfficeID
+	default:
+		return p.OriginOfficeID
+	}
+}
+
+func onlyDigits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -7,6 +7,7 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_core/domain"
 	"ms-parcel-core/internal/parcel/parcel_core/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type ListParcelsInput struct {
@@ -28,6 +29,9 @@ func NewListParcelsUseCase(repo port.ParcelRepository) *ListParcelsUseCase {
 }
 
 func (u *ListParcelsUseCase) Execute(ctx context.Context, in ListParcelsInput) (*ListParcelsOutput, error) {
+	ctx, span := tracing.StartUseCase(ctx, "ListParcels", tracing.TenantID(in.TenantID))
+	defer span.End()
+
 	if strings.TrimSpace(in.TenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
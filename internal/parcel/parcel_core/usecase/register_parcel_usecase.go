@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_core/domain"
 	"ms-parcel-core/internal/parcel/parcel_core/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type RegisterParcelInput struct {
@@ -22,13 +25,28 @@ type RegisterParcelInput struct {
 type RegisterParcelUseCase struct {
 	repo     port.ParcelRepository
 	tracking port.TrackingRecorder
+	uow      port.UnitOfWork
+	events   port.EventOutbox
+	metrics  port.ParcelMetrics
+	logger   *slog.Logger
 }
 
-func NewRegisterParcelUseCase(repo port.ParcelRepository, tracking port.TrackingRecorder) *RegisterParcelUseCase {
-	return &RegisterParcelUseCase{repo: repo, tracking: tracking}
+func NewRegisterParcelUseCase(repo port.ParcelRepository, tracking port.TrackingRecorder, uow port.UnitOfWork, events port.EventOutbox, metrics port.ParcelMetrics, logger *slog.Logger) *RegisterParcelUseCase {
+	return &RegisterParcelUseCase{repo: repo, tracking: tracking, uow: port.UnitOfWorkOrDirect(uow), events: port.EventOutboxOrNop(events), metrics: port.MetricsOrNop(metrics), logger: logging.OrDiscard(logger)}
 }
 
 func (u *RegisterParcelUseCase) Execute(ctx context.Context, in RegisterParcelInput) (*domain.Parcel, error) {
+	ctx, span := tracing.StartUseCase(ctx, "RegisterParcel", tracing.TenantID(in.TenantID), tracing.ParcelID(in.ParcelID.String()))
+	defer span.End()
+	updated, err := port.WithinUnitOfWork(ctx, u.uow, func(ctx context.Context) (*domain.Parcel, error) {
+		return u.register(ctx, in)
+	})
+	tracing.RecordError(span, err)
+	observeTransition(u.metrics, transitionRegister, updated, err)
+	return updated, err
+}
+
+func (u *RegisterParcelUseCase) register(ctx context.Context, in RegisterParcelInput) (*domain.Parcel, error) {
 	if strings.TrimSpace(in.TenantID) == "" || strings.TrimSpace(in.UserID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
@@ -36,7 +54,7 @@ func (u *RegisterParcelUseCase) Execute(ctx context.Context, in RegisterParcelIn
 		return nil, apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"})
 	}
 
-	p, err := u.repo.GetByID(ctx, in.TenantID, in.ParcelID)
+	p, err := u.repo.LockByID(ctx, in.TenantID, in.ParcelID)
 	if err != nil {
 		return nil, err
 	}
@@ -64,10 +82,15 @@ func (u *RegisterParcelUseCase) Execute(ctx context.Context, in RegisterParcelIn
 			UserID:     in.UserID,
 			UserName:   in.UserName,
 			Metadata:   map[string]any{},
+			OfficeID:   updated.OriginOfficeID,
 		}); err != nil {
-			// TODO: logger
+			u.logger.ErrorContext(ctx, "no se pudo registrar evento de tracking, se revierte la transición", "parcel_id", in.ParcelID.String(), "event_type", port.EventTypeParcelRegistered, "error", err)
+			return nil, err
 		}
 	}
+	if err := enqueueParcelEvent(ctx, u.logger, u.events, in.TenantID, port.DomainEventParcelRegistered, updated, registeredAt, in.UserID, nil); err != nil {
+		return nil, err
+	}
 
 	return updated, nil
 }
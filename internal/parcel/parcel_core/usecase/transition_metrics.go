@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"ms-parcel-core/internal/parcel/parcel_core/domain"
+	"ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+)
+
+// Nombres de transición usados como etiqueta en métricas.
+const (
+	transitionRegister = "register"
+	transitionBoard    = "board"
+	transitionDepart   = "depart"
+	transitionArrive   = "arrive"
+	transitionDeliver  = "deliver"
+)
+
+func observeTransition(m port.ParcelMetrics, transition string, updated *domain.Parcel, err error) {
+	if err != nil {
+		m.TransitionFailed(transition, apperror.CodeOf(err))
+		return
+	}
+	if updated != nil {
+		m.StatusTransition(transition, string(updated.Status))
+	}
+}
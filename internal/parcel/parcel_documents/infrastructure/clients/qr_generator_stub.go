@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"ms-parcel-core/internal/parcel/parcel_documents/port"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type StubQRGenerator struct{}
@@ -15,8 +16,8 @@ func NewStubQRGenerator() *StubQRGenerator {
 var _ port.QRGenerator = (*StubQRGenerator)(nil)
 
 func (s *StubQRGenerator) Generate(ctx context.Context, payload port.QRPayload) ([]byte, error) {
-	_ = ctx
-	_ = payload
-	// TODO: llamar ms-qr-generator por HTTP y retornar bytes
+	_, span := tracing.Start(ctx, "QRGenerator.Generate", tracing.TenantID(payload.TenantID), tracing.ParcelID(payload.ParcelID))
+	defer span.End()
+	// TODO: llamar ms-qr-generator por HTTP (con setServiceHeaders/otelhttp para propagar la traza) y retornar bytes
 	return []byte("stub-qr"), nil
 }
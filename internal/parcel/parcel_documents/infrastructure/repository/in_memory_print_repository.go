@@ -9,6 +9,7 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_documents/domain"
 	"ms-parcel-core/internal/parcel/parcel_documents/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type InMemoryPrintRepository struct {
@@ -23,7 +24,8 @@ func NewInMemoryPrintRepository() *InMemoryPrintRepository {
 }
 
 func (r *InMemoryPrintRepository) Add(ctx context.Context, tenantID string, rec domain.PrintRecord) (*domain.PrintRecord, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "PrintRepository.Add", tracing.TenantID(tenantID))
+	defer span.End()
 
 	parcelID, err := uuid.Parse(rec.ParcelID)
 	if err != nil {
@@ -48,7 +50,8 @@ func (r *InMemoryPrintRepository) Add(ctx context.Context, tenantID string, rec
 }
 
 func (r *InMemoryPrintRepository) CountByParcelAndType(ctx context.Context, tenantID string, parcelID uuid.UUID, docType domain.DocumentType) (int, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "PrintRepository.CountByParcelAndType", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -76,7 +79,8 @@ func (r *InMemoryPrintRepository) CountByParcelAndType(ctx context.Context, tena
 }
 
 func (r *InMemoryPrintRepository) ListByParcel(ctx context.Context, tenantID string, parcelID uuid.UUID) ([]domain.PrintRecord, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "PrintRepository.ListByParcel", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
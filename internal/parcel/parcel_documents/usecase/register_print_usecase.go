@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 	docdomain "ms-parcel-core/internal/parcel/parcel_documents/domain"
 	docport "ms-parcel-core/internal/parcel/parcel_documents/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type RegisterPrintInput struct {
@@ -18,6 +21,8 @@ type RegisterPrintInput struct {
 	ParcelID uuid.UUID
 	DocType  docdomain.DocumentType
 	UserID   *string
+	// OfficeID: oficina que imprime, la de origen o la de destino del envío; si no se indica se usa la de origen
+	OfficeID *string
 }
 
 type RegisterPrintMeta struct {
@@ -35,18 +40,27 @@ type RegisterPrintResult struct {
 type RegisterPrintUseCase struct {
 	parcelRepo coreport.ParcelReader
 	printRepo  docport.PrintRepository
-	opts       coreport.TenantOptionsProvider
+	opts       coreport.ParcelOptionsResolver
 	qrGen      docport.QRGenerator
+	features   coreport.FeatureGate
+	metrics    coreport.ParcelMetrics
+	logger     *slog.Logger
 }
 
-func NewRegisterPrintUseCase(parcelRepo coreport.ParcelReader, printRepo docport.PrintRepository, opts coreport.TenantOptionsProvider, qrGen docport.QRGenerator) *RegisterPrintUseCase {
-	return &RegisterPrintUseCase{parcelRepo: parcelRepo, printRepo: printRepo, opts: opts, qrGen: qrGen}
+func NewRegisterPrintUseCase(parcelRepo coreport.ParcelReader, printRepo docport.PrintRepository, opts coreport.ParcelOptionsResolver, qrGen docport.QRGenerator, features coreport.FeatureGate, metrics coreport.ParcelMetrics, logger *slog.Logger) *RegisterPrintUseCase {
+	return &RegisterPrintUseCase{parcelRepo: parcelRepo, printRepo: printRepo, opts: opts, qrGen: qrGen, features: features, metrics: coreport.MetricsOrNop(metrics), logger: logging.OrDiscard(logger)}
 }
 
 func (u *RegisterPrintUseCase) Execute(ctx context.Context, in RegisterPrintInput) (*RegisterPrintResult, error) {
+	ctx, span := tracing.StartUseCase(ctx, "RegisterPrint", tracing.TenantID(in.TenantID), tracing.ParcelID(in.ParcelID.String()))
+	defer span.End()
+
 	if strings.TrimSpace(in.TenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
+	if err := coreport.RequireFeature(ctx, u.features, in.TenantID, coreport.FeatureDocuments); err != nil {
+		return nil, err
+	}
 	if in.ParcelID == uuid.Nil {
 		return nil, apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"})
 	}
@@ -77,27 +91,23 @@ func (u *RegisterPrintUseCase) Execute(ctx context.Context, in RegisterPrintInpu
 			TrackingCode: trackingCode,
 		}
 		if _, err := u.qrGen.Generate(ctx, payload); err != nil {
-			// TODO: logger
+			u.logger.WarnContext(ctx, "no se pudo generar el QR de la etiqueta", "parcel_id", in.ParcelID.String(), "error", err)
 		}
 	}
 
-	defaults := coreport.ParcelOptions{
-		RequirePackageKey:       true,
-		UsePriceTable:           true,
-		AllowManualPrice:        false,
-		AllowOverridePriceTable: true,
-		AllowPayInDestination:   false,
-		MaxPrints:               1,
-		AllowReprint:            false,
-		ReprintFeeEnabled:       false,
+	// La oficina define MaxPrints/AllowReprint: solo puede imprimir una oficina del recorrido del envío,
+	// si no cualquiera podría elegir la configuración más permisiva.
+	printingOfficeID := p.OriginOfficeID
+	if in.OfficeID != nil && strings.TrimSpace(*in.OfficeID) != "" {
+		officeID := strings.TrimSpace(*in.OfficeID)
+		if !strings.EqualFold(officeID, p.OriginOfficeID) && !strings.EqualFold(officeID, p.DestinationOfficeID) {
+			return nil, apperror.NewBadRequest("validation_error", "office_id debe ser la oficina de origen o de destino del envío", map[string]any{"field": "office_id"})
+		}
+		printingOfficeID = officeID
 	}
-	opts := defaults
+	opts := coreport.DefaultParcelOptions()
 	if u.opts != nil {
-		if o, err := u.opts.GetParcelOptions(ctx, in.TenantID); err == nil {
-			opts = o
-		} else {
-			// TODO: logger
-		}
+		opts = u.opts.Resolve(ctx, in.TenantID, printingOfficeID)
 	}
 	if opts.MaxPrints <= 0 {
 		opts.MaxPrints = 1
@@ -132,6 +142,7 @@ func (u *RegisterPrintUseCase) Execute(ctx context.Context, in RegisterPrintInpu
 	}
 
 	countAfter := current + 1
+	u.metrics.DocumentPrinted(string(in.DocType), isReprint)
 
 	return &RegisterPrintResult{
 		Record: saved,
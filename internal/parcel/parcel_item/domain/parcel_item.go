@@ -18,3 +18,9 @@ type ParcelItem struct {
 	Notes            *string
 	CreatedAt        time.Time
 }
+
+// LineTotal es el importe de la línea. UnitPrice ya guarda el precio de la línea completa
+// (la tabla de precios lo calcula por cantidad o peso facturable).
+func (i ParcelItem) LineTotal() float64 {
+	return i.UnitPrice
+}
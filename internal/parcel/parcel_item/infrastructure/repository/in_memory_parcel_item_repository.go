@@ -6,9 +6,11 @@ import (
 
 	"github.com/google/uuid"
 
+	"ms-parcel-core/internal/infrastructure/persistence/memory"
 	"ms-parcel-core/internal/parcel/parcel_item/domain"
 	"ms-parcel-core/internal/parcel/parcel_item/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type InMemoryParcelItemRepository struct {
@@ -23,7 +25,8 @@ func NewInMemoryParcelItemRepository() *InMemoryParcelItemRepository {
 }
 
 func (r *InMemoryParcelItemRepository) Add(ctx context.Context, tenantID string, item domain.ParcelItem) (uuid.UUID, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelItemRepository.Add", tracing.TenantID(tenantID))
+	defer span.End()
 
 	parcelID, err := uuid.Parse(item.ParcelID)
 	if err != nil {
@@ -49,12 +52,15 @@ func (r *InMemoryParcelItemRepository) Add(ctx context.Context, tenantID string,
 
 	item.ID = itemID.String()
 	item.ParcelID = parcelID.String()
+	prev, existed := r.data[tenantID][parcelID][itemID]
+	r.restoreOnRollback(ctx, tenantID, parcelID, itemID, prev, existed)
 	r.data[tenantID][parcelID][itemID] = item
 	return itemID, nil
 }
 
 func (r *InMemoryParcelItemRepository) ListByParcelID(ctx context.Context, tenantID string, parcelID uuid.UUID) ([]domain.ParcelItem, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelItemRepository.ListByParcelID", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -80,7 +86,8 @@ func (r *InMemoryParcelItemRepository) ListByParcelID(ctx context.Context, tenan
 }
 
 func (r *InMemoryParcelItemRepository) Delete(ctx context.Context, tenantID string, parcelID uuid.UUID, itemID uuid.UUID) error {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelItemRepository.Delete", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -97,6 +104,22 @@ func (r *InMemoryParcelItemRepository) Delete(ctx context.Context, tenantID stri
 	if !ok {
 		return nil
 	}
+	if prev, existed := byParcel[itemID]; existed {
+		r.restoreOnRollback(ctx, tenantID, parcelID, itemID, prev, true)
+	}
 	delete(byParcel, itemID)
 	return nil
 }
+
+// restoreOnRollback deja el item como estaba antes de la escritura si se revierte la unidad de trabajo de ctx.
+func (r *InMemoryParcelItemRepository) restoreOnRollback(ctx context.Context, tenantID string, parcelID uuid.UUID, itemID uuid.UUID, prev domain.ParcelItem, existed bool) {
+	memory.OnRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if existed {
+			r.data[tenantID][parcelID][itemID] = prev
+		} else {
+			delete(r.data[tenantID][parcelID], itemID)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -13,6 +14,8 @@ import (
 	pricingdomain "ms-parcel-core/internal/parcel/parcel_pricing/domain"
 	pricingport "ms-parcel-core/internal/parcel/parcel_pricing/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type AddParcelItemInput struct {
@@ -35,15 +38,22 @@ type AddParcelItemUseCase struct {
 	parcelReader    coreport.ParcelReader
 	repo            port.ParcelItemRepository
 	tracking        coreport.TrackingRecorder
-	optionsProvider coreport.TenantOptionsProvider
+	optionsProvider coreport.ParcelOptionsResolver
 	priceRules      pricingport.PriceRuleRepository
+	paymentSync     coreport.PaymentAmountSync
+	uow             coreport.UnitOfWork
+	events          coreport.EventOutbox
+	logger          *slog.Logger
 }
 
-func NewAddParcelItemUseCase(parcelReader coreport.ParcelReader, repo port.ParcelItemRepository, tracking coreport.TrackingRecorder, optionsProvider coreport.TenantOptionsProvider, priceRules pricingport.PriceRuleRepository) *AddParcelItemUseCase {
-	return &AddParcelItemUseCase{parcelReader: parcelReader, repo: repo, tracking: tracking, optionsProvider: optionsProvider, priceRules: priceRules}
+func NewAddParcelItemUseCase(parcelReader coreport.ParcelReader, repo port.ParcelItemRepository, tracking coreport.TrackingRecorder, optionsProvider coreport.ParcelOptionsResolver, priceRules pricingport.PriceRuleRepository, paymentSync coreport.PaymentAmountSync, uow coreport.UnitOfWork, events coreport.EventOutbox, logger *slog.Logger) *AddParcelItemUseCase {
+	return &AddParcelItemUseCase{parcelReader: parcelReader, repo: repo, tracking: tracking, optionsProvider: optionsProvider, priceRules: priceRules, paymentSync: paymentSync, uow: coreport.UnitOfWorkOrDirect(uow), events: coreport.EventOutboxOrNop(events), logger: logging.OrDiscard(logger)}
 }
 
 func (u *AddParcelItemUseCase) Execute(ctx context.Context, in AddParcelItemInput) (*domain.ParcelItem, error) {
+	ctx, span := tracing.StartUseCase(ctx, "AddParcelItem", tracing.TenantID(in.TenantID), tracing.ParcelID(in.ParcelID.String()))
+	defer span.End()
+
 	if strings.TrimSpace(in.TenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
@@ -59,25 +69,9 @@ func (u *AddParcelItemUseCase) Execute(ctx context.Context, in AddParcelItemInpu
 		return nil, apperror.New("not_found", "parcel no encontrado", map[string]any{"id": in.ParcelID.String()}, 404)
 	}
 
-	defaults := coreport.ParcelOptions{
-		RequirePackageKey:       true,
-		UsePriceTable:           true,
-		UseVolumetricWeight:     false,
-		VolumetricDivisor:       6000,
-		AllowManualPrice:        false,
-		AllowOverridePriceTable: true,
-		AllowPayInDestination:   false,
-		MaxPrints:               1,
-		AllowReprint:            false,
-		ReprintFeeEnabled:       false,
-	}
-	opts := defaults
+	opts := coreport.DefaultParcelOptions()
 	if u.optionsProvider != nil {
-		if o, err := u.optionsProvider.GetParcelOptions(ctx, in.TenantID); err == nil {
-			opts = o
-		} else {
-			// TODO: logger
-		}
+		opts = u.optionsProvider.Resolve(ctx, in.TenantID, parcel.OriginOfficeID)
 	}
 
 	// Cálculo de peso volumétrico y facturable
@@ -161,28 +155,65 @@ func (u *AddParcelItemUseCase) Execute(ctx context.Context, in AddParcelItemInpu
 		CreatedAt:        time.Now().UTC(),
 	}
 
-	id, err := u.repo.Add(ctx, in.TenantID, item)
-	if err != nil {
-		return nil, err
-	}
+	// Items, pago y tracking se escriben juntos: si falla el recálculo o el evento no queda el item
+	id, err := coreport.WithinUnitOfWork(ctx, u.uow, func(ctx context.Context) (uuid.UUID, error) {
+		id, err := u.repo.Add(ctx, in.TenantID, item)
+		if err != nil {
+			return uuid.Nil, err
+		}
 
-	item.ID = id.String()
+		if u.paymentSync != nil {
+			if err := u.paymentSync.ItemsChanged(ctx, in.TenantID, in.ParcelID); err != nil {
+				u.logger.ErrorContext(ctx, "no se pudo recalcular el pago tras cambio de items, se revierte", "parcel_id", in.ParcelID.String(), "error", err)
+				return uuid.Nil, err
+			}
+		}
+
+		if u.tracking != nil {
+			if err := u.tracking.RecordEvent(ctx, in.TenantID, coreport.TrackingEventDTO{
+				ParcelID:   in.ParcelID.String(),
+				EventType:  coreport.EventTypeParcelItemAdded,
+				OccurredAt: time.Now().UTC(),
+				UserID:     in.UserID,
+				UserName:   in.UserName,
+				Metadata: map[string]any{
+					"item_id":   id.String(),
+					"quantity":  in.Quantity,
+					"weight_kg": in.WeightKg,
+				},
+			}); err != nil {
+				u.logger.ErrorContext(ctx, "no se pudo registrar evento de tracking, se revierte", "parcel_id", in.ParcelID.String(), "event_type", coreport.EventTypeParcelItemAdded, "error", err)
+				return uuid.Nil, err
+			}
+		}
 
-	if u.tracking != nil {
-		_ = u.tracking.RecordEvent(ctx, in.TenantID, coreport.TrackingEventDTO{
-			ParcelID:   in.ParcelID.String(),
-			EventType:  "PARCEL_ITEM_ADDED",
-			OccurredAt: time.Now().UTC(),
-			UserID:     in.UserID,
-			UserName:   in.UserName,
-			Metadata: map[string]any{
-				"item_id":   id.String(),
-				"quantity":  in.Quantity,
-				"weight_kg": in.WeightKg,
+		if err := u.events.Enqueue(ctx, in.TenantID, coreport.DomainEventDTO{
+			Type:          coreport.DomainEventParcelItemAdded,
+			AggregateType: coreport.AggregateParcel,
+			AggregateID:   in.ParcelID.String(),
+			OccurredAt:    item.CreatedAt,
+			Data: map[string]any{
+				"parcel_id":       in.ParcelID.String(),
+				"tracking_code":   parcel.TrackingCode,
+				"item_id":         id.String(),
+				"description":     item.Description,
+				"quantity":        item.Quantity,
+				"weight_kg":       item.WeightKg,
+				"billable_weight": item.BillableWeight,
+				"unit_price":      item.UnitPrice,
+				"user_id":         in.UserID,
 			},
-		})
-		// TODO: logger si falla
+		}); err != nil {
+			u.logger.ErrorContext(ctx, "no se pudo encolar evento de integración, se revierte", "parcel_id", in.ParcelID.String(), "event_type", coreport.DomainEventParcelItemAdded, "error", err)
+			return uuid.Nil, err
+		}
+
+		return id, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	item.ID = id.String()
 	return &item, nil
 }
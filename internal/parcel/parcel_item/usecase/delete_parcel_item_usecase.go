@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
 	"ms-parcel-core/internal/parcel/parcel_item/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type DeleteParcelItemInput struct {
@@ -25,13 +28,20 @@ type DeleteParcelItemUseCase struct {
 	parcelReader coreport.ParcelReader
 	repo         port.ParcelItemRepository
 	tracking     coreport.TrackingRecorder
+	paymentSync  coreport.PaymentAmountSync
+	uow          coreport.UnitOfWork
+	events       coreport.EventOutbox
+	logger       *slog.Logger
 }
 
-func NewDeleteParcelItemUseCase(parcelReader coreport.ParcelReader, repo port.ParcelItemRepository, tracking coreport.TrackingRecorder) *DeleteParcelItemUseCase {
-	return &DeleteParcelItemUseCase{parcelReader: parcelReader, repo: repo, tracking: tracking}
+func NewDeleteParcelItemUseCase(parcelReader coreport.ParcelReader, repo port.ParcelItemRepository, tracking coreport.TrackingRecorder, paymentSync coreport.PaymentAmountSync, uow coreport.UnitOfWork, events coreport.EventOutbox, logger *slog.Logger) *DeleteParcelItemUseCase {
+	return &DeleteParcelItemUseCase{parcelReader: parcelReader, repo: repo, tracking: tracking, paymentSync: paymentSync, uow: coreport.UnitOfWorkOrDirect(uow), events: coreport.EventOutboxOrNop(events), logger: logging.OrDiscard(logger)}
 }
 
 func (u *DeleteParcelItemUseCase) Execute(ctx context.Context, in DeleteParcelItemInput) error {
+	ctx, span := tracing.StartUseCase(ctx, "DeleteParcelItem", tracing.TenantID(in.TenantID), tracing.ParcelID(in.ParcelID.String()))
+	defer span.End()
+
 	if strings.TrimSpace(in.TenantID) == "" {
 		return apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
@@ -55,21 +65,49 @@ func (u *DeleteParcelItemUseCase) Execute(ctx context.Context, in DeleteParcelIt
 		return apperror.New("invalid_state", "no se pueden modificar items en este estado", map[string]any{"allowed": []coredomain.ParcelStatus{coredomain.ParcelStatusCreated, coredomain.ParcelStatusRegistered}, "actual": p.Status}, 409)
 	}
 
-	if err := u.repo.Delete(ctx, in.TenantID, in.ParcelID, in.ItemID); err != nil {
-		return err
-	}
+	// Items, pago y tracking se escriben juntos: si falla el recálculo o el evento no queda el item borrado
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		if err := u.repo.Delete(ctx, in.TenantID, in.ParcelID, in.ItemID); err != nil {
+			return err
+		}
 
-	if u.tracking != nil {
-		_ = u.tracking.RecordEvent(ctx, in.TenantID, coreport.TrackingEventDTO{
-			ParcelID:   in.ParcelID.String(),
-			EventType:  "PARCEL_ITEM_REMOVED",
-			OccurredAt: time.Now().UTC(),
-			UserID:     in.UserID,
-			UserName:   in.UserName,
-			Metadata:   map[string]any{"item_id": in.ItemID.String()},
-		})
-		// TODO: logger si falla
-	}
+		if u.paymentSync != nil {
+			if err := u.paymentSync.ItemsChanged(ctx, in.TenantID, in.ParcelID); err != nil {
+				u.logger.ErrorContext(ctx, "no se pudo recalcular el pago tras cambio de items, se revierte", "parcel_id", in.ParcelID.String(), "error", err)
+				return err
+			}
+		}
+
+		if u.tracking != nil {
+			if err := u.tracking.RecordEvent(ctx, in.TenantID, coreport.TrackingEventDTO{
+				ParcelID:   in.ParcelID.String(),
+				EventType:  coreport.EventTypeParcelItemRemoved,
+				OccurredAt: time.Now().UTC(),
+				UserID:     in.UserID,
+				UserName:   in.UserName,
+				Metadata:   map[string]any{"item_id": in.ItemID.String()},
+			}); err != nil {
+				u.logger.ErrorContext(ctx, "no se pudo registrar evento de tracking, se revierte", "parcel_id", in.ParcelID.String(), "event_type", coreport.EventTypeParcelItemRemoved, "error", err)
+				return err
+			}
+		}
+
+		if err := u.events.Enqueue(ctx, in.TenantID, coreport.DomainEventDTO{
+			Type:          coreport.DomainEventParcelItemRemoved,
+			AggregateType: coreport.AggregateParcel,
+			AggregateID:   in.ParcelID.String(),
+			OccurredAt:    time.Now().UTC(),
+			Data: map[string]any{
+				"parcel_id":     in.ParcelID.String(),
+				"tracking_code": p.TrackingCode,
+				"item_id":       in.ItemID.String(),
+				"user_id":       in.UserID,
+			},
+		}); err != nil {
+			u.logger.ErrorContext(ctx, "no se pudo encolar evento de integración, se revierte", "parcel_id", in.ParcelID.String(), "event_type", coreport.DomainEventParcelItemRemoved, "error", err)
+			return err
+		}
 
-	return nil
+		return nil
+	})
 }
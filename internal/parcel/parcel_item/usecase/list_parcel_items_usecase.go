@@ -10,6 +10,7 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_item/domain"
 	"ms-parcel-core/internal/parcel/parcel_item/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type ListParcelItemsUseCase struct {
@@ -22,6 +23,9 @@ func NewListParcelItemsUseCase(parcelReader coreport.ParcelReader, repo port.Par
 }
 
 func (u *ListParcelItemsUseCase) Execute(ctx context.Context, tenantID string, parcelID uuid.UUID) ([]domain.ParcelItem, error) {
+	ctx, span := tracing.StartUseCase(ctx, "ListParcelItems", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
 	if strings.TrimSpace(tenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
@@ -9,6 +9,7 @@ import (
 	manifestdomain "ms-parcel-core/internal/parcel/parcel_manifest/domain"
 	manifestport "ms-parcel-core/internal/parcel/parcel_manifest/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type BuildManifestPreviewInput struct {
@@ -27,6 +28,9 @@ func NewBuildManifestPreviewUseCase(reader manifestport.ParcelReader) *BuildMani
 }
 
 func (u *BuildManifestPreviewUseCase) Execute(ctx context.Context, in BuildManifestPreviewInput) (*manifestdomain.ManifestPreview, error) {
+	ctx, span := tracing.StartUseCase(ctx, "BuildManifestPreview", tracing.TenantID(in.TenantID))
+	defer span.End()
+
 	if strings.TrimSpace(in.TenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
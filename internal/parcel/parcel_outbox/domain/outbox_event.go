@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending    OutboxStatus = "PENDING"
+	OutboxStatusPublishing OutboxStatus = "PUBLISHING"
+	OutboxStatusPublished  OutboxStatus = "PUBLISHED"
+	// OutboxStatusFailed: se agotaron los intentos; queda para revisión manual
+	OutboxStatusFailed OutboxStatus = "FAILED"
+)
+
+// EnvelopeVersion es la versión actual del sobre; sube ante cambios incompatibles en Data.
+const EnvelopeVersion = 1
+
+// EventSource identifica al servicio emisor en los sobres publicados.
+const EventSource = "ms-parcel-core"
+
+// OutboxEvent es un evento guardado junto con el cambio de estado que lo origina.
+// ID se publica como id del sobre: los consumidores lo usan para descartar duplicados.
+type OutboxEvent struct {
+	ID            string
+	TenantID      string
+	Type          string
+	Version       int
+	AggregateType string
+	AggregateID   string
+	OccurredAt    time.Time
+	Payload       map[string]any
+	Status        OutboxStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     *string
+	PublishedAt   *time.Time
+	CreatedAt     time.Time
+}
+
+// Envelope es lo que reciben los consumidores.
+type Envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	Version       int             `json:"version"`
+	Source        string          `json:"source"`
+	TenantID      string          `json:"tenant_id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Attempt       int             `json:"attempt"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// Envelope arma el sobre para el intento de publicación en curso.
+func (e OutboxEvent) Envelope() (Envelope, error) {
+	data := e.Payload
+	if data == nil {
+		data = map[string]any{}
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Envelope{}, err
+	}
+	return Envelope{
+		ID:            e.ID,
+		Type:          e.Type,
+		Version:       e.Version,
+		Source:        EventSource,
+		TenantID:      e.TenantID,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		OccurredAt:    e.OccurredAt,
+		Attempt:       e.Attempts,
+		Data:          raw,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package dispatcher
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"ms-parcel-core/internal/parcel/parcel_outbox/usecase"
+	"ms-parcel-core/internal/pkg/util/logging"
+)
+
+// DispatchWorker ejecuta periódicamente la publicación de eventos del outbox.
+type DispatchWorker struct {
+	uc       *usecase.DispatchOutboxEventsUseCase
+	interval time.Duration
+	batch    int
+	logger   *slog.Logger
+}
+
+func NewDispatchWorker(uc *usecase.DispatchOutboxEventsUseCase, interval time.Duration, batch int, logger *slog.Logger) *DispatchWorker {
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	if batch <= 0 {
+		batch = 100
+	}
+	return &DispatchWorker{uc: uc, interval: interval, batch: batch, logger: logging.OrDiscard(logger)}
+}
+
+// Run bloquea hasta que ctx se cancele. Si un lote sale lleno se sigue sin esperar el próximo tick,
+// para vaciar atrasos rápido.
+func (w *DispatchWorker) Run(ctx context.Context) {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			for w.dispatch(ctx) && ctx.Err() == nil {
+			}
+		}
+	}
+}
+
+// dispatch publica un lote y devuelve true si quedó lleno (puede haber más pendientes).
+func (w *DispatchWorker) dispatch(ctx context.Context) bool {
+	res, err := w.uc.Execute(ctx, w.batch)
+	if err != nil {
+		w.logger.ErrorContext(ctx, "falló la publicación de eventos del outbox", "error", err)
+		return false
+	}
+	if res.Attempted > 0 {
+		w.logger.DebugContext(ctx, "publicación de eventos del outbox", "attempted", res.Attempted, "published", res.Published, "pending", res.Pending, "failed", res.Failed)
+	}
+	return res.Attempted >= w.batch && res.Pending == 0 && res.Failed == 0
+}
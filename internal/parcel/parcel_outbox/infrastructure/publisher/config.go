@@ -0,0 +1,89 @@
+package publisher
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"ms-parcel-core/internal/parcel/parcel_outbox/port"
+)
+
+// Valores de OUTBOX_PUBLISHER.
+const (
+	KindNone   = "none"
+	KindStdout = "stdout"
+	KindFile   = "file"
+	KindHTTP   = "http"
+)
+
+type Config struct {
+	// Kind elige el publisher; con none los eventos quedan en el outbox sin publicar.
+	Kind        string
+	FilePath    string
+	HTTPURL     string
+	HTTPToken   string
+	HTTPTimeout time.Duration
+	// Interval y Batch configuran el dispatcher.
+	Interval time.Duration
+	Batch    int
+}
+
+// ConfigFromEnv lee OUTBOX_PUBLISHER (none|stdout|file|http, default none), OUTBOX_FILE_PATH,
+// OUTBOX_HTTP_URL, OUTBOX_HTTP_TOKEN, OUTBOX_HTTP_TIMEOUT_MS, OUTBOX_DISPATCH_INTERVAL_MS y OUTBOX_DISPATCH_BATCH.
+func ConfigFromEnv() Config {
+	kind := strings.ToLower(strings.TrimSpace(os.Getenv("OUTBOX_PUBLISHER")))
+	if kind == "" {
+		kind = KindNone
+	}
+	path := strings.TrimSpace(os.Getenv("OUTBOX_FILE_PATH"))
+	if path == "" {
+		path = "outbox-events.jsonl"
+	}
+	return Config{
+		Kind:        kind,
+		FilePath:    path,
+		HTTPURL:     strings.TrimSpace(os.Getenv("OUTBOX_HTTP_URL")),
+		HTTPToken:   strings.TrimSpace(os.Getenv("OUTBOX_HTTP_TOKEN")),
+		HTTPTimeout: envDurationMs("OUTBOX_HTTP_TIMEOUT_MS", 5*time.Second),
+		Interval:    envDurationMs("OUTBOX_DISPATCH_INTERVAL_MS", 2*time.Second),
+		Batch:       envInt("OUTBOX_DISPATCH_BATCH", 100),
+	}
+}
+
+// New crea el publisher configurado. Devuelve nil (sin error) con none: el composition root no
+// arranca el dispatcher y los eventos se acumulan hasta que se configure uno.
+func New(cfg Config) (port.EventPublisher, error) {
+	switch cfg.Kind {
+	case KindNone:
+		return nil, nil
+	case KindStdout:
+		return NewStdoutPublisher(), nil
+	case KindFile:
+		return NewFilePublisher(cfg.FilePath)
+	case KindHTTP:
+		if cfg.HTTPURL == "" {
+			return nil, fmt.Errorf("OUTBOX_HTTP_URL es requerido con OUTBOX_PUBLISHER=%s", KindHTTP)
+		}
+		return NewHTTPPublisher(cfg.HTTPURL, cfg.HTTPToken, cfg.HTTPTimeout, nil), nil
+	default:
+		return nil, fmt.Errorf("OUTBOX_PUBLISHER inválido: %q (none|stdout|file|http)", cfg.Kind)
+	}
+}
+
+func envInt(key string, def int) int {
+	v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || v <= 0 {
+		return def
+	}
+	return v
+}
+
+func envDurationMs(key string, def time.Duration) time.Duration {
+	v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || v <= 0 {
+		return def
+	}
+	return time.Duration(v) * time.Millisecond
+}
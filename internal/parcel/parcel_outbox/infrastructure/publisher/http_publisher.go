@@ -0,0 +1,80 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"ms-parcel-core/internal/parcel/parcel_outbox/domain"
+	"ms-parcel-core/internal/parcel/parcel_outbox/port"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+// HTTPPublisher hace POST del sobre en JSON a un endpoint (webhook, bus con API HTTP, etc.).
+// Cualquier 2xx confirma la entrega. No reintenta: de eso se ocupa el dispatcher con backoff.
+type HTTPPublisher struct {
+	url   string
+	token string
+	http  *http.Client
+}
+
+var _ port.EventPublisher = (*HTTPPublisher)(nil)
+
+// NewHTTPPublisher crea el publisher. httpClient es opcional; si es nil se crea uno con timeout
+// y transporte instrumentado con OpenTelemetry.
+func NewHTTPPublisher(url string, token string, timeout time.Duration, httpClient *http.Client) *HTTPPublisher {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: timeout, Transport: otelhttp.NewTransport(http.DefaultTransport)}
+	}
+	return &HTTPPublisher{url: strings.TrimSpace(url), token: strings.TrimSpace(token), http: httpClient}
+}
+
+func (p *HTTPPublisher) Publish(ctx context.Context, env domain.Envelope) error {
+	ctx, span := tracing.Start(ctx, "HTTPPublisher.Publish", tracing.TenantID(env.TenantID))
+	defer span.End()
+
+	body, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Idempotency-Key / X-Event-Id: el mismo evento reintentado lleva siempre el mismo id
+	req.Header.Set("Idempotency-Key", env.ID)
+	req.Header.Set("X-Event-Id", env.ID)
+	req.Header.Set("X-Event-Type", env.Type)
+	req.Header.Set("X-Tenant-ID", env.TenantID)
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := p.http.Do(req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := fmt.Errorf("publicación rechazada: status %d", resp.StatusCode)
+		tracing.RecordError(span, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"ms-parcel-core/internal/parcel/parcel_outbox/domain"
+	"ms-parcel-core/internal/parcel/parcel_outbox/port"
+)
+
+// WriterPublisher escribe cada sobre como una línea JSON (JSON Lines). Pensado para desarrollo local:
+// stdout para verlos pasar, o un archivo para inspeccionarlos o reinyectarlos después.
+type WriterPublisher struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+var _ port.EventPublisher = (*WriterPublisher)(nil)
+
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{w: w}
+}
+
+// NewStdoutPublisher publica en la salida estándar, mezclado con los logs del proceso.
+func NewStdoutPublisher() *WriterPublisher {
+	return NewWriterPublisher(os.Stdout)
+}
+
+// NewFilePublisher agrega los sobres al final de path (lo crea si no existe).
+func NewFilePublisher(path string) (*WriterPublisher, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &WriterPublisher{w: f, closer: f}, nil
+}
+
+func (p *WriterPublisher) Publish(_ context.Context, env domain.Envelope) error {
+	line, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err = p.w.Write(line)
+	return err
+}
+
+// Close cierra el archivo si el publisher lo abrió.
+func (p *WriterPublisher) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+	return p.closer.Close()
+}
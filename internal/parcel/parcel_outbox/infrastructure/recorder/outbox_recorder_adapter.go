@@ -0,0 +1,47 @@
+package recorder
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_outbox/domain"
+	outboxport "ms-parcel-core/internal/parcel/parcel_outbox/port"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type OutboxRecorderAdapter struct {
+	repo outboxport.OutboxRepository
+}
+
+var _ coreport.EventOutbox = (*OutboxRecorderAdapter)(nil)
+
+func NewOutboxRecorderAdapter(repo outboxport.OutboxRepository) *OutboxRecorderAdapter {
+	return &OutboxRecorderAdapter{repo: repo}
+}
+
+func (a *OutboxRecorderAdapter) Enqueue(ctx context.Context, tenantID string, ev coreport.DomainEventDTO) error {
+	ctx, span := tracing.Start(ctx, "OutboxRecorderAdapter.Enqueue", tracing.TenantID(tenantID))
+	defer span.End()
+
+	now := time.Now().UTC()
+	occurredAt := ev.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = now
+	}
+	return a.repo.Add(ctx, domain.OutboxEvent{
+		ID:            uuid.NewString(),
+		TenantID:      tenantID,
+		Type:          ev.Type,
+		Version:       domain.EnvelopeVersion,
+		AggregateType: ev.AggregateType,
+		AggregateID:   ev.AggregateID,
+		OccurredAt:    occurredAt.UTC(),
+		Payload:       ev.Data,
+		Status:        domain.OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"ms-parcel-core/internal/infrastructure/persistence/memory"
+	"ms-parcel-core/internal/parcel/parcel_outbox/domain"
+	"ms-parcel-core/internal/parcel/parcel_outbox/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type InMemoryOutboxRepository struct {
+	mu     sync.Mutex
+	events []domain.OutboxEvent // orden de alta
+}
+
+var _ port.OutboxRepository = (*InMemoryOutboxRepository)(nil)
+
+func NewInMemoryOutboxRepository() *InMemoryOutboxRepository {
+	return &InMemoryOutboxRepository{events: []domain.OutboxEvent{}}
+}
+
+func (r *InMemoryOutboxRepository) Add(ctx context.Context, ev domain.OutboxEvent) error {
+	_, span := tracing.Start(ctx, "OutboxRepository.Add", tracing.TenantID(ev.TenantID))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.events == nil {
+		return apperror.NewInternal("internal_error", "repositorio outbox no inicializado", nil)
+	}
+	r.events = append(r.events, ev)
+
+	memory.OnRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for i := len(r.events) - 1; i >= 0; i-- {
+			if r.events[i].ID == ev.ID {
+				r.events = append(r.events[:i:i], r.events[i+1:]...)
+				break
+			}
+		}
+	})
+	return nil
+}
+
+func (r *InMemoryOutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.OutboxEvent, error) {
+	_, span := tracing.Start(ctx, "OutboxRepository.ClaimDue")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.events == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio outbox no inicializado", nil)
+	}
+
+	idx := make([]int, 0)
+	for i := range r.events {
+		if outboxEventDue(r.events[i], now) {
+			idx = append(idx, i)
+		}
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return r.events[idx[a]].CreatedAt.Before(r.events[idx[b]].CreatedAt) })
+	if limit > 0 && len(idx) > limit {
+		idx = idx[:limit]
+	}
+
+	out := make([]domain.OutboxEvent, 0, len(idx))
+	for _, i := range idx {
+		r.events[i].Status = domain.OutboxStatusPublishing
+		r.events[i].Attempts++
+		r.events[i].NextAttemptAt = now.Add(lease)
+		out = append(out, r.events[i])
+	}
+	return out, nil
+}
+
+func (r *InMemoryOutboxRepository) SaveDelivery(ctx context.Context, ev domain.OutboxEvent) error {
+	_, span := tracing.Start(ctx, "OutboxRepository.SaveDelivery", tracing.TenantID(ev.TenantID))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.events == nil {
+		return apperror.NewInternal("internal_error", "repositorio outbox no inicializado", nil)
+	}
+	for i := range r.events {
+		if r.events[i].ID != ev.ID {
+			continue
+		}
+		if r.events[i].Status != domain.OutboxStatusPublishing || r.events[i].Attempts != ev.Attempts {
+			return port.ErrEventChanged
+		}
+		// Solo se actualizan los campos de entrega; el evento en sí es inmutable
+		r.events[i].Status = ev.Status
+		r.events[i].Attempts = ev.Attempts
+		r.events[i].NextAttemptAt = ev.NextAttemptAt
+		r.events[i].LastError = ev.LastError
+		r.events[i].PublishedAt = ev.PublishedAt
+		return nil
+	}
+	return apperror.New("not_found", "evento no encontrado", map[string]any{"event_id": ev.ID}, 404)
+}
+
+func outboxEventDue(ev domain.OutboxEvent, now time.Time) bool {
+	switch ev.Status {
+	case domain.OutboxStatusPending, domain.OutboxStatusPublishing:
+		return !now.Before(ev.NextAttemptAt)
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"ms-parcel-core/internal/parcel/parcel_outbox/domain"
+)
+
+// EventPublisher entrega un sobre a los consumidores. Devolver nil confirma la entrega; ante error el
+// evento se reintenta, así que un mismo sobre (mismo id) puede llegar más de una vez.
+type EventPublisher interface {
+	Publish(ctx context.Context, env domain.Envelope) error
+}
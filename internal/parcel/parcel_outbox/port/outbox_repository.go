@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ms-parcel-core/internal/parcel/parcel_outbox/domain"
+)
+
+// ErrEventChanged indica que el evento ya no está reclamado con ese intento: venció el lease y otro
+// dispatcher lo retomó, así que el resultado de este intento se descarta.
+var ErrEventChanged = errors.New("el evento de outbox fue retomado por otro dispatcher")
+
+type OutboxRepository interface {
+	// Add guarda el evento PENDING; con el ctx de una unidad de trabajo se confirma junto con ella.
+	Add(ctx context.Context, ev domain.OutboxEvent) error
+	// ClaimDue toma (todos los tenants) hasta limit eventos listos para publicar: PENDING con
+	// NextAttemptAt vencido, o PUBLISHING con lease vencido. Los deja PUBLISHING hasta now+lease
+	// y suma un intento; dos dispatchers nunca reciben el mismo evento dentro del lease.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.OutboxEvent, error)
+	// SaveDelivery guarda el resultado del intento (estado, próximo intento, error, fecha de publicación)
+	// solo si el evento sigue PUBLISHING con ev.Attempts, es decir, con el reclamo de este intento; si
+	// no, devuelve ErrEventChanged.
+	SaveDelivery(ctx context.Context, ev domain.OutboxEvent) error
+}
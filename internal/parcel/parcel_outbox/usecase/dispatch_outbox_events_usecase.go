@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"ms-parcel-core/internal/parcel/parcel_outbox/domain"
+	"ms-parcel-core/internal/parcel/parcel_outbox/port"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+const (
+	// El lote se reserva mientras se publica (ver outboxLeaseFor); si el proceso muere, pasado el
+	// lease otro dispatcher lo vuelve a tomar (por eso la entrega es at-least-once).
+	outboxMinLease       = 2 * time.Minute
+	outboxLeaseMargin    = 30 * time.Second
+	outboxDefaultTimeout = 5 * time.Second
+	outboxMaxAttempts    = 12
+	outboxBaseBackoff    = 10 * time.Second
+	outboxMaxBackoff     = 30 * time.Minute
+)
+
+type DispatchOutboxEventsResult struct {
+	Attempted int
+	Published int
+	Pending   int
+	Failed    int
+}
+
+// DispatchOutboxEventsUseCase publica los eventos pendientes del outbox.
+type DispatchOutboxEventsUseCase struct {
+	repo      port.OutboxRepository
+	publisher port.EventPublisher
+	// publishTimeout acota cada publicación; con él se dimensiona el lease del lote
+	publishTimeout time.Duration
+	logger         *slog.Logger
+}
+
+func NewDispatchOutboxEventsUseCase(repo port.OutboxRepository, publisher port.EventPublisher, publishTimeout time.Duration, logger *slog.Logger) *DispatchOutboxEventsUseCase {
+	if publishTimeout <= 0 {
+		publishTimeout = outboxDefaultTimeout
+	}
+	return &DispatchOutboxEventsUseCase{repo: repo, publisher: publisher, publishTimeout: publishTimeout, logger: logging.OrDiscard(logger)}
+}
+
+func (u *DispatchOutboxEventsUseCase) Execute(ctx context.Context, limit int) (*DispatchOutboxEventsResult, error) {
+	ctx, span := tracing.StartUseCase(ctx, "DispatchOutboxEvents")
+	defer span.End()
+
+	if limit <= 0 {
+		limit = 100
+	}
+
+	events, err := u.repo.ClaimDue(ctx, time.Now().UTC(), outboxLeaseFor(limit, u.publishTimeout), limit)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	res := &DispatchOutboxEventsResult{}
+	for i := range events {
+		if ctx.Err() != nil {
+			// los no intentados quedan PUBLISHING y se retoman al vencer el lease
+			break
+		}
+		res.Attempted++
+		switch u.deliver(ctx, events[i]).Status {
+		case domain.OutboxStatusPublished:
+			res.Published++
+		case domain.OutboxStatusFailed:
+			res.Failed++
+		default:
+			res.Pending++
+		}
+	}
+	return res, nil
+}
+
+// deliver publica un evento ya reclamado y guarda el resultado del intento.
+func (u *DispatchOutboxEventsUseCase) deliver(ctx context.Context, ev domain.OutboxEvent) domain.OutboxEvent {
+	now := time.Now().UTC()
+	env, err := ev.Envelope()
+	if err == nil {
+		pctx, cancel := context.WithTimeout(ctx, u.publishTimeout)
+		err = u.publisher.Publish(pctx, env)
+		cancel()
+	}
+
+	if err != nil {
+		msg := err.Error()
+		ev.LastError = &msg
+		if ev.Attempts >= outboxMaxAttempts {
+			ev.Status = domain.OutboxStatusFailed
+			u.logger.ErrorContext(ctx, "evento de outbox descartado tras agotar reintentos", "event_id", ev.ID, "type", ev.Type, "aggregate_id", ev.AggregateID, "attempts", ev.Attempts, "error", err)
+		} else {
+			ev.Status = domain.OutboxStatusPending
+			ev.NextAttemptAt = now.Add(outboxBackoff(ev.Attempts))
+			u.logger.WarnContext(ctx, "no se pudo publicar evento de outbox, queda pendiente", "event_id", ev.ID, "type", ev.Type, "aggregate_id", ev.AggregateID, "attempts", ev.Attempts, "next_attempt_at", ev.NextAttemptAt, "error", err)
+		}
+	} else {
+		ev.Status = domain.OutboxStatusPublished
+		ev.LastError = nil
+		ev.PublishedAt = &now
+	}
+
+	if err := u.repo.SaveDelivery(ctx, ev); err != nil {
+		if errors.Is(err, port.ErrEventChanged) {
+			// otro dispatcher lo retomó: su intento es el que cuenta
+			u.logger.WarnContext(ctx, "evento de outbox retomado por otro dispatcher, se descarta este intento", "event_id", ev.ID, "attempts", ev.Attempts)
+			return ev
+		}
+		// el lease vence y se vuelve a publicar; el consumidor descarta el duplicado por id
+		u.logger.ErrorContext(ctx, "no se pudo guardar el estado del evento de outbox", "event_id", ev.ID, "error", err)
+	}
+	return ev
+}
+
+// outboxLeaseFor reserva el lote el tiempo que puede tardar publicarlo entero, una publicación tras
+// otra y cada una acotada a publishTimeout, más un margen: así el lease no vence a mitad del lote y
+// otro dispatcher no retoma eventos que este todavía va a publicar.
+func outboxLeaseFor(limit int, publishTimeout time.Duration) time.Duration {
+	return max(time.Duration(limit)*publishTimeout+outboxLeaseMargin, outboxMinLease)
+}
+
+func outboxBackoff(attempts int) time.Duration {
+	d := outboxBaseBackoff
+	for i := 1; i < attempts && d < outboxMaxBackoff; i++ {
+		d *= 2
+	}
+	if d > outboxMaxBackoff {
+		d = outboxMaxBackoff
+	}
+	return d
+}
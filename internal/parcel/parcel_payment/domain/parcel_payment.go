@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 type PaymentType string
 
@@ -10,6 +13,8 @@ type PaymentStatus string
 
 type PaymentChannel string
 
+type PaymentAmountSource string
+
 const (
 	PaymentTypeCash              PaymentType = "CASH"
 	PaymentTypeFOB               PaymentType = "FOB"
@@ -25,9 +30,15 @@ const (
 	CurrencyUSD Currency = "USD"
 )
 
+// Estado calculado desde el ledger de transacciones (ver ApplyLedger).
 const (
-	PaymentStatusPending PaymentStatus = "PENDING"
-	PaymentStatusPaid    PaymentStatus = "PAID"
+	PaymentStatusUnpaid   PaymentStatus = "UNPAID"
+	PaymentStatusPartial  PaymentStatus = "PARTIAL"
+	PaymentStatusPaid     PaymentStatus = "PAID"
+	PaymentStatusOverpaid PaymentStatus = "OVERPAID"
+
+	PaymentStatusRefunded          PaymentStatus = "REFUNDED"
+	PaymentStatusPartiallyRefunded PaymentStatus = "PARTIALLY_REFUNDED"
 )
 
 const (
@@ -35,6 +46,20 @@ const (
 	PaymentChannelWeb     PaymentChannel = "WEB"
 )
 
+const (
+	// PaymentAmountAuto: monto = suma de items + recargos
+	PaymentAmountAuto PaymentAmountSource = "AUTO"
+	// PaymentAmountManual: monto fijado a mano (requiere AllowManualPrice y motivo)
+	PaymentAmountManual PaymentAmountSource = "MANUAL"
+)
+
+// PaymentSurcharge es un recargo adicional a los items (embalaje, seguro, etc.)
+type PaymentSurcharge struct {
+	Code        string
+	Description *string
+	Amount      float64
+}
+
 type ParcelPayment struct {
 	ID           string
 	TenantID     string
@@ -53,4 +78,68 @@ type ParcelPayment struct {
 	OfficeID     *string
 	CashboxID    *string
 	SellerUserID *string
+
+	// Desglose del monto
+	ItemsAmount        float64
+	Surcharges         []PaymentSurcharge
+	SurchargesAmount   float64
+	AmountSource       PaymentAmountSource
+	ManualAmountReason *string
+	// AmountStale indica que los items cambiaron después de fijar el monto
+	// y este no se recalculó (monto manual o pago ya cobrado).
+	AmountStale        bool
+	AmountCalculatedAt *time.Time
+
+	// Totales del ledger (derivados de PaymentTransaction)
+	PaidAmount     float64
+	RefundedAmount float64
+	Balance        float64
+}
+
+// CalculatedAmount es el monto sugerido: items + recargos.
+func (p ParcelPayment) CalculatedAmount() float64 {
+	return RoundAmount(p.ItemsAmount + p.SurchargesAmount)
+}
+
+// ApplyLedger recalcula PaidAmount (neto), RefundedAmount, Balance y Status a partir de las transacciones.
+// El estado sale del saldo: con devoluciones es REFUNDED si no queda nada cobrado y PARTIALLY_REFUNDED
+// solo mientras falte cobrar; un pago que vuelve a quedar saldado (o con vuelto devuelto) es PAID.
+// Las anulaciones (VOID) solo corrigen el cobrado.
+func (p *ParcelPayment) ApplyLedger(txs []PaymentTransaction) {
+	paid := 0.0
+	refunded := 0.0
+	for _, tx := range txs {
+		paid += tx.SignedAmount()
+		if tx.Kind == PaymentTransactionRefund {
+			refunded += tx.Amount
+		}
+	}
+	p.PaidAmount = RoundAmount(paid)
+	p.RefundedAmount = RoundAmount(refunded)
+	p.Balance = RoundAmount(p.Amount - p.PaidAmount)
+
+	switch {
+	case p.PaidAmount <= 0 && p.RefundedAmount > 0:
+		p.Status = PaymentStatusRefunded
+	case p.PaidAmount <= 0 && p.Amount > 0:
+		p.Status = PaymentStatusUnpaid
+	case p.Balance < 0:
+		p.Status = PaymentStatusOverpaid
+	case p.Balance == 0:
+		p.Status = PaymentStatusPaid
+	case p.RefundedAmount > 0:
+		p.Status = PaymentStatusPartiallyRefunded
+	default:
+		p.Status = PaymentStatusPartial
+	}
+}
+
+// IsSettled indica que no queda saldo pendiente.
+func (p ParcelPayment) IsSettled() bool {
+	return p.Balance <= 0
+}
+
+// RoundAmount redondea a 2 decimales (céntimos).
+func RoundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
 }
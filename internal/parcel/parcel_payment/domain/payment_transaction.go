@@ -0,0 +1,87 @@
+package domain
+
+import "time"
+
+type PaymentTransactionKind string
+
+const (
+	// PaymentTransactionCharge es un cobro (ingreso) contra el pago del envío.
+	PaymentTransactionCharge PaymentTransactionKind = "CHARGE"
+	// PaymentTransactionRefund devuelve dinero ya cobrado (total o parcial).
+	PaymentTransactionRefund PaymentTransactionKind = "REFUND"
+	// PaymentTransactionVoid anula un cobro puntual (corrección, como si no hubiera existido).
+	PaymentTransactionVoid PaymentTransactionKind = "VOID"
+)
+
+// CashboxPostingStatus es el estado del envío del movimiento a ms-cashbox.
+// Vacío = la transacción no pasó por caja (p.ej. canal WEB).
+type CashboxPostingStatus string
+
+const (
+	CashboxPostingPending CashboxPostingStatus = "PENDING"
+	// CashboxPostingInProgress: un proceso tomó la transacción y está posteando.
+	CashboxPostingInProgress CashboxPostingStatus = "POSTING"
+	CashboxPostingPosted     CashboxPostingStatus = "POSTED"
+	// CashboxPostingFailed: se agotaron los reintentos; requiere revisión manual.
+	CashboxPostingFailed CashboxPostingStatus = "FAILED"
+)
+
+// PaymentTransaction es un movimiento del ledger de pagos de un envío.
+// Un envío puede pagarse en varias transacciones (parte en origen, parte en destino,
+// o con distintos medios de pago).
+type PaymentTransaction struct {
+	ID              string
+	TenantID        string
+	ParcelID        string
+	PaymentID       string
+	Kind            PaymentTransactionKind
+	PaymentType     PaymentType
+	Channel         PaymentChannel
+	Amount          float64
+	Currency        Currency
+	OfficeID        *string
+	CashboxID       *string
+	SellerUserID    *string
+	CreatedByUserID *string
+	Notes           *string
+	CreatedAt       time.Time
+
+	// Devoluciones y anulaciones
+	Reason                *string
+	ApprovedByUserID      *string
+	ReversesTransactionID *string
+
+	// Movimiento en caja (ms-cashbox); el ID de la transacción es la clave de idempotencia
+	CashboxMovementID      *string
+	CashboxPostingStatus   CashboxPostingStatus
+	CashboxPostingAttempts int
+	CashboxPostingError    *string
+	CashboxNextAttemptAt   *time.Time
+	CashboxPostedAt        *time.Time
+}
+
+// RequiresCashboxPosting indica si la transacción debe registrarse como movimiento de caja.
+func (t PaymentTransaction) RequiresCashboxPosting() bool {
+	return t.CashboxID != nil && *t.CashboxID != ""
+}
+
+// SignedAmount es el efecto de la transacción sobre el monto cobrado.
+func (t PaymentTransaction) SignedAmount() float64 {
+	switch t.Kind {
+	case PaymentTransactionRefund, PaymentTransactionVoid:
+		return -t.Amount
+	default:
+		return t.Amount
+	}
+}
+
+// VoidedTransactionIDs devuelve los IDs de cobros ya anulados en el ledger.
+func VoidedTransactionIDs(txs []PaymentTransaction) map[string]bool {
+	out := map[string]bool{}
+	for _, t := range txs {
+		if t.Kind == PaymentTransactionVoid && t.ReversesTransactionID != nil {
+			out[*t.ReversesTransactionID] = true
+		}
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package balance
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_payment/port"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type PaymentBalanceAdapter struct {
+	repo port.ParcelPaymentRepository
+}
+
+var _ coreport.PaymentBalanceReader = (*PaymentBalanceAdapter)(nil)
+
+func NewPaymentBalanceAdapter(repo port.ParcelPaymentRepository) *PaymentBalanceAdapter {
+	return &PaymentBalanceAdapter{repo: repo}
+}
+
+func (a *PaymentBalanceAdapter) GetBalance(ctx context.Context, tenantID string, parcelID uuid.UUID) (*coreport.ParcelPaymentBalanceDTO, error) {
+	ctx, span := tracing.Start(ctx, "PaymentBalanceAdapter.GetBalance", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	pay, err := a.repo.GetByParcelID(ctx, tenantID, parcelID)
+	if err != nil {
+		return nil, err
+	}
+	if pay == nil {
+		return nil, nil
+	}
+
+	// Se recalcula desde el ledger para no depender de totales persistidos
+	txs, err := a.repo.ListTransactions(ctx, tenantID, parcelID)
+	if err != nil {
+		return nil, err
+	}
+	pay.ApplyLedger(txs)
+
+	return &coreport.ParcelPaymentBalanceDTO{
+		Amount:     pay.Amount,
+		PaidAmount: pay.PaidAmount,
+		Balance:    pay.Balance,
+		Currency:   string(pay.Currency),
+		Status:     string(pay.Status),
+	}, nil
+}
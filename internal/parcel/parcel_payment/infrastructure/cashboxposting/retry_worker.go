@@ -0,0 +1,47 @@
+package cashboxposting
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"ms-parcel-core/internal/parcel/parcel_payment/usecase"
+	"ms-parcel-core/internal/pkg/util/logging"
+)
+
+// RetryWorker ejecuta periódicamente el reintento de movimientos de caja pendientes.
+type RetryWorker struct {
+	uc       *usecase.RetryCashboxPostingsUseCase
+	interval time.Duration
+	batch    int
+	logger   *slog.Logger
+}
+
+func NewRetryWorker(uc *usecase.RetryCashboxPostingsUseCase, interval time.Duration, batch int, logger *slog.Logger) *RetryWorker {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return &RetryWorker{uc: uc, interval: interval, batch: batch, logger: logging.OrDiscard(logger)}
+}
+
+// Run bloquea hasta que ctx se cancele.
+func (w *RetryWorker) Run(ctx context.Context) {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			res, err := w.uc.Execute(ctx, w.batch)
+			if err != nil {
+				w.logger.ErrorContext(ctx, "falló el reintento de movimientos de caja", "error", err)
+				continue
+			}
+			if res.Attempted > 0 {
+				w.logger.InfoContext(ctx, "reintento de movimientos de caja", "attempted", res.Attempted, "posted", res.Posted, "pending", res.Pending, "failed", res.Failed)
+			}
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package itemsync
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_payment/usecase"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type PaymentAmountSyncAdapter struct {
+	recalculateUC *usecase.RecalculateParcelPaymentUseCase
+}
+
+var _ coreport.PaymentAmountSync = (*PaymentAmountSyncAdapter)(nil)
+
+func NewPaymentAmountSyncAdapter(recalculateUC *usecase.RecalculateParcelPaymentUseCase) *PaymentAmountSyncAdapter {
+	return &PaymentAmountSyncAdapter{recalculateUC: recalculateUC}
+}
+
+func (a *PaymentAmountSyncAdapter) ItemsChanged(ctx context.Context, tenantID string, parcelID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "PaymentAmountSyncAdapter.ItemsChanged", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	_, err := a.recalculateUC.Execute(ctx, tenantID, parcelID)
+	return err
+}
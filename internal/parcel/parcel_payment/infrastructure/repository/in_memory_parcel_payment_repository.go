@@ -2,28 +2,37 @@ package repository
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
+	"ms-parcel-core/internal/infrastructure/persistence/memory"
 	"ms-parcel-core/internal/parcel/parcel_payment/domain"
 	"ms-parcel-core/internal/parcel/parcel_payment/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type InMemoryParcelPaymentRepository struct {
 	mu   sync.Mutex
-	data map[string]map[uuid.UUID]domain.ParcelPayment // tenant -> parcel -> payment
+	data map[string]map[uuid.UUID]domain.ParcelPayment        // tenant -> parcel -> payment
+	txs  map[string]map[uuid.UUID][]domain.PaymentTransaction // tenant -> parcel -> ledger
 }
 
 var _ port.ParcelPaymentRepository = (*InMemoryParcelPaymentRepository)(nil)
 
 func NewInMemoryParcelPaymentRepository() *InMemoryParcelPaymentRepository {
-	return &InMemoryParcelPaymentRepository{data: map[string]map[uuid.UUID]domain.ParcelPayment{}}
+	return &InMemoryParcelPaymentRepository{
+		data: map[string]map[uuid.UUID]domain.ParcelPayment{},
+		txs:  map[string]map[uuid.UUID][]domain.PaymentTransaction{},
+	}
 }
 
 func (r *InMemoryParcelPaymentRepository) Upsert(ctx context.Context, tenantID string, p domain.ParcelPayment) (*domain.ParcelPayment, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelPaymentRepository.Upsert", tracing.TenantID(tenantID))
+	defer span.End()
 
 	parcelID, err := uuid.Parse(p.ParcelID)
 	if err != nil {
@@ -40,13 +49,25 @@ func (r *InMemoryParcelPaymentRepository) Upsert(ctx context.Context, tenantID s
 		r.data[tenantID] = map[uuid.UUID]domain.ParcelPayment{}
 	}
 
+	prev, existed := r.data[tenantID][parcelID]
+	memory.OnRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if existed {
+			r.data[tenantID][parcelID] = prev
+		} else {
+			delete(r.data[tenantID], parcelID)
+		}
+	})
+
 	r.data[tenantID][parcelID] = p
 	cp := p
 	return &cp, nil
 }
 
 func (r *InMemoryParcelPaymentRepository) GetByParcelID(ctx context.Context, tenantID string, parcelID uuid.UUID) (*domain.ParcelPayment, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "ParcelPaymentRepository.GetByParcelID", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -67,3 +88,181 @@ func (r *InMemoryParcelPaymentRepository) GetByParcelID(ctx context.Context, ten
 	cp := p
 	return &cp, nil
 }
+
+// LockByParcelID no necesita bloquear: InMemoryUnitOfWork ya serializa las unidades de trabajo.
+func (r *InMemoryParcelPaymentRepository) LockByParcelID(ctx context.Context, tenantID string, parcelID uuid.UUID) (*domain.ParcelPayment, error) {
+	return r.GetByParcelID(ctx, tenantID, parcelID)
+}
+
+func (r *InMemoryParcelPaymentRepository) AddTransaction(ctx context.Context, tenantID string, tx domain.PaymentTransaction) (*domain.PaymentTransaction, error) {
+	_, span := tracing.Start(ctx, "ParcelPaymentRepository.AddTransaction", tracing.TenantID(tenantID))
+	defer span.End()
+
+	parcelID, err := uuid.Parse(tx.ParcelID)
+	if err != nil {
+		return nil, apperror.NewBadRequest("validation_error", "parcel_id inválido", map[string]any{"field": "parcel_id"})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.txs == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio pagos no inicializado", nil)
+	}
+	if _, ok := r.txs[tenantID]; !ok {
+		r.txs[tenantID] = map[uuid.UUID][]domain.PaymentTransaction{}
+	}
+	if tx.ID == "" {
+		tx.ID = uuid.NewString()
+	}
+	tx.TenantID = tenantID
+
+	r.txs[tenantID][parcelID] = append(r.txs[tenantID][parcelID], tx)
+	memory.OnRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		ledger := r.txs[tenantID][parcelID]
+		for i := len(ledger) - 1; i >= 0; i-- {
+			if ledger[i].ID == tx.ID {
+				r.txs[tenantID][parcelID] = append(ledger[:i:i], ledger[i+1:]...)
+				break
+			}
+		}
+	})
+	cp := tx
+	return &cp, nil
+}
+
+func (r *InMemoryParcelPaymentRepository) ListTransactions(ctx context.Context, tenantID string, parcelID uuid.UUID) ([]domain.PaymentTransaction, error) {
+	_, span := tracing.Start(ctx, "ParcelPaymentRepository.ListTransactions", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.txs == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio pagos no inicializado", nil)
+	}
+
+	byTenant, ok := r.txs[tenantID]
+	if !ok {
+		return []domain.PaymentTransaction{}, nil
+	}
+	out := make([]domain.PaymentTransaction, len(byTenant[parcelID]))
+	copy(out, byTenant[parcelID])
+	return out, nil
+}
+
+func (r *InMemoryParcelPaymentRepository) ClaimCashboxPosting(ctx context.Context, tenantID string, parcelID uuid.UUID, txID string, now time.Time, lease time.Duration) (*domain.PaymentTransaction, error) {
+	_, span := tracing.Start(ctx, "ParcelPaymentRepository.ClaimCashboxPosting", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.txs == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio pagos no inicializado", nil)
+	}
+
+	ledger := r.txs[tenantID][parcelID]
+	for i := range ledger {
+		if ledger[i].ID != txID {
+			continue
+		}
+		if !cashboxPostingDue(ledger[i], now) {
+			return nil, nil
+		}
+		r.restoreTransactionOnRollback(ctx, tenantID, parcelID, ledger[i])
+		until := now.Add(lease)
+		ledger[i].CashboxPostingStatus = domain.CashboxPostingInProgress
+		ledger[i].CashboxNextAttemptAt = &until
+		cp := ledger[i]
+		return &cp, nil
+	}
+	return nil, apperror.New("not_found", "transacción no encontrada", map[string]any{"transaction_id": txID}, 404)
+}
+
+func (r *InMemoryParcelPaymentRepository) SaveCashboxPosting(ctx context.Context, tenantID string, tx domain.PaymentTransaction) error {
+	_, span := tracing.Start(ctx, "ParcelPaymentRepository.SaveCashboxPosting", tracing.TenantID(tenantID))
+	defer span.End()
+
+	parcelID, err := uuid.Parse(tx.ParcelID)
+	if err != nil {
+		return apperror.NewBadRequest("validation_error", "parcel_id inválido", map[string]any{"field": "parcel_id"})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.txs == nil {
+		return apperror.NewInternal("internal_error", "repositorio pagos no inicializado", nil)
+	}
+
+	ledger := r.txs[tenantID][parcelID]
+	for i := range ledger {
+		if ledger[i].ID != tx.ID {
+			continue
+		}
+		r.restoreTransactionOnRollback(ctx, tenantID, parcelID, ledger[i])
+		// Solo se actualizan los campos de posteo; el resto de la transacción es inmutable
+		ledger[i].CashboxMovementID = tx.CashboxMovementID
+		ledger[i].CashboxPostingStatus = tx.CashboxPostingStatus
+		ledger[i].CashboxPostingAttempts = tx.CashboxPostingAttempts
+		ledger[i].CashboxPostingError = tx.CashboxPostingError
+		ledger[i].CashboxNextAttemptAt = tx.CashboxNextAttemptAt
+		ledger[i].CashboxPostedAt = tx.CashboxPostedAt
+		return nil
+	}
+	return apperror.New("not_found", "transacción no encontrada", map[string]any{"transaction_id": tx.ID}, 404)
+}
+
+func (r *InMemoryParcelPaymentRepository) ListPendingCashboxPostings(ctx context.Context, now time.Time, limit int) ([]domain.PaymentTransaction, error) {
+	_, span := tracing.Start(ctx, "ParcelPaymentRepository.ListPendingCashboxPostings")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.txs == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio pagos no inicializado", nil)
+	}
+
+	out := make([]domain.PaymentTransaction, 0)
+	for _, byParcel := range r.txs {
+		for _, ledger := range byParcel {
+			for _, tx := range ledger {
+				if cashboxPostingDue(tx, now) {
+					out = append(out, tx)
+				}
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// restoreTransactionOnRollback vuelve la transacción del ledger a prev si se revierte la unidad de trabajo de ctx.
+func (r *InMemoryParcelPaymentRepository) restoreTransactionOnRollback(ctx context.Context, tenantID string, parcelID uuid.UUID, prev domain.PaymentTransaction) {
+	memory.OnRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		ledger := r.txs[tenantID][parcelID]
+		for i := range ledger {
+			if ledger[i].ID == prev.ID {
+				ledger[i] = prev
+				return
+			}
+		}
+	})
+}
+
+func cashboxPostingDue(tx domain.PaymentTransaction, now time.Time) bool {
+	switch tx.CashboxPostingStatus {
+	case domain.CashboxPostingPending, domain.CashboxPostingInProgress:
+		return tx.CashboxNextAttemptAt == nil || !now.Before(*tx.CashboxNextAttemptAt)
+	}
+	return false
+}
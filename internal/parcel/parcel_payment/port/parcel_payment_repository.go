@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -11,4 +12,18 @@ import (
 type ParcelPaymentRepository interface {
 	Upsert(ctx context.Context, tenantID string, p domain.ParcelPayment) (*domain.ParcelPayment, error)
 	GetByParcelID(ctx context.Context, tenantID string, parcelID uuid.UUID) (*domain.ParcelPayment, error)
+	// LockByParcelID lee el pago y, dentro de una unidad de trabajo, lo bloquea hasta el commit para
+	// que los límites que dependen del ledger (devoluciones, anulaciones) se validen sin carreras.
+	LockByParcelID(ctx context.Context, tenantID string, parcelID uuid.UUID) (*domain.ParcelPayment, error)
+
+	// Ledger de transacciones del envío (orden de registro)
+	AddTransaction(ctx context.Context, tenantID string, tx domain.PaymentTransaction) (*domain.PaymentTransaction, error)
+	ListTransactions(ctx context.Context, tenantID string, parcelID uuid.UUID) ([]domain.PaymentTransaction, error)
+
+	// Posteo a caja. ClaimCashboxPosting toma la transacción (PENDING, o POSTING con lease vencido)
+	// y la deja POSTING hasta now+lease; devuelve nil si ya fue posteada o la tiene otro proceso.
+	ClaimCashboxPosting(ctx context.Context, tenantID string, parcelID uuid.UUID, txID string, now time.Time, lease time.Duration) (*domain.PaymentTransaction, error)
+	SaveCashboxPosting(ctx context.Context, tenantID string, tx domain.PaymentTransaction) error
+	// ListPendingCashboxPostings lista (todos los tenants) las transacciones listas para reintentar.
+	ListPendingCashboxPostings(ctx context.Context, now time.Time, limit int) ([]domain.PaymentTransaction, error)
 }
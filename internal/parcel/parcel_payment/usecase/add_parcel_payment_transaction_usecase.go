@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+
+	coredomain "ms-parcel-core/internal/parcel/parcel_core/domain"
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_payment/domain"
+	"ms-parcel-core/internal/parcel/parcel_payment/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type AddParcelPaymentTransactionInput struct {
+	TenantID     string
+	UserID       *string
+	ParcelID     uuid.UUID
+	PaymentType  domain.PaymentType
+	Channel      domain.PaymentChannel
+	Amount       float64
+	Currency     *domain.Currency
+	OfficeID     *string
+	CashboxID    *string
+	SellerUserID *string
+	Notes        *string
+}
+
+type AddParcelPaymentTransactionResult struct {
+	Payment     *domain.ParcelPayment
+	Transaction *domain.PaymentTransaction
+}
+
+// AddParcelPaymentTransactionUseCase registra un cobro parcial o total contra el pago del envío.
+type AddParcelPaymentTransactionUseCase struct {
+	parcelRepo  coreport.ParcelReader
+	paymentRepo port.ParcelPaymentRepository
+	opts        coreport.ParcelOptionsResolver
+	cashbox     coreport.CashboxClient
+	features    coreport.FeatureGate
+	uow         coreport.UnitOfWork
+	events      coreport.EventOutbox
+	metrics     coreport.ParcelMetrics
+	logger      *slog.Logger
+}
+
+func NewAddParcelPaymentTransactionUseCase(parcelRepo coreport.ParcelReader, paymentRepo port.ParcelPaymentRepository, opts coreport.ParcelOptionsResolver, cashbox coreport.CashboxClient, features coreport.FeatureGate, uow coreport.UnitOfWork, events coreport.EventOutbox, metrics coreport.ParcelMetrics, logger *slog.Logger) *AddParcelPaymentTransactionUseCase {
+	return &AddParcelPaymentTransactionUseCase{parcelRepo: parcelRepo, paymentRepo: paymentRepo, opts: opts, cashbox: cashbox, features: features, uow: coreport.UnitOfWorkOrDirect(uow), events: coreport.EventOutboxOrNop(events), metrics: coreport.MetricsOrNop(metrics), logger: logging.OrDiscard(logger)}
+}
+
+func (u *AddParcelPaymentTransactionUseCase) Execute(ctx context.Context, in AddParcelPaymentTransactionInput) (*AddParcelPaymentTransactionResult, error) {
+	ctx, span := tracing.StartUseCase(ctx, "AddParcelPaymentTransaction", tracing.TenantID(in.TenantID), tracing.ParcelID(in.ParcelID.String()))
+	defer span.End()
+
+	if strings.TrimSpace(in.TenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	if err := coreport.RequireFeature(ctx, u.features, in.TenantID, coreport.FeaturePayments); err != nil {
+		return nil, err
+	}
+	if in.ParcelID == uuid.Nil {
+		return nil, apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"})
+	}
+	if domain.RoundAmount(in.Amount) <= 0 {
+		return nil, apperror.NewBadRequest("validation_error", "amount debe ser > 0", map[string]any{"field": "amount"})
+	}
+	if in.PaymentType == domain.PaymentTypeFree || strings.TrimSpace(string(in.PaymentType)) == "" {
+		return nil, apperror.NewBadRequest("validation_error", "payment_type inválido", map[string]any{"field": "payment_type"})
+	}
+
+	ch := in.Channel
+	if strings.TrimSpace(string(ch)) == "" {
+		ch = domain.PaymentChannelCounter
+	}
+	switch ch {
+	case domain.PaymentChannelCounter, domain.PaymentChannelWeb:
+	default:
+		return nil, apperror.NewBadRequest("validation_error", "channel inválido", map[string]any{"field": "channel"})
+	}
+	if ch == domain.PaymentChannelCounter {
+		if in.OfficeID == nil || strings.TrimSpace(*in.OfficeID) == "" {
+			return nil, apperror.NewBadRequest("validation_error", "office_id requerido", map[string]any{"field": "office_id"})
+		}
+		if err := checkCashboxOpen(ctx, u.logger, u.cashbox, u.opts, in.TenantID, in.OfficeID, in.CashboxID); err != nil {
+			return nil, err
+		}
+	}
+
+	p, err := u.parcelRepo.GetByID(ctx, in.TenantID, in.ParcelID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, apperror.New("not_found", "parcel no encontrado", map[string]any{"id": in.ParcelID.String()}, 404)
+	}
+
+	// Cobrar después del registro en origen es cobro en destino: rigen las opciones de la oficina destino.
+	// Se resuelven antes de bloquear el pago para no esperar al servicio de configuración con el lock tomado.
+	atOrigin := p.Status == coredomain.ParcelStatusCreated || p.Status == coredomain.ParcelStatusRegistered
+	allowPayInDestination := true
+	if !atOrigin {
+		opts := coreport.DefaultParcelOptions()
+		if u.opts != nil {
+			opts = u.opts.Resolve(ctx, in.TenantID, p.DestinationOfficeID)
+		}
+		allowPayInDestination = opts.AllowPayInDestination
+	}
+
+	// Las reglas que dependen del pago se validan sobre la fila bloqueada
+	updated, tx, statusBefore, err := recordTransaction(ctx, u.uow, u.events, u.paymentRepo, in.TenantID, in.ParcelID, func(pay *domain.ParcelPayment) (*domain.PaymentTransaction, error) {
+		if pay.PaymentType == domain.PaymentTypeFree {
+			return nil, apperror.New("invalid_state", "un pago FREE no admite cobros", nil, 409)
+		}
+		if in.Currency != nil && *in.Currency != pay.Currency {
+			return nil, apperror.NewBadRequest("validation_error", "currency distinta a la del pago", map[string]any{"field": "currency", "expected": pay.Currency})
+		}
+		if !allowPayInDestination {
+			return nil, apperror.New("pay_in_destination_disabled", "pago en destino deshabilitado", nil, 409)
+		}
+		return &domain.PaymentTransaction{
+			Kind:            domain.PaymentTransactionCharge,
+			PaymentType:     in.PaymentType,
+			Channel:         ch,
+			Amount:          in.Amount,
+			OfficeID:        in.OfficeID,
+			CashboxID:       in.CashboxID,
+			SellerUserID:    in.SellerUserID,
+			CreatedByUserID: in.UserID,
+			Notes:           in.Notes,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	tx = postCashboxMovement(ctx, u.logger, u.paymentRepo, u.cashbox, in.TenantID, tx)
+	observePaid(u.metrics, statusBefore, updated)
+
+	return &AddParcelPaymentTransactionResult{Payment: updated, Transaction: tx}, nil
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_payment/domain"
+	"ms-parcel-core/internal/parcel/parcel_payment/port"
+)
+
+const (
+	// cashboxPostingLease es cuánto se reserva una transacción mientras se postea;
+	// si el proceso muere, pasado el lease otro la puede tomar.
+	cashboxPostingLease       = 2 * time.Minute
+	cashboxPostingMaxAttempts = 10
+	cashboxPostingBaseBackoff = 30 * time.Second
+	cashboxPostingMaxBackoff  = 30 * time.Minute
+)
+
+// postCashboxMovement registra en ms-cashbox el movimiento de una transacción con caja.
+// Nunca falla la operación de pago: si el posteo falla queda PENDING para el job de reintentos.
+// Devuelve el estado de la transacción tras el intento.
+func postCashboxMovement(ctx context.Context, logger *slog.Logger, repo port.ParcelPaymentRepository, cashbox coreport.CashboxClient, tenantID string, tx *domain.PaymentTransaction) *domain.PaymentTransaction {
+	if tx == nil || !tx.RequiresCashboxPosting() || cashbox == nil {
+		return tx
+	}
+	parcelID, err := uuid.Parse(tx.ParcelID)
+	if err != nil {
+		return tx
+	}
+
+	now := time.Now().UTC()
+	claimed, err := repo.ClaimCashboxPosting(ctx, tenantID, parcelID, tx.ID, now, cashboxPostingLease)
+	if err != nil || claimed == nil {
+		// ya posteada o en curso en otro proceso: nunca se postea dos veces
+		return tx
+	}
+
+	movementID, err := cashbox.RegisterMovement(ctx, tenantID, cashboxMovementFromTransaction(*claimed))
+	claimed.CashboxPostingAttempts++
+	if err != nil {
+		msg := err.Error()
+		claimed.CashboxPostingError = &msg
+		if claimed.CashboxPostingAttempts >= cashboxPostingMaxAttempts {
+			claimed.CashboxPostingStatus = domain.CashboxPostingFailed
+			claimed.CashboxNextAttemptAt = nil
+			logger.ErrorContext(ctx, "movimiento de caja descartado tras agotar reintentos", "parcel_id", claimed.ParcelID, "transaction_id", claimed.ID, "attempts", claimed.CashboxPostingAttempts, "error", err)
+		} else {
+			next := now.Add(cashboxPostingBackoff(claimed.CashboxPostingAttempts))
+			claimed.CashboxPostingStatus = domain.CashboxPostingPending
+			claimed.CashboxNextAttemptAt = &next
+			logger.WarnContext(ctx, "no se pudo registrar movimiento de caja, queda pendiente", "parcel_id", claimed.ParcelID, "transaction_id", claimed.ID, "attempts", claimed.CashboxPostingAttempts, "next_attempt_at", next, "error", err)
+		}
+	} else {
+		claimed.CashboxMovementID = &movementID
+		claimed.CashboxPostingStatus = domain.CashboxPostingPosted
+		claimed.CashboxPostingError = nil
+		claimed.CashboxNextAttemptAt = nil
+		claimed.CashboxPostedAt = &now
+	}
+
+	if err := repo.SaveCashboxPosting(ctx, tenantID, *claimed); err != nil {
+		// el lease vence y el job reintenta; la idempotency key evita duplicar
+		logger.ErrorContext(ctx, "no se pudo guardar el estado del movimiento de caja", "parcel_id", claimed.ParcelID, "transaction_id", claimed.ID, "error", err)
+		return tx
+	}
+	return claimed
+}
+
+func cashboxMovementFromTransaction(tx domain.PaymentTransaction) coreport.CashboxMovementDTO {
+	movementType := coreport.CashboxMovementIncome
+	if tx.Kind == domain.PaymentTransactionRefund || tx.Kind == domain.PaymentTransactionVoid {
+		movementType = coreport.CashboxMovementRefund
+	}
+	notes := derefString(tx.Notes)
+	if tx.Reason != nil {
+		notes = *tx.Reason
+	}
+	return coreport.CashboxMovementDTO{
+		IdempotencyKey:  tx.ID,
+		CashboxID:       derefString(tx.CashboxID),
+		OfficeID:        derefString(tx.OfficeID),
+		Type:            movementType,
+		ParcelID:        tx.ParcelID,
+		Amount:          tx.Amount,
+		Currency:        string(tx.Currency),
+		PaymentType:     string(tx.PaymentType),
+		SellerUserID:    derefString(tx.SellerUserID),
+		CreatedByUserID: derefString(tx.CreatedByUserID),
+		Notes:           notes,
+	}
+}
+
+func cashboxPostingBackoff(attempts int) time.Duration {
+	d := cashboxPostingBaseBackoff
+	for i := 1; i < attempts && d < cashboxPostingMaxBackoff; i++ {
+		d *= 2
+	}
+	if d > cashboxPostingMaxBackoff {
+		d = cashboxPostingMaxBackoff
+	}
+	return d
+}
@@ -9,6 +9,7 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_payment/domain"
 	"ms-parcel-core/internal/parcel/parcel_payment/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type GetParcelPaymentUseCase struct {
@@ -20,6 +21,9 @@ func NewGetParcelPaymentUseCase(repo port.ParcelPaymentRepository) *GetParcelPay
 }
 
 func (u *GetParcelPaymentUseCase) Execute(ctx context.Context, tenantID string, parcelID uuid.UUID) (*domain.ParcelPayment, error) {
+	ctx, span := tracing.StartUseCase(ctx, "GetParcelPayment", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
 	if strings.TrimSpace(tenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/parcel/parcel_payment/domain"
+	"ms-parcel-core/internal/parcel/parcel_payment/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type ListParcelPaymentTransactionsUseCase struct {
+	paymentRepo port.ParcelPaymentRepository
+}
+
+func NewListParcelPaymentTransactionsUseCase(paymentRepo port.ParcelPaymentRepository) *ListParcelPaymentTransactionsUseCase {
+	return &ListParcelPaymentTransactionsUseCase{paymentRepo: paymentRepo}
+}
+
+func (u *ListParcelPaymentTransactionsUseCase) Execute(ctx context.Context, tenantID string, parcelID uuid.UUID) ([]domain.PaymentTransaction, error) {
+	ctx, span := tracing.StartUseCase(ctx, "ListParcelPaymentTransactions", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	if strings.TrimSpace(tenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	if parcelID == uuid.Nil {
+		return nil, apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"})
+	}
+	return u.paymentRepo.ListTransactions(ctx, tenantID, parcelID)
+}
@@ -2,8 +2,8 @@ package usecase
 
 import (
 	"context"
+	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -12,22 +12,36 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_payment/domain"
 	"ms-parcel-core/internal/parcel/parcel_payment/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type MarkPaidParcelPaymentUseCase struct {
 	parcelRepo  coreport.ParcelReader
 	paymentRepo port.ParcelPaymentRepository
-	opts        coreport.TenantOptionsProvider
+	opts        coreport.ParcelOptionsResolver
+	cashbox     coreport.CashboxClient
+	features    coreport.FeatureGate
+	uow         coreport.UnitOfWork
+	events      coreport.EventOutbox
+	metrics     coreport.ParcelMetrics
+	logger      *slog.Logger
 }
 
-func NewMarkPaidParcelPaymentUseCase(parcelRepo coreport.ParcelReader, paymentRepo port.ParcelPaymentRepository, opts coreport.TenantOptionsProvider) *MarkPaidParcelPaymentUseCase {
-	return &MarkPaidParcelPaymentUseCase{parcelRepo: parcelRepo, paymentRepo: paymentRepo, opts: opts}
+func NewMarkPaidParcelPaymentUseCase(parcelRepo coreport.ParcelReader, paymentRepo port.ParcelPaymentRepository, opts coreport.ParcelOptionsResolver, cashbox coreport.CashboxClient, features coreport.FeatureGate, uow coreport.UnitOfWork, events coreport.EventOutbox, metrics coreport.ParcelMetrics, logger *slog.Logger) *MarkPaidParcelPaymentUseCase {
+	return &MarkPaidParcelPaymentUseCase{parcelRepo: parcelRepo, paymentRepo: paymentRepo, opts: opts, cashbox: cashbox, features: features, uow: coreport.UnitOfWorkOrDirect(uow), events: coreport.EventOutboxOrNop(events), metrics: coreport.MetricsOrNop(metrics), logger: logging.OrDiscard(logger)}
 }
 
 func (u *MarkPaidParcelPaymentUseCase) Execute(ctx context.Context, tenantID string, parcelID uuid.UUID, userID *string) (*domain.ParcelPayment, error) {
+	ctx, span := tracing.StartUseCase(ctx, "MarkPaidParcelPayment", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
 	if strings.TrimSpace(tenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
+	if err := coreport.RequireFeature(ctx, u.features, tenantID, coreport.FeaturePayments); err != nil {
+		return nil, err
+	}
 	if parcelID == uuid.Nil {
 		return nil, apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"})
 	}
@@ -52,24 +66,18 @@ func (u *MarkPaidParcelPaymentUseCase) Execute(ctx context.Context, tenantID str
 		pay.Channel = domain.PaymentChannelCounter
 	}
 
-	defaults := coreport.ParcelOptions{
-		RequirePackageKey:       true,
-		UsePriceTable:           true,
-		AllowManualPrice:        false,
-		AllowOverridePriceTable: true,
-		AllowPayInDestination:   false,
+	isDestinationPayment := pay.PaymentType == domain.PaymentTypeFOB || pay.PaymentType == domain.PaymentTypeCollectOnDelivery
+
+	// Pago en destino: rigen las opciones de la oficina destino; si no, las de origen
+	officeID := p.OriginOfficeID
+	if isDestinationPayment {
+		officeID = p.DestinationOfficeID
 	}
-	opts := defaults
+	opts := coreport.DefaultParcelOptions()
 	if u.opts != nil {
-		if o, err := u.opts.GetParcelOptions(ctx, tenantID); err == nil {
-			opts = o
-		} else {
-			// TODO: logger
-		}
+		opts = u.opts.Resolve(ctx, tenantID, officeID)
 	}
 
-	isDestinationPayment := pay.PaymentType == domain.PaymentTypeFOB || pay.PaymentType == domain.PaymentTypeCollectOnDelivery
-
 	if isDestinationPayment {
 		if !opts.AllowPayInDestination {
 			return nil, apperror.New("pay_in_destination_disabled", "pago en destino deshabilitado", nil, 409)
@@ -102,11 +110,42 @@ func (u *MarkPaidParcelPaymentUseCase) Execute(ctx context.Context, tenantID str
 		}
 	}
 
-	now := time.Now().UTC()
-	pay.Status = domain.PaymentStatusPaid
-	pay.PaidAt = &now
-	pay.PaidByUserID = userID
-	pay.UpdatedAt = now
+	if err := refreshLedger(ctx, u.paymentRepo, tenantID, pay); err != nil {
+		return nil, err
+	}
+	// Idempotente: sin saldo pendiente no se registra otro cobro
+	if pay.Balance <= 0 {
+		return pay, nil
+	}
 
-	return u.paymentRepo.Upsert(ctx, tenantID, *pay)
+	// Cobra el saldo pendiente en una sola transacción con los datos del pago bloqueado: otro cobro
+	// concurrente pudo saldarlo o cambiar el tipo de pago validado arriba
+	updated, tx, statusBefore, err := recordTransaction(ctx, u.uow, u.events, u.paymentRepo, tenantID, parcelID, func(locked *domain.ParcelPayment) (*domain.PaymentTransaction, error) {
+		if locked.PaymentType != pay.PaymentType {
+			return nil, apperror.New("invalid_state", "el tipo de pago cambió durante la operación", map[string]any{"expected": pay.PaymentType, "actual": locked.PaymentType}, 409)
+		}
+		if locked.Balance <= 0 {
+			return nil, nil
+		}
+		if strings.TrimSpace(string(locked.Channel)) == "" {
+			locked.Channel = domain.PaymentChannelCounter
+		}
+		return &domain.PaymentTransaction{
+			Kind:            domain.PaymentTransactionCharge,
+			PaymentType:     locked.PaymentType,
+			Channel:         locked.Channel,
+			Amount:          locked.Balance,
+			OfficeID:        locked.OfficeID,
+			CashboxID:       locked.CashboxID,
+			SellerUserID:    locked.SellerUserID,
+			CreatedByUserID: userID,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// El ingreso a caja se registra aparte: si falla queda pendiente y lo reintenta el job
+	_ = postCashboxMovement(ctx, u.logger, u.paymentRepo, u.cashbox, tenantID, tx)
+	observePaid(u.metrics, statusBefore, updated)
+	return updated, nil
 }
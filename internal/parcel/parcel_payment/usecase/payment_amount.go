@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"strings"
+
+	itemdomain "ms-parcel-core/internal/parcel/parcel_item/domain"
+	"ms-parcel-core/internal/parcel/parcel_payment/domain"
+	"ms-parcel-core/internal/pkg/util/apperror"
+)
+
+const maxSurcharges = 20
+
+func sumItemsAmount(items []itemdomain.ParcelItem) float64 {
+	total := 0.0
+	for _, it := range items {
+		total += it.LineTotal()
+	}
+	return domain.RoundAmount(total)
+}
+
+// validateSurcharges normaliza los recargos y devuelve su total.
+func validateSurcharges(surcharges []domain.PaymentSurcharge) (float64, error) {
+	if len(surcharges) > maxSurcharges {
+		return 0, apperror.NewBadRequest("validation_error", "demasiados recargos", map[string]any{"field": "surcharges", "max": maxSurcharges})
+	}
+	total := 0.0
+	for i := range surcharges {
+		surcharges[i].Code = strings.ToUpper(strings.TrimSpace(surcharges[i].Code))
+		if surcharges[i].Code == "" {
+			return 0, apperror.NewBadRequest("validation_error", "code de recargo requerido", map[string]any{"field": "surcharges", "index": i})
+		}
+		if surcharges[i].Amount < 0 {
+			return 0, apperror.NewBadRequest("validation_error", "amount de recargo inválido", map[string]any{"field": "surcharges", "index": i})
+		}
+		surcharges[i].Amount = domain.RoundAmount(surcharges[i].Amount)
+		total += surcharges[i].Amount
+	}
+	return domain.RoundAmount(total), nil
+}
@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_payment/domain"
+	"ms-parcel-core/internal/parcel/parcel_payment/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+)
+
+// refreshLedger recalcula PaidAmount/Balance/Status del pago desde sus transacciones.
+// Si el pago deja de estar saldado (p.ej. subió el monto) se limpia PaidAt, salvo que
+// sea por devoluciones: ahí PaidAt conserva cuándo se cobró.
+func refreshLedger(ctx context.Context, repo port.ParcelPaymentRepository, tenantID string, pay *domain.ParcelPayment) error {
+	parcelID, err := uuid.Parse(pay.ParcelID)
+	if err != nil {
+		return apperror.NewInternal("internal_error", "parcel_id inválido en pago", map[string]any{"parcel_id": pay.ParcelID})
+	}
+	txs, err := repo.ListTransactions(ctx, tenantID, parcelID)
+	if err != nil {
+		return err
+	}
+	pay.ApplyLedger(txs)
+	if !pay.IsSettled() && pay.RefundedAmount == 0 {
+		pay.PaidAt = nil
+		pay.PaidByUserID = nil
+	}
+	return nil
+}
+
+// lockPayment relee el pago bloqueado dentro de la unidad de trabajo de ctx, con el ledger al día.
+// Las reglas validadas antes de abrirla se repiten con él: otro cobro, devolución, anulación o
+// recálculo concurrente pudo confirmarse entre medio.
+func lockPayment(ctx context.Context, repo port.ParcelPaymentRepository, tenantID string, parcelID uuid.UUID) (*domain.ParcelPayment, error) {
+	pay, err := repo.LockByParcelID(ctx, tenantID, parcelID)
+	if err != nil {
+		return nil, err
+	}
+	if pay == nil {
+		return nil, apperror.New("not_found", "pago no encontrado", map[string]any{"parcel_id": parcelID.String()}, 404)
+	}
+	if err := refreshLedger(ctx, repo, tenantID, pay); err != nil {
+		return nil, err
+	}
+	return pay, nil
+}
+
+// buildTransaction arma la transacción a registrar a partir del pago ya bloqueado, validando contra
+// él las reglas que dependen de su estado. Devolver nil no registra nada (p.ej. ya no hay saldo).
+type buildTransaction func(pay *domain.ParcelPayment) (*domain.PaymentTransaction, error)
+
+// recordTransaction bloquea el pago, arma la transacción con build, la agrega al ledger, recalcula el
+// estado, persiste el pago y encola los eventos de integración, todo en una unidad de trabajo (o en la
+// del llamador si ya hay una). Devuelve también el estado del pago bloqueado antes de la transacción.
+// PaidAt/PaidByUserID se fijan la primera vez que el pago queda saldado.
+func recordTransaction(ctx context.Context, uow coreport.UnitOfWork, events coreport.EventOutbox, repo port.ParcelPaymentRepository, tenantID string, parcelID uuid.UUID, build buildTransaction) (*domain.ParcelPayment, *domain.PaymentTransaction, domain.PaymentStatus, error) {
+	var statusBefore domain.PaymentStatus
+	var saved *domain.PaymentTransaction
+	var updated *domain.ParcelPayment
+	err := uow.Do(ctx, func(ctx context.Context) error {
+		pay, err := lockPayment(ctx, repo, tenantID, parcelID)
+		if err != nil {
+			return err
+		}
+		statusBefore = pay.Status
+		built, err := build(pay)
+		if err != nil {
+			return err
+		}
+		if built == nil {
+			updated = pay
+			return nil
+		}
+
+		now := time.Now().UTC()
+		tx := *built
+		tx.ID = uuid.NewString()
+		tx.TenantID = tenantID
+		tx.ParcelID = pay.ParcelID
+		tx.PaymentID = pay.ID
+		tx.Currency = pay.Currency
+		tx.Amount = domain.RoundAmount(tx.Amount)
+		tx.CreatedAt = now
+		if tx.RequiresCashboxPosting() {
+			tx.CashboxPostingStatus = domain.CashboxPostingPending
+		}
+
+		saved, err = repo.AddTransaction(ctx, tenantID, tx)
+		if err != nil {
+			return err
+		}
+
+		if err := refreshLedger(ctx, repo, tenantID, pay); err != nil {
+			return err
+		}
+		if pay.IsSettled() && pay.PaidAt == nil {
+			pay.PaidAt = &now
+			pay.PaidByUserID = tx.CreatedByUserID
+		}
+		pay.UpdatedAt = now
+
+		updated, err = repo.Upsert(ctx, tenantID, *pay)
+		if err != nil {
+			return err
+		}
+		return enqueuePaymentEvents(ctx, events, tenantID, statusBefore, updated, saved)
+	})
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return updated, saved, statusBefore, nil
+}
+
+// paymentEventTypes indica qué evento de integración publica cada tipo de transacción.
+var paymentEventTypes = map[domain.PaymentTransactionKind]string{
+	domain.PaymentTransactionCharge: coreport.DomainEventPaymentCharged,
+	domain.PaymentTransactionRefund: coreport.DomainEventPaymentRefunded,
+	domain.PaymentTransactionVoid:   coreport.DomainEventPaymentVoided,
+}
+
+// enqueuePaymentEvents encola el evento de la transacción y, si con ella el pago quedó PAID, también
+// payment.paid (una sola vez por saldado, aunque se llegue con varios cobros parciales).
+func enqueuePaymentEvents(ctx context.Context, events coreport.EventOutbox, tenantID string, statusBefore domain.PaymentStatus, pay *domain.ParcelPayment, tx *domain.PaymentTransaction) error {
+	data := map[string]any{
+		"payment_id":      pay.ID,
+		"parcel_id":       pay.ParcelID,
+		"payment_type":    string(pay.PaymentType),
+		"payment_status":  string(pay.Status),
+		"currency":        string(pay.Currency),
+		"amount":          pay.Amount,
+		"paid_amount":     pay.PaidAmount,
+		"refunded_amount": pay.RefundedAmount,
+		"balance":         pay.Balance,
+		"transaction": map[string]any{
+			"id":                      tx.ID,
+			"kind":                    string(tx.Kind),
+			"payment_type":            string(tx.PaymentType),
+			"channel":                 string(tx.Channel),
+			"amount":                  tx.Amount,
+			"office_id":               derefString(tx.OfficeID),
+			"cashbox_id":              derefString(tx.CashboxID),
+			"created_by_user_id":      derefString(tx.CreatedByUserID),
+			"reason":                  derefString(tx.Reason),
+			"reverses_transaction_id": derefString(tx.ReversesTransactionID),
+		},
+	}
+	types := []string{paymentEventTypes[tx.Kind]}
+	if statusBefore != domain.PaymentStatusPaid && pay.Status == domain.PaymentStatusPaid {
+		types = append(types, coreport.DomainEventPaymentPaid)
+	}
+	for _, t := range types {
+		if t == "" {
+			continue
+		}
+		if err := events.Enqueue(ctx, tenantID, coreport.DomainEventDTO{
+			Type:          t,
+			AggregateType: coreport.AggregatePayment,
+			AggregateID:   pay.ID,
+			OccurredAt:    tx.CreatedAt,
+			Data:          data,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCashboxOpen valida que la caja indicada esté abierta (si hay cliente configurado).
+// Si ms-cashbox no responde decide la opción CashboxFailOpen del tenant; sin resolver, falla cerrado.
+func checkCashboxOpen(ctx context.Context, logger *slog.Logger, cashbox coreport.CashboxClient, opts coreport.ParcelOptionsResolver, tenantID string, officeID *string, cashboxID *string) error {
+	if cashboxID == nil || strings.TrimSpace(*cashboxID) == "" || cashbox == nil {
+		return nil
+	}
+	id := strings.TrimSpace(*cashboxID)
+	open, err := cashbox.IsOpen(ctx, tenantID, id)
+	if err != nil {
+		if opts != nil && opts.Resolve(ctx, tenantID, derefString(officeID)).CashboxFailOpen {
+			logger.WarnContext(ctx, "no se pudo verificar la caja, se asume abierta", "cashbox_id", id, "error", err)
+			return nil
+		}
+		return apperror.New("cashbox_unavailable", "no se pudo verificar la caja", map[string]any{"cashbox_id": id}, 503)
+	}
+	if !open {
+		return apperror.New("cashbox_closed", "caja cerrada", map[string]any{"cashbox_id": id}, 409)
+	}
+	return nil
+}
+
+// requireCashboxOpen es la variante estricta para salidas de dinero (devoluciones/anulaciones):
+// la caja es obligatoria y si no se puede verificar se rechaza la operación.
+func requireCashboxOpen(ctx context.Context, cashbox coreport.CashboxClient, tenantID string, cashboxID *string) error {
+	if cashboxID == nil || strings.TrimSpace(*cashboxID) == "" {
+		return apperror.NewBadRequest("validation_error", "cashbox_id requerido", map[string]any{"field": "cashbox_id"})
+	}
+	if cashbox == nil {
+		return apperror.New("cashbox_unavailable", "servicio de caja no configurado", nil, 503)
+	}
+	id := strings.TrimSpace(*cashboxID)
+	open, err := cashbox.IsOpen(ctx, tenantID, id)
+	if err != nil {
+		return apperror.New("cashbox_unavailable", "no se pudo verificar la caja", map[string]any{"cashbox_id": id}, 503)
+	}
+	if !open {
+		return apperror.New("cashbox_closed", "caja cerrada", map[string]any{"cashbox_id": id}, 409)
+	}
+	return nil
+}
+
+// validateReversalApproval valida motivo y aprobador de una devolución o anulación.
+func validateReversalApproval(reason string, approvedByUserID string, userID *string) error {
+	if strings.TrimSpace(reason) == "" {
+		return apperror.NewBadRequest("validation_error", "reason requerido", map[string]any{"field": "reason"})
+	}
+	approver := strings.TrimSpace(approvedByUserID)
+	if approver == "" {
+		return apperror.NewBadRequest("validation_error", "approved_by_user_id requerido", map[string]any{"field": "approved_by_user_id"})
+	}
+	if userID != nil && strings.TrimSpace(*userID) == approver {
+		return apperror.NewBadRequest("validation_error", "el aprobador debe ser distinto del operador", map[string]any{"field": "approved_by_user_id"})
+	}
+	return nil
+}
+
+// observePaid cuenta el pago como pagado solo cuando el cobro lo llevó a PAID.
+func observePaid(m coreport.ParcelMetrics, before domain.PaymentStatus, updated *domain.ParcelPayment) {
+	if updated != nil && before != domain.PaymentStatusPaid && updated.Status == domain.PaymentStatusPaid {
+		m.PaymentMarkedPaid(string(updated.PaymentType))
+	}
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	itemport "ms-parcel-core/internal/parcel/parcel_item/port"
+	"ms-parcel-core/internal/parcel/parcel_payment/domain"
+	"ms-parcel-core/internal/parcel/parcel_payment/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+// RecalculateParcelPaymentUseCase sincroniza el monto del pago cuando cambian los items.
+// Solo recalcula pagos con saldo pendiente y monto AUTO; los manuales o ya saldados quedan marcados
+// como desactualizados (AmountStale) para que el operador decida. El pago se relee bloqueado para no
+// pisar un cobro concurrente.
+type RecalculateParcelPaymentUseCase struct {
+	paymentRepo port.ParcelPaymentRepository
+	itemRepo    itemport.ParcelItemRepository
+	uow         coreport.UnitOfWork
+}
+
+func NewRecalculateParcelPaymentUseCase(paymentRepo port.ParcelPaymentRepository, itemRepo itemport.ParcelItemRepository, uow coreport.UnitOfWork) *RecalculateParcelPaymentUseCase {
+	return &RecalculateParcelPaymentUseCase{paymentRepo: paymentRepo, itemRepo: itemRepo, uow: coreport.UnitOfWorkOrDirect(uow)}
+}
+
+// Execute devuelve nil si el envío aún no tiene pago.
+func (u *RecalculateParcelPaymentUseCase) Execute(ctx context.Context, tenantID string, parcelID uuid.UUID) (*domain.ParcelPayment, error) {
+	ctx, span := tracing.StartUseCase(ctx, "RecalculateParcelPayment", tracing.TenantID(tenantID), tracing.ParcelID(parcelID.String()))
+	defer span.End()
+
+	if strings.TrimSpace(tenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	if parcelID == uuid.Nil {
+		return nil, apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"})
+	}
+
+	return coreport.WithinUnitOfWork(ctx, u.uow, func(ctx context.Context) (*domain.ParcelPayment, error) {
+		return u.recalculate(ctx, tenantID, parcelID)
+	})
+}
+
+func (u *RecalculateParcelPaymentUseCase) recalculate(ctx context.Context, tenantID string, parcelID uuid.UUID) (*domain.ParcelPayment, error) {
+	pay, err := u.paymentRepo.LockByParcelID(ctx, tenantID, parcelID)
+	if err != nil {
+		return nil, err
+	}
+	if pay == nil {
+		return nil, nil
+	}
+
+	items, err := u.itemRepo.ListByParcelID(ctx, tenantID, parcelID)
+	if err != nil {
+		return nil, err
+	}
+	itemsAmount := sumItemsAmount(items)
+
+	now := time.Now().UTC()
+	pay.UpdatedAt = now
+
+	auto := pay.AmountSource == domain.PaymentAmountAuto || pay.AmountSource == ""
+	if !pay.IsSettled() && auto {
+		pay.ItemsAmount = itemsAmount
+		pay.AmountSource = domain.PaymentAmountAuto
+		pay.AmountCalculatedAt = &now
+		pay.AmountStale = false
+		if pay.PaymentType != domain.PaymentTypeFree {
+			pay.Amount = pay.CalculatedAmount()
+		}
+	} else {
+		pay.AmountStale = domain.RoundAmount(itemsAmount) != pay.ItemsAmount
+	}
+
+	if err := refreshLedger(ctx, u.paymentRepo, tenantID, pay); err != nil {
+		return nil, err
+	}
+
+	return u.paymentRepo.Upsert(ctx, tenantID, *pay)
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_payment/domain"
+	"ms-parcel-core/internal/parcel/parcel_payment/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type RefundParcelPaymentInput struct {
+	TenantID string
+	UserID   *string
+	UserName string
+	ParcelID uuid.UUID
+	// Amount nil = devolver todo lo cobrado (neto)
+	Amount           *float64
+	Reason           string
+	ApprovedByUserID string
+	PaymentType      domain.PaymentType
+	Channel          domain.PaymentChannel
+	OfficeID         *string
+	CashboxID        *string
+	Notes            *string
+}
+
+type RefundParcelPaymentResult struct {
+	Payment     *domain.ParcelPayment
+	Transaction *domain.PaymentTransaction
+}
+
+// RefundParcelPaymentUseCase devuelve total o parcialmente lo cobrado de un envío.
+// Toda devolución sale de una caja abierta y queda en el timeline del envío.
+type RefundParcelPaymentUseCase struct {
+	paymentRepo port.ParcelPaymentRepository
+	cashbox     coreport.CashboxClient
+	tracking    coreport.TrackingRecorder
+	uow         coreport.UnitOfWork
+	events      coreport.EventOutbox
+	features    coreport.FeatureGate
+	logger      *slog.Logger
+}
+
+func NewRefundParcelPaymentUseCase(paymentRepo port.ParcelPaymentRepository, cashbox coreport.CashboxClient, tracking coreport.TrackingRecorder, uow coreport.UnitOfWork, events coreport.EventOutbox, features coreport.FeatureGate, logger *slog.Logger) *RefundParcelPaymentUseCase {
+	return &RefundParcelPaymentUseCase{paymentRepo: paymentRepo, cashbox: cashbox, tracking: tracking, uow: coreport.UnitOfWorkOrDirect(uow), events: coreport.EventOutboxOrNop(events), features: features, logger: logging.OrDiscard(logger)}
+}
+
+func (u *RefundParcelPaymentUseCase) Execute(ctx context.Context, in RefundParcelPaymentInput) (*RefundParcelPaymentResult, error) {
+	ctx, span := tracing.StartUseCase(ctx, "RefundParcelPayment", tracing.TenantID(in.TenantID), tracing.ParcelID(in.ParcelID.String()))
+	defer span.End()
+
+	if strings.TrimSpace(in.TenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	if err := coreport.RequireFeature(ctx, u.features, in.TenantID, coreport.FeaturePayments); err != nil {
+		return nil, err
+	}
+	if in.ParcelID == uuid.Nil {
+		return nil, apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"})
+	}
+	if in.Amount != nil && domain.RoundAmount(*in.Amount) <= 0 {
+		return nil, apperror.NewBadRequest("validation_error", "amount debe ser > 0", map[string]any{"field": "amount"})
+	}
+	if err := validateReversalApproval(in.Reason, in.ApprovedByUserID, in.UserID); err != nil {
+		return nil, err
+	}
+
+	ch := in.Channel
+	if strings.TrimSpace(string(ch)) == "" {
+		ch = domain.PaymentChannelCounter
+	}
+	switch ch {
+	case domain.PaymentChannelCounter, domain.PaymentChannelWeb:
+	default:
+		return nil, apperror.NewBadRequest("validation_error", "channel inválido", map[string]any{"field": "channel"})
+	}
+	if ch == domain.PaymentChannelCounter && (in.OfficeID == nil || strings.TrimSpace(*in.OfficeID) == "") {
+		return nil, apperror.NewBadRequest("validation_error", "office_id requerido", map[string]any{"field": "office_id"})
+	}
+	pt := in.PaymentType
+	if strings.TrimSpace(string(pt)) == "" {
+		pt = domain.PaymentTypeCash
+	}
+	if pt == domain.PaymentTypeFree {
+		return nil, apperror.NewBadRequest("validation_error", "payment_type inválido", map[string]any{"field": "payment_type"})
+	}
+
+	pay, err := u.paymentRepo.GetByParcelID(ctx, in.TenantID, in.ParcelID)
+	if err != nil {
+		return nil, err
+	}
+	if pay == nil {
+		return nil, apperror.New("not_found", "pago no encontrado", map[string]any{"parcel_id": in.ParcelID.String()}, 404)
+	}
+	if err := refreshLedger(ctx, u.paymentRepo, in.TenantID, pay); err != nil {
+		return nil, err
+	}
+
+	amount := pay.PaidAmount
+	if in.Amount != nil {
+		amount = domain.RoundAmount(*in.Amount)
+	}
+	if err := validateRefundable(pay, amount); err != nil {
+		return nil, err
+	}
+
+	if err := requireCashboxOpen(ctx, u.cashbox, in.TenantID, in.CashboxID); err != nil {
+		return nil, err
+	}
+
+	reason := strings.TrimSpace(in.Reason)
+	approver := strings.TrimSpace(in.ApprovedByUserID)
+	// Ledger, pago y tracking se confirman juntos; el movimiento de caja va después del commit
+	// porque es una llamada externa con su propio reintento.
+	var updated *domain.ParcelPayment
+	var tx *domain.PaymentTransaction
+	err = u.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updated, tx, _, err = recordTransaction(ctx, u.uow, u.events, u.paymentRepo, in.TenantID, in.ParcelID, func(locked *domain.ParcelPayment) (*domain.PaymentTransaction, error) {
+			if err := validateRefundable(locked, amount); err != nil {
+				return nil, err
+			}
+			return &domain.PaymentTransaction{
+				Kind:             domain.PaymentTransactionRefund,
+				PaymentType:      pt,
+				Channel:          ch,
+				Amount:           amount,
+				OfficeID:         in.OfficeID,
+				CashboxID:        in.CashboxID,
+				CreatedByUserID:  in.UserID,
+				Notes:            in.Notes,
+				Reason:           &reason,
+				ApprovedByUserID: &approver,
+			}, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if u.tracking != nil {
+			if err := u.tracking.RecordEvent(ctx, in.TenantID, coreport.TrackingEventDTO{
+				ParcelID:   in.ParcelID.String(),
+				EventType:  coreport.EventTypePaymentRefunded,
+				OccurredAt: time.Now().UTC(),
+				UserID:     derefString(in.UserID),
+				UserName:   in.UserName,
+				Metadata: map[string]any{
+					"transaction_id":      tx.ID,
+					"amount":              tx.Amount,
+					"currency":            tx.Currency,
+					"reason":              reason,
+					"approved_by_user_id": approver,
+					"channel":             tx.Channel,
+					"cashbox_id":          derefString(tx.CashboxID),
+					"payment_status":      updated.Status,
+				},
+			}); err != nil {
+				u.logger.ErrorContext(ctx, "no se pudo registrar evento de tracking, se revierte la devolución", "parcel_id", in.ParcelID.String(), "event_type", coreport.EventTypePaymentRefunded, "error", err)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	tx = postCashboxMovement(ctx, u.logger, u.paymentRepo, u.cashbox, in.TenantID, tx)
+
+	return &RefundParcelPaymentResult{Payment: updated, Transaction: tx}, nil
+}
+
+// validateRefundable verifica que amount no supere lo cobrado (neto) del pago.
+func validateRefundable(pay *domain.ParcelPayment, amount float64) error {
+	refundable := pay.PaidAmount
+	if refundable <= 0 {
+		return apperror.New("invalid_state", "el pago no tiene montos cobrados para devolver", map[string]any{"status": pay.Status}, 409)
+	}
+	if amount > refundable {
+		return apperror.New("refund_exceeds_paid", "la devolución supera lo cobrado", map[string]any{"refundable": refundable, "amount": amount}, 409)
+	}
+	return nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
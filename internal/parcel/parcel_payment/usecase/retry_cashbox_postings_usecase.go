@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_payment/domain"
+	"ms-parcel-core/internal/parcel/parcel_payment/port"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type RetryCashboxPostingsResult struct {
+	Attempted int
+	Posted    int
+	Pending   int
+	Failed    int
+}
+
+// RetryCashboxPostingsUseCase reintenta los movimientos de caja que no se pudieron registrar.
+type RetryCashboxPostingsUseCase struct {
+	paymentRepo port.ParcelPaymentRepository
+	cashbox     coreport.CashboxClient
+	logger      *slog.Logger
+}
+
+func NewRetryCashboxPostingsUseCase(paymentRepo port.ParcelPaymentRepository, cashbox coreport.CashboxClient, logger *slog.Logger) *RetryCashboxPostingsUseCase {
+	return &RetryCashboxPostingsUseCase{paymentRepo: paymentRepo, cashbox: cashbox, logger: logging.OrDiscard(logger)}
+}
+
+func (u *RetryCashboxPostingsUseCase) Execute(ctx context.Context, limit int) (*RetryCashboxPostingsResult, error) {
+	ctx, span := tracing.StartUseCase(ctx, "RetryCashboxPostings")
+	defer span.End()
+
+	if limit <= 0 {
+		limit = 100
+	}
+
+	txs, err := u.paymentRepo.ListPendingCashboxPostings(ctx, time.Now().UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &RetryCashboxPostingsResult{}
+	for i := range txs {
+		if ctx.Err() != nil {
+			break
+		}
+		res.Attempted++
+		out := postCashboxMovement(ctx, u.logger, u.paymentRepo, u.cashbox, txs[i].TenantID, &txs[i])
+		switch out.CashboxPostingStatus {
+		case domain.CashboxPostingPosted:
+			res.Posted++
+		case domain.CashboxPostingFailed:
+			res.Failed++
+		default:
+			res.Pending++
+		}
+	}
+	return res, nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -9,9 +10,12 @@ import (
 
 	coredomain "ms-parcel-core/internal/parcel/parcel_core/domain"
 	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	itemport "ms-parcel-core/internal/parcel/parcel_item/port"
 	"ms-parcel-core/internal/parcel/parcel_payment/domain"
 	"ms-parcel-core/internal/parcel/parcel_payment/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type UpsertParcelPaymentInput struct {
@@ -19,8 +23,12 @@ type UpsertParcelPaymentInput struct {
 	ParcelID    uuid.UUID
 	PaymentType domain.PaymentType
 	Currency    domain.Currency
-	Amount      float64
-	Notes       *string
+	// Amount nil = calcular desde items + recargos. Si difiere del calculado es un
+	// override manual: requiere AllowManualPrice y ManualAmountReason.
+	Amount             *float64
+	ManualAmountReason *string
+	Surcharges         []domain.PaymentSurcharge
+	Notes              *string
 
 	Channel      domain.PaymentChannel
 	OfficeID     *string
@@ -31,27 +39,33 @@ type UpsertParcelPaymentInput struct {
 type UpsertParcelPaymentUseCase struct {
 	parcelRepo  coreport.ParcelReader
 	paymentRepo port.ParcelPaymentRepository
-	opts        coreport.TenantOptionsProvider
+	itemRepo    itemport.ParcelItemRepository
+	opts        coreport.ParcelOptionsResolver
 	cashbox     coreport.CashboxClient
+	features    coreport.FeatureGate
+	logger      *slog.Logger
 }
 
-func NewUpsertParcelPaymentUseCase(parcelRepo coreport.ParcelReader, paymentRepo port.ParcelPaymentRepository, opts coreport.TenantOptionsProvider, cashbox coreport.CashboxClient) *UpsertParcelPaymentUseCase {
-	return &UpsertParcelPaymentUseCase{parcelRepo: parcelRepo, paymentRepo: paymentRepo, opts: opts, cashbox: cashbox}
+func NewUpsertParcelPaymentUseCase(parcelRepo coreport.ParcelReader, paymentRepo port.ParcelPaymentRepository, itemRepo itemport.ParcelItemRepository, opts coreport.ParcelOptionsResolver, cashbox coreport.CashboxClient, features coreport.FeatureGate, logger *slog.Logger) *UpsertParcelPaymentUseCase {
+	return &UpsertParcelPaymentUseCase{parcelRepo: parcelRepo, paymentRepo: paymentRepo, itemRepo: itemRepo, opts: opts, cashbox: cashbox, features: features, logger: logging.OrDiscard(logger)}
 }
 
 func (u *UpsertParcelPaymentUseCase) Execute(ctx context.Context, in UpsertParcelPaymentInput) (*domain.ParcelPayment, error) {
+	ctx, span := tracing.StartUseCase(ctx, "UpsertParcelPayment", tracing.TenantID(in.TenantID), tracing.ParcelID(in.ParcelID.String()))
+	defer span.End()
+
 	if strings.TrimSpace(in.TenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
+	if err := coreport.RequireFeature(ctx, u.features, in.TenantID, coreport.FeaturePayments); err != nil {
+		return nil, err
+	}
 	if in.ParcelID == uuid.Nil {
 		return nil, apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"})
 	}
-	if in.Amount < 0 {
+	if in.Amount != nil && *in.Amount < 0 {
 		return nil, apperror.NewBadRequest("validation_error", "amount inválido", map[string]any{"field": "amount"})
 	}
-	if in.PaymentType != domain.PaymentTypeFree && in.Amount <= 0 {
-		return nil, apperror.NewBadRequest("validation_error", "amount debe ser > 0", map[string]any{"field": "amount"})
-	}
 
 	ch := in.Channel
 	if strings.TrimSpace(string(ch)) == "" {
@@ -68,13 +82,8 @@ func (u *UpsertParcelPaymentUseCase) Execute(ctx context.Context, in UpsertParce
 			return nil, apperror.NewBadRequest("validation_error", "office_id requerido", map[string]any{"field": "office_id"})
 		}
 
-		if in.CashboxID != nil && strings.TrimSpace(*in.CashboxID) != "" && u.cashbox != nil {
-			open, err := u.cashbox.IsOpen(ctx, in.TenantID, strings.TrimSpace(*in.CashboxID))
-			if err != nil {
-				// TODO: logger (no bloqueante)
-			} else if !open {
-				return nil, apperror.New("cashbox_closed", "caja cerrada", map[string]any{"cashbox_id": strings.TrimSpace(*in.CashboxID)}, 409)
-			}
+		if err := checkCashboxOpen(ctx, u.logger, u.cashbox, u.opts, in.TenantID, in.OfficeID, in.CashboxID); err != nil {
+			return nil, err
 		}
 	}
 
@@ -91,45 +100,79 @@ func (u *UpsertParcelPaymentUseCase) Execute(ctx context.Context, in UpsertParce
 		return nil, apperror.New("invalid_state", "no se puede modificar el pago en este estado", map[string]any{"allowed": []coredomain.ParcelStatus{coredomain.ParcelStatusCreated, coredomain.ParcelStatusRegistered}, "actual": p.Status}, 409)
 	}
 
-	defaults := coreport.ParcelOptions{
-		RequirePackageKey:       true,
-		UsePriceTable:           true,
-		AllowManualPrice:        false,
-		AllowOverridePriceTable: true,
-		AllowPayInDestination:   false,
-	}
-	opts := defaults
+	// El pago se define en origen; el pago en destino lo habilita la oficina que va a cobrarlo
+	opts := coreport.DefaultParcelOptions()
 	if u.opts != nil {
-		if o, err := u.opts.GetParcelOptions(ctx, in.TenantID); err == nil {
-			opts = o
-		} else {
-			// TODO: logger
-		}
+		opts = u.opts.Resolve(ctx, in.TenantID, p.OriginOfficeID)
 	}
 
-	if !opts.AllowPayInDestination {
-		if in.PaymentType == domain.PaymentTypeFOB || in.PaymentType == domain.PaymentTypeCollectOnDelivery {
-			return nil, apperror.New("pay_in_destination_disabled", "pago en destino deshabilitado", nil, 409)
+	if in.PaymentType == domain.PaymentTypeFOB || in.PaymentType == domain.PaymentTypeCollectOnDelivery {
+		destOpts := coreport.DefaultParcelOptions()
+		if u.opts != nil {
+			destOpts = u.opts.Resolve(ctx, in.TenantID, p.DestinationOfficeID)
+		}
+		if !destOpts.AllowPayInDestination {
+			return nil, apperror.New("pay_in_destination_disabled", "pago en destino deshabilitado", map[string]any{"destination_office_id": p.DestinationOfficeID}, 409)
 		}
 	}
 
-	// TODO: no calcular monto desde items aún.
-
-	now := time.Now().UTC()
 	existing, err := u.paymentRepo.GetByParcelID(ctx, in.TenantID, in.ParcelID)
 	if err != nil {
 		return nil, err
 	}
 
+	if existing != nil && existing.PaidAmount > 0 && existing.Currency != in.Currency {
+		return nil, apperror.New("invalid_state", "no se puede cambiar la moneda de un pago con cobros registrados", map[string]any{"currency": existing.Currency}, 409)
+	}
+
+	// Sin recargos en el request se conservan los ya registrados
+	surcharges := in.Surcharges
+	if surcharges == nil && existing != nil {
+		surcharges = existing.Surcharges
+	}
+	surchargesAmount, err := validateSurcharges(surcharges)
+	if err != nil {
+		return nil, err
+	}
+
+	itemsAmount, err := u.itemsAmount(ctx, in.TenantID, in.ParcelID)
+	if err != nil {
+		return nil, err
+	}
+	calculated := domain.RoundAmount(itemsAmount + surchargesAmount)
+
+	amount := calculated
+	source := domain.PaymentAmountAuto
+	var manualReason *string
+	if in.PaymentType == domain.PaymentTypeFree {
+		amount = 0
+	} else if in.Amount != nil && domain.RoundAmount(*in.Amount) != calculated {
+		if !opts.AllowManualPrice {
+			return nil, apperror.New("manual_price_disabled", "precio manual deshabilitado", map[string]any{"calculated_amount": calculated}, 409)
+		}
+		if in.ManualAmountReason == nil || strings.TrimSpace(*in.ManualAmountReason) == "" {
+			return nil, apperror.NewBadRequest("validation_error", "manual_amount_reason requerido para monto manual", map[string]any{"field": "manual_amount_reason", "calculated_amount": calculated})
+		}
+		reason := strings.TrimSpace(*in.ManualAmountReason)
+		amount = domain.RoundAmount(*in.Amount)
+		source = domain.PaymentAmountManual
+		manualReason = &reason
+	}
+	if in.PaymentType != domain.PaymentTypeFree && amount <= 0 {
+		return nil, apperror.NewBadRequest("validation_error", "amount debe ser > 0: agregue items o indique un monto manual", map[string]any{"field": "amount", "calculated_amount": calculated})
+	}
+
+	now := time.Now().UTC()
+
 	pay := domain.ParcelPayment{
 		ID:           uuid.NewString(),
 		TenantID:     in.TenantID,
 		ParcelID:     in.ParcelID.String(),
 		PaymentType:  in.PaymentType,
 		Currency:     in.Currency,
-		Amount:       in.Amount,
+		Amount:       amount,
 		Notes:        in.Notes,
-		Status:       domain.PaymentStatusPending,
+		Status:       domain.PaymentStatusUnpaid,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 		PaidAt:       nil,
@@ -138,6 +181,14 @@ func (u *UpsertParcelPaymentUseCase) Execute(ctx context.Context, in UpsertParce
 		OfficeID:     in.OfficeID,
 		CashboxID:    in.CashboxID,
 		SellerUserID: in.SellerUserID,
+
+		ItemsAmount:        itemsAmount,
+		Surcharges:         surcharges,
+		SurchargesAmount:   surchargesAmount,
+		AmountSource:       source,
+		ManualAmountReason: manualReason,
+		AmountStale:        false,
+		AmountCalculatedAt: &now,
 	}
 	if existing != nil {
 		pay.ID = existing.ID
@@ -158,5 +209,20 @@ func (u *UpsertParcelPaymentUseCase) Execute(ctx context.Context, in UpsertParce
 		}
 	}
 
+	if err := refreshLedger(ctx, u.paymentRepo, in.TenantID, &pay); err != nil {
+		return nil, err
+	}
+
 	return u.paymentRepo.Upsert(ctx, in.TenantID, pay)
 }
+
+func (u *UpsertParcelPaymentUseCase) itemsAmount(ctx context.Context, tenantID string, parcelID uuid.UUID) (float64, error) {
+	if u.itemRepo == nil {
+		return 0, apperror.NewInternal("internal_error", "repositorio items no configurado", nil)
+	}
+	items, err := u.itemRepo.ListByParcelID(ctx, tenantID, parcelID)
+	if err != nil {
+		return 0, err
+	}
+	return sumItemsAmount(items), nil
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_payment/domain"
+	"ms-parcel-core/internal/parcel/parcel_payment/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type VoidParcelPaymentTransactionInput struct {
+	TenantID         string
+	UserID           *string
+	UserName         string
+	ParcelID         uuid.UUID
+	TransactionID    uuid.UUID
+	Reason           string
+	ApprovedByUserID string
+}
+
+type VoidParcelPaymentTransactionResult struct {
+	Payment     *domain.ParcelPayment
+	Transaction *domain.PaymentTransaction
+}
+
+// VoidParcelPaymentTransactionUseCase anula un cobro puntual (p.ej. registrado por error)
+// agregando una transacción VOID por el mismo monto.
+type VoidParcelPaymentTransactionUseCase struct {
+	paymentRepo port.ParcelPaymentRepository
+	cashbox     coreport.CashboxClient
+	tracking    coreport.TrackingRecorder
+	uow         coreport.UnitOfWork
+	events      coreport.EventOutbox
+	features    coreport.FeatureGate
+	logger      *slog.Logger
+}
+
+func NewVoidParcelPaymentTransactionUseCase(paymentRepo port.ParcelPaymentRepository, cashbox coreport.CashboxClient, tracking coreport.TrackingRecorder, uow coreport.UnitOfWork, events coreport.EventOutbox, features coreport.FeatureGate, logger *slog.Logger) *VoidParcelPaymentTransactionUseCase {
+	return &VoidParcelPaymentTransactionUseCase{paymentRepo: paymentRepo, cashbox: cashbox, tracking: tracking, uow: coreport.UnitOfWorkOrDirect(uow), events: coreport.EventOutboxOrNop(events), features: features, logger: logging.OrDiscard(logger)}
+}
+
+func (u *VoidParcelPaymentTransactionUseCase) Execute(ctx context.Context, in VoidParcelPaymentTransactionInput) (*VoidParcelPaymentTransactionResult, error) {
+	ctx, span := tracing.StartUseCase(ctx, "VoidParcelPaymentTransaction", tracing.TenantID(in.TenantID), tracing.ParcelID(in.ParcelID.String()))
+	defer span.End()
+
+	if strings.TrimSpace(in.TenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	if err := coreport.RequireFeature(ctx, u.features, in.TenantID, coreport.FeaturePayments); err != nil {
+		return nil, err
+	}
+	if in.ParcelID == uuid.Nil {
+		return nil, apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"})
+	}
+	if in.TransactionID == uuid.Nil {
+		return nil, apperror.NewBadRequest("validation_error", "transaction_id inválido", map[string]any{"field": "transaction_id"})
+	}
+	if err := validateReversalApproval(in.Reason, in.ApprovedByUserID, in.UserID); err != nil {
+		return nil, err
+	}
+
+	pay, err := u.paymentRepo.GetByParcelID(ctx, in.TenantID, in.ParcelID)
+	if err != nil {
+		return nil, err
+	}
+	if pay == nil {
+		return nil, apperror.New("not_found", "pago no encontrado", map[string]any{"parcel_id": in.ParcelID.String()}, 404)
+	}
+
+	target, err := u.voidTarget(ctx, in.TenantID, in.ParcelID, in.TransactionID, pay)
+	if err != nil {
+		return nil, err
+	}
+
+	// El cobro salió de una caja: la anulación también debe pasar por ella
+	if target.CashboxID != nil && strings.TrimSpace(*target.CashboxID) != "" {
+		if err := requireCashboxOpen(ctx, u.cashbox, in.TenantID, target.CashboxID); err != nil {
+			return nil, err
+		}
+	}
+
+	reason := strings.TrimSpace(in.Reason)
+	approver := strings.TrimSpace(in.ApprovedByUserID)
+	// Ledger, pago y tracking se confirman juntos; el movimiento de caja va después del commit
+	// porque es una llamada externa con su propio reintento.
+	var updated *domain.ParcelPayment
+	var tx *domain.PaymentTransaction
+	err = u.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updated, tx, _, err = recordTransaction(ctx, u.uow, u.events, u.paymentRepo, in.TenantID, in.ParcelID, func(locked *domain.ParcelPayment) (*domain.PaymentTransaction, error) {
+			target, err := u.voidTarget(ctx, in.TenantID, in.ParcelID, in.TransactionID, locked)
+			if err != nil {
+				return nil, err
+			}
+			reverses := target.ID
+			return &domain.PaymentTransaction{
+				Kind:                  domain.PaymentTransactionVoid,
+				PaymentType:           target.PaymentType,
+				Channel:               target.Channel,
+				Amount:                target.Amount,
+				OfficeID:              target.OfficeID,
+				CashboxID:             target.CashboxID,
+				SellerUserID:          target.SellerUserID,
+				CreatedByUserID:       in.UserID,
+				Reason:                &reason,
+				ApprovedByUserID:      &approver,
+				ReversesTransactionID: &reverses,
+			}, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if u.tracking != nil {
+			if err := u.tracking.RecordEvent(ctx, in.TenantID, coreport.TrackingEventDTO{
+				ParcelID:   in.ParcelID.String(),
+				EventType:  coreport.EventTypePaymentVoided,
+				OccurredAt: time.Now().UTC(),
+				UserID:     derefString(in.UserID),
+				UserName:   in.UserName,
+				Metadata: map[string]any{
+					"transaction_id":          tx.ID,
+					"reverses_transaction_id": derefString(tx.ReversesTransactionID),
+					"amount":                  tx.Amount,
+					"currency":                tx.Currency,
+					"reason":                  reason,
+					"approved_by_user_id":     approver,
+					"payment_status":          updated.Status,
+				},
+			}); err != nil {
+				u.logger.ErrorContext(ctx, "no se pudo registrar evento de tracking, se revierte la anulación", "parcel_id", in.ParcelID.String(), "event_type", coreport.EventTypePaymentVoided, "error", err)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	tx = postCashboxMovement(ctx, u.logger, u.paymentRepo, u.cashbox, in.TenantID, tx)
+
+	return &VoidParcelPaymentTransactionResult{Payment: updated, Transaction: tx}, nil
+}
+
+// voidTarget busca el cobro a anular en el ledger y verifica que todavía se pueda anular: que no esté
+// anulado y que las devoluciones ya hechas no superen lo cobrado sin él. Actualiza el ledger de pay.
+func (u *VoidParcelPaymentTransactionUseCase) voidTarget(ctx context.Context, tenantID string, parcelID uuid.UUID, transactionID uuid.UUID, pay *domain.ParcelPayment) (*domain.PaymentTransaction, error) {
+	txs, err := u.paymentRepo.ListTransactions(ctx, tenantID, parcelID)
+	if err != nil {
+		return nil, err
+	}
+	var target *domain.PaymentTransaction
+	for i := range txs {
+		if txs[i].ID == transactionID.String() {
+			target = &txs[i]
+			break
+		}
+	}
+	if target == nil {
+		return nil, apperror.New("not_found", "transacción no encontrada", map[string]any{"transaction_id": transactionID.String()}, 404)
+	}
+	if target.Kind != domain.PaymentTransactionCharge {
+		return nil, apperror.New("invalid_state", "solo se pueden anular cobros", map[string]any{"kind": target.Kind}, 409)
+	}
+	if domain.VoidedTransactionIDs(txs)[target.ID] {
+		return nil, apperror.New("invalid_state", "el cobro ya fue anulado", map[string]any{"transaction_id": target.ID}, 409)
+	}
+
+	pay.ApplyLedger(txs)
+	if domain.RoundAmount(pay.PaidAmount-target.Amount) < 0 {
+		return nil, apperror.New("invalid_state", "no se puede anular: las devoluciones superarían lo cobrado", map[string]any{"paid_amount": pay.PaidAmount, "amount": target.Amount}, 409)
+	}
+	return target, nil
+}
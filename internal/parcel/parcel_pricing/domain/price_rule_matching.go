@@ -0,0 +1,41 @@
+package domain
+
+// Matches indica si la regla aplica a la ruta (coincidencia exacta o comodín por lado).
+// No considera Active; eso lo decide quien busca.
+func (r PriceRule) Matches(shipmentType, originOfficeID, destinationOfficeID string) bool {
+	if string(r.ShipmentType) != shipmentType {
+		return false
+	}
+	originMatch := r.OriginOfficeID == originOfficeID || r.OriginOfficeID == WildcardOffice
+	destMatch := r.DestinationOfficeID == destinationOfficeID || r.DestinationOfficeID == WildcardOffice
+	return originMatch && destMatch
+}
+
+// RuleScore asigna puntaje de especificidad
+// Mayor puntaje = más específica = mayor prioridad
+func RuleScore(rule PriceRule, targetOrigin, targetDest string) int {
+	score := 0
+
+	if rule.OriginOfficeID == targetOrigin {
+		score += 10 // Origen exacto
+	} else if rule.OriginOfficeID == WildcardOffice {
+		score += 1 // Origen comodín
+	}
+
+	if rule.DestinationOfficeID == targetDest {
+		score += 10 // Destino exacto
+	} else if rule.DestinationOfficeID == WildcardOffice {
+		score += 1 // Destino comodín
+	}
+
+	return score
+}
+
+// Outranks indica si a gana sobre b: primero especificidad, luego Priority.
+// Si ninguna gana sobre la otra, el empate es ambiguo.
+func Outranks(a PriceRule, scoreA int, b PriceRule, scoreB int) bool {
+	if scoreA != scoreB {
+		return scoreA > scoreB
+	}
+	return a.Priority > b.Priority
+}
@@ -0,0 +1,228 @@
+// Package pricetable lee y escribe reglas de precios en formato tabular (CSV/XLSX). Lo comparten
+// la API (import/export) y parcelctl.
+package pricetable
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	pricingdomain "ms-parcel-core/internal/parcel/parcel_pricing/domain"
+	pricingusecase "ms-parcel-core/internal/parcel/parcel_pricing/usecase"
+	"ms-parcel-core/internal/pkg/util/apperror"
+)
+
+// Formato tabular de reglas de precios compartido por import y export.
+// El export produce exactamente las columnas que acepta el import (round-trip).
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+
+	sheetName = "price_rules"
+)
+
+var columns = []string{
+	"shipment_type",
+	"origin_office_id",
+	"destination_office_id",
+	"unit",
+	"price",
+	"currency",
+	"priority",
+	"active",
+}
+
+var requiredColumns = []string{
+	"shipment_type",
+	"origin_office_id",
+	"destination_office_id",
+	"unit",
+	"price",
+	"currency",
+}
+
+func ReadCSV(r io.Reader) ([][]string, error) {
+	br := bufio.NewReader(r)
+
+	// BOM de Excel/Windows
+	if b, err := br.Peek(3); err == nil && bytes.Equal(b, []byte{0xEF, 0xBB, 0xBF}) {
+		_, _ = br.Discard(3)
+	}
+
+	// Excel en locales es-* exporta con ';'
+	delimiter := ','
+	line, _ := br.Peek(4096)
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	if bytes.Count(line, []byte(";")) > bytes.Count(line, []byte(",")) {
+		delimiter = ';'
+	}
+
+	cr := csv.NewReader(br)
+	cr.Comma = delimiter
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, apperror.NewBadRequest("invalid_file", "CSV inválido", map[string]any{"error": err.Error()})
+	}
+	return records, nil
+}
+
+func ReadXLSX(r io.Reader) ([][]string, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, apperror.NewBadRequest("invalid_file", "XLSX inválido", map[string]any{"error": err.Error()})
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, apperror.NewBadRequest("invalid_file", "XLSX sin hojas", nil)
+	}
+	rows, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, apperror.NewBadRequest("invalid_file", "XLSX inválido", map[string]any{"error": err.Error()})
+	}
+	return rows, nil
+}
+
+// Decode convierte las filas crudas (header + datos) en filas de importación.
+// Los errores de formato por celda se adjuntan a la fila en vez de abortar todo el archivo.
+func Decode(records [][]string) ([]pricingusecase.ImportPriceRuleRow, error) {
+	if len(records) == 0 {
+		return nil, apperror.NewBadRequest("invalid_file", "archivo vacío", nil)
+	}
+
+	idx := map[string]int{}
+	for i, h := range records[0] {
+		idx[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	missing := make([]string, 0)
+	for _, col := range requiredColumns {
+		if _, ok := idx[col]; !ok {
+			missing = append(missing, col)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, apperror.NewBadRequest("invalid_file", "faltan columnas requeridas", map[string]any{"missing": missing, "expected": columns})
+	}
+
+	rows := make([]pricingusecase.ImportPriceRuleRow, 0, len(records)-1)
+	for n, rec := range records[1:] {
+		cell := func(col string) string {
+			i, ok := idx[col]
+			if !ok || i >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}
+
+		blank := true
+		for _, v := range rec {
+			if strings.TrimSpace(v) != "" {
+				blank = false
+				break
+			}
+		}
+		if blank {
+			continue
+		}
+
+		row := pricingusecase.ImportPriceRuleRow{
+			Line:                n + 2, // línea 1 = cabecera
+			ShipmentType:        cell("shipment_type"),
+			OriginOfficeID:      cell("origin_office_id"),
+			DestinationOfficeID: cell("destination_office_id"),
+			Unit:                strings.ToUpper(cell("unit")),
+			Currency:            strings.ToUpper(cell("currency")),
+			Active:              true,
+		}
+
+		if v := cell("price"); v != "" {
+			price, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", "."), 64)
+			if err != nil {
+				row.ParseErrors = append(row.ParseErrors, pricingusecase.ImportRowError{Field: "price", Code: "invalid_number", Message: "price no es numérico"})
+			}
+			row.Price = price
+		}
+		if v := cell("priority"); v != "" {
+			priority, err := strconv.Atoi(v)
+			if err != nil {
+				row.ParseErrors = append(row.ParseErrors, pricingusecase.ImportRowError{Field: "priority", Code: "invalid_number", Message: "priority no es entero"})
+			}
+			row.Priority = priority
+		}
+		if v := cell("active"); v != "" {
+			switch strings.ToLower(v) {
+			case "true", "1", "si", "sí", "yes", "y":
+				row.Active = true
+			case "false", "0", "no", "n":
+				row.Active = false
+			default:
+				row.ParseErrors = append(row.ParseErrors, pricingusecase.ImportRowError{Field: "active", Code: "invalid_boolean", Message: "active debe ser true/false"})
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func encodeRecords(rules []pricingdomain.PriceRule) [][]string {
+	out := make([][]string, 0, len(rules)+1)
+	out = append(out, columns)
+	for _, r := range rules {
+		out = append(out, []string{
+			string(r.ShipmentType),
+			r.OriginOfficeID,
+			r.DestinationOfficeID,
+			string(r.Unit),
+			strconv.FormatFloat(r.Price, 'f', 2, 64),
+			r.Currency,
+			strconv.Itoa(r.Priority),
+			strconv.FormatBool(r.Active),
+		})
+	}
+	return out
+}
+
+func WriteCSV(w io.Writer, rules []pricingdomain.PriceRule) error {
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(encodeRecords(rules)); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func WriteXLSX(w io.Writer, rules []pricingdomain.PriceRule) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName(f.GetSheetName(0), sheetName); err != nil {
+		return err
+	}
+	for i, rec := range encodeRecords(rules) {
+		cellRef, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		values := make([]any, len(rec))
+		for j, v := range rec {
+			values[j] = v
+		}
+		if err := f.SetSheetRow(sheetName, cellRef, &values); err != nil {
+			return err
+		}
+	}
+	return f.Write(w)
+}
@@ -7,9 +7,11 @@ import (
 
 	"github.com/google/uuid"
 
+	"ms-parcel-core/internal/infrastructure/persistence/memory"
 	"ms-parcel-core/internal/parcel/parcel_pricing/domain"
 	"ms-parcel-core/internal/parcel/parcel_pricing/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type InMemoryPriceRuleRepository struct {
@@ -24,7 +26,8 @@ func NewInMemoryPriceRuleRepository() *InMemoryPriceRuleRepository {
 }
 
 func (r *InMemoryPriceRuleRepository) Create(ctx context.Context, tenantID string, rule domain.PriceRule) (*domain.PriceRule, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "PriceRuleRepository.Create", tracing.TenantID(tenantID))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -44,12 +47,14 @@ func (r *InMemoryPriceRuleRepository) Create(ctx context.Context, tenantID strin
 	rule.UpdatedAt = now
 
 	r.data[tenantID][id] = rule
+	r.restoreOnRollback(ctx, tenantID, id, domain.PriceRule{}, false)
 	cp := rule
 	return &cp, nil
 }
 
 func (r *InMemoryPriceRuleRepository) Update(ctx context.Context, tenantID string, id uuid.UUID, rule domain.PriceRule) (*domain.PriceRule, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "PriceRuleRepository.Update", tracing.TenantID(tenantID))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -74,13 +79,15 @@ func (r *InMemoryPriceRuleRepository) Update(ctx context.Context, tenantID strin
 
 	byTenant[id] = rule
 	r.data[tenantID] = byTenant
+	r.restoreOnRollback(ctx, tenantID, id, existing, true)
 
 	cp := rule
 	return &cp, nil
 }
 
 func (r *InMemoryPriceRuleRepository) List(ctx context.Context, tenantID string) ([]domain.PriceRule, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "PriceRuleRepository.List", tracing.TenantID(tenantID))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -101,7 +108,8 @@ func (r *InMemoryPriceRuleRepository) List(ctx context.Context, tenantID string)
 }
 
 func (r *InMemoryPriceRuleRepository) FindMatch(ctx context.Context, tenantID string, shipmentType, originOfficeID, destinationOfficeID string) (*domain.PriceRule, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "PriceRuleRepository.FindMatch", tracing.TenantID(tenantID))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -126,14 +134,7 @@ func (r *InMemoryPriceRuleRepository) FindMatch(ctx context.Context, tenantID st
 		if !rule.Active {
 			continue
 		}
-		if string(rule.ShipmentType) != shipmentType {
-			continue
-		}
-
-		originMatch := rule.OriginOfficeID == originOfficeID || rule.OriginOfficeID == domain.WildcardOffice
-		destMatch := rule.DestinationOfficeID == destinationOfficeID || rule.DestinationOfficeID == domain.WildcardOffice
-
-		if originMatch && destMatch {
+		if rule.Matches(shipmentType, originOfficeID, destinationOfficeID) {
 			candidates = append(candidates, rule)
 		}
 	}
@@ -144,11 +145,11 @@ func (r *InMemoryPriceRuleRepository) FindMatch(ctx context.Context, tenantID st
 
 	// Ordenar por especificidad (prioridad implícita)
 	best := candidates[0]
-	bestScore := calculateRuleScore(best, originOfficeID, destinationOfficeID)
+	bestScore := domain.RuleScore(best, originOfficeID, destinationOfficeID)
 
 	for i := 1; i < len(candidates); i++ {
-		score := calculateRuleScore(candidates[i], originOfficeID, destinationOfficeID)
-		if score > bestScore || (score == bestScore && candidates[i].Priority > best.Priority) {
+		score := domain.RuleScore(candidates[i], originOfficeID, destinationOfficeID)
+		if domain.Outranks(candidates[i], score, best, bestScore) {
 			best = candidates[i]
 			bestScore = score
 		}
@@ -158,22 +159,45 @@ func (r *InMemoryPriceRuleRepository) FindMatch(ctx context.Context, tenantID st
 	return &cp, nil
 }
 
-// calculateRuleScore asigna puntaje de especificidad
-// Mayor puntaje = más específica = mayor prioridad
-func calculateRuleScore(rule domain.PriceRule, targetOrigin, targetDest string) int {
-	score := 0
+// FindByRoute busca la regla (activa o no) con la misma clave shipment_type + origen + destino.
+// Se usa para upsert en importaciones masivas; si hubiera duplicados, devuelve la de mayor prioridad.
+func (r *InMemoryPriceRuleRepository) FindByRoute(ctx context.Context, tenantID string, shipmentType, originOfficeID, destinationOfficeID string) (*domain.PriceRule, error) {
+	_, span := tracing.Start(ctx, "PriceRuleRepository.FindByRoute", tracing.TenantID(tenantID))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	if rule.OriginOfficeID == targetOrigin {
-		score += 10 // Origen exacto
-	} else if rule.OriginOfficeID == domain.WildcardOffice {
-		score += 1 // Origen comodín
+	if r.data == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio pricing no inicializado", nil)
+	}
+	byTenant, ok := r.data[tenantID]
+	if !ok {
+		return nil, nil
 	}
 
-	if rule.DestinationOfficeID == targetDest {
-		score += 10 // Destino exacto
-	} else if rule.DestinationOfficeID == domain.WildcardOffice {
-		score += 1 // Destino comodín
+	var found *domain.PriceRule
+	for _, rule := range byTenant {
+		if string(rule.ShipmentType) != shipmentType || rule.OriginOfficeID != originOfficeID || rule.DestinationOfficeID != destinationOfficeID {
+			continue
+		}
+		if found == nil || rule.Priority > found.Priority {
+			cp := rule
+			found = &cp
+		}
 	}
+	return found, nil
+}
 
-	return score
+// restoreOnRollback deja la regla como estaba antes de la escritura si se revierte la unidad de trabajo de ctx.
+func (r *InMemoryPriceRuleRepository) restoreOnRollback(ctx context.Context, tenantID string, id uuid.UUID, prev domain.PriceRule, existed bool) {
+	memory.OnRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if existed {
+			r.data[tenantID][id] = prev
+		} else {
+			delete(r.data[tenantID], id)
+		}
+	})
 }
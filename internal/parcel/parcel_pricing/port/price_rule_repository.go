@@ -13,4 +13,5 @@ type PriceRuleRepository interface {
 	Update(ctx context.Context, tenantID string, id uuid.UUID, r domain.PriceRule) (*domain.PriceRule, error)
 	List(ctx context.Context, tenantID string) ([]domain.PriceRule, error)
 	FindMatch(ctx context.Context, tenantID string, shipmentType, originOfficeID, destinationOfficeID string) (*domain.PriceRule, error)
+	FindByRoute(ctx context.Context, tenantID string, shipmentType, originOfficeID, destinationOfficeID string) (*domain.PriceRule, error)
 }
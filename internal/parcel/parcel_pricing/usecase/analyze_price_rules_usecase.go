@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	coredomain "ms-parcel-core/internal/parcel/parcel_core/domain"
+	"ms-parcel-core/internal/parcel/parcel_pricing/domain"
+	"ms-parcel-core/internal/parcel/parcel_pricing/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+const (
+	UnreachableReasonShadowed = "SHADOWED"
+	UnreachableReasonNoRoute  = "NO_ROUTE"
+
+	// límites del reporte para no devolver payloads gigantes con tablas grandes
+	maxAnalysisExamples  = 5
+	maxAnalysisUncovered = 1000
+)
+
+type AnalyzePriceRulesInput struct {
+	TenantID string
+	// OfficeIDs amplía el universo de oficinas evaluado; por defecto solo las que aparecen en reglas.
+	OfficeIDs []string
+	// ShipmentTypes restringe los tipos evaluados; por defecto los tipos presentes en reglas.
+	ShipmentTypes []string
+}
+
+type PriceRoute struct {
+	ShipmentType        string
+	OriginOfficeID      string
+	DestinationOfficeID string
+}
+
+// PriceRuleTie agrupa las rutas donde el mismo conjunto de reglas empata
+// en especificidad y prioridad (el ganador no es determinístico).
+type PriceRuleTie struct {
+	RuleIDs  []string
+	Score    int
+	Priority int
+	Routes   int
+	Examples []PriceRoute
+}
+
+// UnreachableRule es una regla activa que no gana en ninguna ruta evaluada.
+type UnreachableRule struct {
+	Rule       domain.PriceRule
+	Reason     string
+	ShadowedBy []string
+}
+
+type AnalyzePriceRulesResult struct {
+	OfficeIDs          []string
+	ShipmentTypes      []string
+	RoutesEvaluated    int
+	Ties               []PriceRuleTie
+	Unreachable        []UnreachableRule
+	Uncovered          []PriceRoute
+	UncoveredTotal     int
+	UncoveredTruncated bool
+}
+
+type AnalyzePriceRulesUseCase struct {
+	repo port.PriceRuleRepository
+}
+
+func NewAnalyzePriceRulesUseCase(repo port.PriceRuleRepository) *AnalyzePriceRulesUseCase {
+	return &AnalyzePriceRulesUseCase{repo: repo}
+}
+
+// Execute evalúa todas las rutas origen->destino (origen != destino) entre las oficinas conocidas,
+// por cada tipo de envío, usando el mismo criterio de selección que FindMatch.
+func (u *AnalyzePriceRulesUseCase) Execute(ctx context.Context, in AnalyzePriceRulesInput) (*AnalyzePriceRulesResult, error) {
+	ctx, span := tracing.StartUseCase(ctx, "AnalyzePriceRules", tracing.TenantID(in.TenantID))
+	defer span.End()
+
+	if strings.TrimSpace(in.TenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+
+	rules, err := u.repo.List(ctx, in.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	offices := map[string]struct{}{}
+	types := map[string]struct{}{}
+	active := make([]domain.PriceRule, 0, len(rules))
+	for _, r := range rules {
+		if !r.Active {
+			continue
+		}
+		active = append(active, r)
+		types[string(r.ShipmentType)] = struct{}{}
+		for _, o := range []string{r.OriginOfficeID, r.DestinationOfficeID} {
+			if o != domain.WildcardOffice && o != "" {
+				offices[o] = struct{}{}
+			}
+		}
+	}
+	for _, o := range in.OfficeIDs {
+		o = strings.TrimSpace(o)
+		if o != "" && o != domain.WildcardOffice {
+			offices[o] = struct{}{}
+		}
+	}
+	if len(in.ShipmentTypes) > 0 {
+		types = map[string]struct{}{}
+		for _, t := range in.ShipmentTypes {
+			if t = strings.TrimSpace(t); t != "" {
+				types[t] = struct{}{}
+			}
+		}
+	}
+	if len(types) == 0 {
+		types[string(coredomain.ShipmentTypeBus)] = struct{}{}
+		types[string(coredomain.ShipmentTypeCarguero)] = struct{}{}
+	}
+
+	res := &AnalyzePriceRulesResult{
+		OfficeIDs:     sortedKeys(offices),
+		ShipmentTypes: sortedKeys(types),
+		Ties:          make([]PriceRuleTie, 0),
+		Unreachable:   make([]UnreachableRule, 0),
+		Uncovered:     make([]PriceRoute, 0),
+	}
+
+	won := map[string]bool{}
+	matched := map[string]bool{}
+	beatenBy := map[string]map[string]struct{}{}
+	ties := map[string]*PriceRuleTie{}
+	tieOrder := make([]string, 0)
+
+	for _, st := range res.ShipmentTypes {
+		for _, origin := range res.OfficeIDs {
+			for _, dest := range res.OfficeIDs {
+				if origin == dest {
+					continue
+				}
+				res.RoutesEvaluated++
+				route := PriceRoute{ShipmentType: st, OriginOfficeID: origin, DestinationOfficeID: dest}
+
+				ranked := rankPriceRules(active, st, origin, dest)
+				if len(ranked) == 0 {
+					res.UncoveredTotal++
+					if len(res.Uncovered) < maxAnalysisUncovered {
+						res.Uncovered = append(res.Uncovered, route)
+					} else {
+						res.UncoveredTruncated = true
+					}
+					continue
+				}
+
+				top := topRankedPriceRules(ranked)
+				for _, rr := range ranked {
+					matched[rr.rule.ID] = true
+				}
+				for _, rr := range top {
+					won[rr.rule.ID] = true
+				}
+				for _, rr := range ranked[len(top):] {
+					if beatenBy[rr.rule.ID] == nil {
+						beatenBy[rr.rule.ID] = map[string]struct{}{}
+					}
+					for _, w := range top {
+						beatenBy[rr.rule.ID][w.rule.ID] = struct{}{}
+					}
+				}
+
+				if len(top) > 1 {
+					ids := make([]string, 0, len(top))
+					for _, rr := range top {
+						ids = append(ids, rr.rule.ID)
+					}
+					key := strings.Join(ids, ",")
+					t, ok := ties[key]
+					if !ok {
+						t = &PriceRuleTie{RuleIDs: ids, Score: top[0].score, Priority: top[0].rule.Priority}
+						ties[key] = t
+						tieOrder = append(tieOrder, key)
+					}
+					t.Routes++
+					if len(t.Examples) < maxAnalysisExamples {
+						t.Examples = append(t.Examples, route)
+					}
+				}
+			}
+		}
+	}
+
+	for _, key := range tieOrder {
+		res.Ties = append(res.Ties, *ties[key])
+	}
+
+	evaluatedTypes := map[string]struct{}{}
+	for _, st := range res.ShipmentTypes {
+		evaluatedTypes[st] = struct{}{}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].ID < active[j].ID })
+	for _, r := range active {
+		if _, ok := evaluatedTypes[string(r.ShipmentType)]; !ok || won[r.ID] {
+			continue
+		}
+		if !matched[r.ID] {
+			res.Unreachable = append(res.Unreachable, UnreachableRule{Rule: r, Reason: UnreachableReasonNoRoute, ShadowedBy: []string{}})
+			continue
+		}
+		res.Unreachable = append(res.Unreachable, UnreachableRule{Rule: r, Reason: UnreachableReasonShadowed, ShadowedBy: sortedKeys(beatenBy[r.ID])})
+	}
+
+	return res, nil
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
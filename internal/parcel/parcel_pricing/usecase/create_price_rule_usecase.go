@@ -8,6 +8,7 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_pricing/domain"
 	"ms-parcel-core/internal/parcel/parcel_pricing/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type CreatePriceRuleUseCase struct {
@@ -31,21 +32,14 @@ type CreatePriceRuleInput struct {
 }
 
 func (u *CreatePriceRuleUseCase) Execute(ctx context.Context, in CreatePriceRuleInput) (*domain.PriceRule, error) {
+	ctx, span := tracing.StartUseCase(ctx, "CreatePriceRule", tracing.TenantID(in.TenantID))
+	defer span.End()
+
 	if strings.TrimSpace(in.TenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
-	if in.Price <= 0 {
-		return nil, apperror.NewBadRequest("validation_error", "price inválido", map[string]any{"field": "price"})
-	}
-	switch strings.TrimSpace(in.Unit) {
-	case string(domain.PriceUnitPerKg), string(domain.PriceUnitPerItem):
-	default:
-		return nil, apperror.NewBadRequest("validation_error", "unit inválido", map[string]any{"field": "unit"})
-	}
-	switch strings.TrimSpace(in.Currency) {
-	case "PEN", "USD":
-	default:
-		return nil, apperror.NewBadRequest("validation_error", "currency inválido", map[string]any{"field": "currency"})
+	if err := validatePriceRuleFields(in.Price, in.Unit, in.Currency); err != nil {
+		return nil, err
 	}
 
 	r := domain.PriceRule{
@@ -61,3 +55,30 @@ func (u *CreatePriceRuleUseCase) Execute(ctx context.Context, in CreatePriceRule
 
 	return u.repo.Create(ctx, in.TenantID, r)
 }
+
+// validatePriceRuleFields concentra las validaciones de precio, unidad y moneda
+// compartidas por creación, actualización e importación de reglas.
+func validatePriceRuleFields(price float64, unit string, currency string) *apperror.AppError {
+	if errs := priceRuleFieldErrors(price, unit, currency); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func priceRuleFieldErrors(price float64, unit string, currency string) []*apperror.AppError {
+	var errs []*apperror.AppError
+	if price <= 0 {
+		errs = append(errs, apperror.NewBadRequest("validation_error", "price inválido", map[string]any{"field": "price"}))
+	}
+	switch strings.TrimSpace(unit) {
+	case string(domain.PriceUnitPerKg), string(domain.PriceUnitPerItem):
+	default:
+		errs = append(errs, apperror.NewBadRequest("validation_error", "unit inválido", map[string]any{"field": "unit"}))
+	}
+	switch strings.TrimSpace(currency) {
+	case "PEN", "USD":
+	default:
+		errs = append(errs, apperror.NewBadRequest("validation_error", "currency inválido", map[string]any{"field": "currency"}))
+	}
+	return errs
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"ms-parcel-core/internal/parcel/parcel_pricing/domain"
+	"ms-parcel-core/internal/parcel/parcel_pricing/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type ExportPriceRulesUseCase struct {
+	repo port.PriceRuleRepository
+}
+
+func NewExportPriceRulesUseCase(repo port.PriceRuleRepository) *ExportPriceRulesUseCase {
+	return &ExportPriceRulesUseCase{repo: repo}
+}
+
+// Execute devuelve la tabla de precios activa ordenada por tipo de envío, origen y destino,
+// lista para reimportarse con ImportPriceRulesUseCase.
+func (u *ExportPriceRulesUseCase) Execute(ctx context.Context, tenantID string) ([]domain.PriceRule, error) {
+	ctx, span := tracing.StartUseCase(ctx, "ExportPriceRules", tracing.TenantID(tenantID))
+	defer span.End()
+
+	if strings.TrimSpace(tenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+
+	rules, err := u.repo.List(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.PriceRule, 0, len(rules))
+	for _, r := range rules {
+		if r.Active {
+			out = append(out, r)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ShipmentType != out[j].ShipmentType {
+			return out[i].ShipmentType < out[j].ShipmentType
+		}
+		if out[i].OriginOfficeID != out[j].OriginOfficeID {
+			return out[i].OriginOfficeID < out[j].OriginOfficeID
+		}
+		if out[i].DestinationOfficeID != out[j].DestinationOfficeID {
+			return out[i].DestinationOfficeID < out[j].DestinationOfficeID
+		}
+		return out[i].Priority > out[j].Priority
+	})
+
+	return out, nil
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	coredomain "ms-parcel-core/internal/parcel/parcel_core/domain"
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_pricing/domain"
+	"ms-parcel-core/internal/parcel/parcel_pricing/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+const (
+	ImportActionCreate = "CREATE"
+	ImportActionUpdate = "UPDATE"
+	ImportActionError  = "ERROR"
+)
+
+type ImportRowError struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ImportPriceRuleRow es una fila ya decodificada del archivo (CSV/XLSX).
+// ParseErrors trae los errores de formato detectados al leer la fila.
+type ImportPriceRuleRow struct {
+	Line                int
+	ShipmentType        string
+	OriginOfficeID      string
+	DestinationOfficeID string
+	Unit                string
+	Price               float64
+	Currency            string
+	Priority            int
+	Active              bool
+	ParseErrors         []ImportRowError
+}
+
+type ImportPriceRulesInput struct {
+	TenantID string
+	DryRun   bool
+	Rows     []ImportPriceRuleRow
+}
+
+type ImportPriceRuleRowResult struct {
+	Line                int              `json:"line"`
+	Action              string           `json:"action"`
+	RuleID              *string          `json:"rule_id,omitempty"`
+	ShipmentType        string           `json:"shipment_type"`
+	OriginOfficeID      string           `json:"origin_office_id"`
+	DestinationOfficeID string           `json:"destination_office_id"`
+	Errors              []ImportRowError `json:"errors,omitempty"`
+}
+
+type ImportPriceRulesResult struct {
+	DryRun  bool                       `json:"dry_run"`
+	Applied bool                       `json:"applied"`
+	Total   int                        `json:"total"`
+	Created int                        `json:"created"`
+	Updated int                        `json:"updated"`
+	Failed  int                        `json:"failed"`
+	Rows    []ImportPriceRuleRowResult `json:"rows"`
+}
+
+type ImportPriceRulesUseCase struct {
+	repo port.PriceRuleRepository
+	uow  coreport.UnitOfWork
+}
+
+func NewImportPriceRulesUseCase(repo port.PriceRuleRepository, uow coreport.UnitOfWork) *ImportPriceRulesUseCase {
+	return &ImportPriceRulesUseCase{repo: repo, uow: coreport.UnitOfWorkOrDirect(uow)}
+}
+
+// Execute valida todas las filas y, si no hay errores y no es dry-run, aplica
+// upsert por clave shipment_type + origen + destino. La importación es todo o nada:
+// con una sola fila inválida no se persiste ninguna, y las filas se aplican en una
+// sola unidad de trabajo.
+func (u *ImportPriceRulesUseCase) Execute(ctx context.Context, in ImportPriceRulesInput) (*ImportPriceRulesResult, error) {
+	ctx, span := tracing.StartUseCase(ctx, "ImportPriceRules", tracing.TenantID(in.TenantID))
+	defer span.End()
+
+	if strings.TrimSpace(in.TenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	if len(in.Rows) == 0 {
+		return nil, apperror.NewBadRequest("validation_error", "el archivo no contiene filas", map[string]any{"field": "file"})
+	}
+
+	res := &ImportPriceRulesResult{DryRun: in.DryRun, Total: len(in.Rows), Rows: make([]ImportPriceRuleRowResult, 0, len(in.Rows))}
+	rules := make([]domain.PriceRule, len(in.Rows))
+	existingIDs := make([]*string, len(in.Rows))
+	seen := map[string]int{}
+
+	for i, row := range in.Rows {
+		rule := domain.PriceRule{
+			ShipmentType:        coredomain.ShipmentType(strings.TrimSpace(row.ShipmentType)),
+			OriginOfficeID:      strings.TrimSpace(row.OriginOfficeID),
+			DestinationOfficeID: strings.TrimSpace(row.DestinationOfficeID),
+			Unit:                domain.PriceUnit(strings.TrimSpace(row.Unit)),
+			Price:               row.Price,
+			Currency:            strings.TrimSpace(row.Currency),
+			Priority:            row.Priority,
+			Active:              row.Active,
+		}
+		rules[i] = rule
+
+		rr := ImportPriceRuleRowResult{
+			Line:                row.Line,
+			ShipmentType:        string(rule.ShipmentType),
+			OriginOfficeID:      rule.OriginOfficeID,
+			DestinationOfficeID: rule.DestinationOfficeID,
+		}
+		rr.Errors = append(rr.Errors, row.ParseErrors...)
+		unparsed := map[string]bool{}
+		for _, pe := range row.ParseErrors {
+			unparsed[pe.Field] = true
+		}
+		for _, ve := range validateImportRow(rule) {
+			// no repetir el error si la celda ni siquiera se pudo leer
+			if ve.Field != "" && unparsed[ve.Field] {
+				continue
+			}
+			rr.Errors = append(rr.Errors, ve)
+		}
+
+		key := string(rule.ShipmentType) + "|" + rule.OriginOfficeID + "|" + rule.DestinationOfficeID
+		if firstLine, dup := seen[key]; dup {
+			rr.Errors = append(rr.Errors, ImportRowError{Code: "duplicated_route", Message: "ruta duplicada en el archivo (ver línea " + strconv.Itoa(firstLine) + ")"})
+		} else {
+			seen[key] = row.Line
+		}
+
+		if len(rr.Errors) == 0 {
+			existing, err := u.repo.FindByRoute(ctx, in.TenantID, string(rule.ShipmentType), rule.OriginOfficeID, rule.DestinationOfficeID)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				id := existing.ID
+				existingIDs[i] = &id
+				rr.RuleID = &id
+				rr.Action = ImportActionUpdate
+				res.Updated++
+			} else {
+				rr.Action = ImportActionCreate
+				res.Created++
+			}
+		} else {
+			rr.Action = ImportActionError
+			res.Failed++
+		}
+
+		res.Rows = append(res.Rows, rr)
+	}
+
+	if in.DryRun {
+		return res, nil
+	}
+	if res.Failed > 0 {
+		return nil, apperror.NewBadRequest("import_validation_error", "la importación contiene filas inválidas; no se aplicaron cambios", res)
+	}
+
+	createdIDs, err := coreport.WithinUnitOfWork(ctx, u.uow, func(ctx context.Context) ([]*string, error) {
+		return u.apply(ctx, in.TenantID, rules, existingIDs)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	for i, id := range createdIDs {
+		if id != nil {
+			res.Rows[i].RuleID = id
+		}
+	}
+
+	res.Applied = true
+	return res, nil
+}
+
+// apply escribe las reglas ya validadas; devuelve el id de las creadas, en el orden de las filas.
+func (u *ImportPriceRulesUseCase) apply(ctx context.Context, tenantID string, rules []domain.PriceRule, existingIDs []*string) ([]*string, error) {
+	createdIDs := make([]*string, len(rules))
+	for i := range rules {
+		if existingIDs[i] != nil {
+			id, err := uuid.Parse(*existingIDs[i])
+			if err != nil {
+				return nil, apperror.NewInternal("internal_error", "id de regla inválido", map[string]any{"id": *existingIDs[i]})
+			}
+			if _, err := u.repo.Update(ctx, tenantID, id, rules[i]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		created, err := u.repo.Create(ctx, tenantID, rules[i])
+		if err != nil {
+			return nil, err
+		}
+		id := created.ID
+		createdIDs[i] = &id
+	}
+	return createdIDs, nil
+}
+
+// validateImportRow aplica las mismas reglas que POST /pricing/rules (binding + CreatePriceRuleUseCase)
+// pero acumulando todos los errores de la fila para el reporte.
+func validateImportRow(r domain.PriceRule) []ImportRowError {
+	var errs []ImportRowError
+	if r.ShipmentType == "" {
+		errs = append(errs, ImportRowError{Field: "shipment_type", Code: "required", Message: "shipment_type requerido"})
+	}
+	if r.OriginOfficeID == "" {
+		errs = append(errs, ImportRowError{Field: "origin_office_id", Code: "required", Message: "origin_office_id requerido"})
+	}
+	if r.DestinationOfficeID == "" {
+		errs = append(errs, ImportRowError{Field: "destination_office_id", Code: "required", Message: "destination_office_id requerido"})
+	}
+	if r.Priority < 0 || r.Priority > 100 {
+		errs = append(errs, ImportRowError{Field: "priority", Code: "validation_error", Message: "priority debe estar entre 0 y 100"})
+	}
+	for _, appErr := range priceRuleFieldErrors(r.Price, string(r.Unit), r.Currency) {
+		field := ""
+		if d, ok := appErr.Details.(map[string]any); ok {
+			field, _ = d["field"].(string)
+		}
+		errs = append(errs, ImportRowError{Field: field, Code: appErr.Code, Message: appErr.Message})
+	}
+	return errs
+}
@@ -7,6 +7,7 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_pricing/domain"
 	"ms-parcel-core/internal/parcel/parcel_pricing/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type ListPriceRulesUseCase struct {
@@ -18,6 +19,9 @@ func NewListPriceRulesUseCase(repo port.PriceRuleRepository) *ListPriceRulesUseC
 }
 
 func (u *ListPriceRulesUseCase) Execute(ctx context.Context, tenantID string) ([]domain.PriceRule, error) {
+	ctx, span := tracing.StartUseCase(ctx, "ListPriceRules", tracing.TenantID(tenantID))
+	defer span.End()
+
 	if strings.TrimSpace(tenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
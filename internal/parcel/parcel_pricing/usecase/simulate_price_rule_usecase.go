@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"ms-parcel-core/internal/parcel/parcel_pricing/domain"
+	"ms-parcel-core/internal/parcel/parcel_pricing/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type SimulatePriceRuleInput struct {
+	TenantID            string
+	ShipmentType        string
+	OriginOfficeID      string
+	DestinationOfficeID string
+}
+
+// PriceRuleCandidate es una regla que coincide con la ruta simulada.
+// Las reglas inactivas se listan para diagnóstico pero nunca ganan.
+type PriceRuleCandidate struct {
+	Rule   domain.PriceRule
+	Score  int
+	Winner bool
+	Tied   bool
+}
+
+type SimulatePriceRuleResult struct {
+	ShipmentType        string
+	OriginOfficeID      string
+	DestinationOfficeID string
+	Candidates          []PriceRuleCandidate
+	Winner              *domain.PriceRule
+	// Ambiguous indica empate en especificidad y prioridad entre las mejores reglas:
+	// FindMatch puede devolver cualquiera de ellas.
+	Ambiguous bool
+}
+
+type SimulatePriceRuleUseCase struct {
+	repo port.PriceRuleRepository
+}
+
+func NewSimulatePriceRuleUseCase(repo port.PriceRuleRepository) *SimulatePriceRuleUseCase {
+	return &SimulatePriceRuleUseCase{repo: repo}
+}
+
+func (u *SimulatePriceRuleUseCase) Execute(ctx context.Context, in SimulatePriceRuleInput) (*SimulatePriceRuleResult, error) {
+	ctx, span := tracing.StartUseCase(ctx, "SimulatePriceRule", tracing.TenantID(in.TenantID))
+	defer span.End()
+
+	if strings.TrimSpace(in.TenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+
+	shipmentType := strings.TrimSpace(in.ShipmentType)
+	origin := strings.TrimSpace(in.OriginOfficeID)
+	dest := strings.TrimSpace(in.DestinationOfficeID)
+	if shipmentType == "" {
+		return nil, apperror.NewBadRequest("validation_error", "shipment_type requerido", map[string]any{"field": "shipment_type"})
+	}
+	if origin == "" || origin == domain.WildcardOffice {
+		return nil, apperror.NewBadRequest("validation_error", "origin_office_id requerido (no se admite comodín)", map[string]any{"field": "origin_office_id"})
+	}
+	if dest == "" || dest == domain.WildcardOffice {
+		return nil, apperror.NewBadRequest("validation_error", "destination_office_id requerido (no se admite comodín)", map[string]any{"field": "destination_office_id"})
+	}
+
+	rules, err := u.repo.List(ctx, in.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SimulatePriceRuleResult{
+		ShipmentType:        shipmentType,
+		OriginOfficeID:      origin,
+		DestinationOfficeID: dest,
+		Candidates:          make([]PriceRuleCandidate, 0),
+	}
+
+	ranked := rankPriceRules(rules, shipmentType, origin, dest)
+	top := topRankedPriceRules(ranked)
+	for i, rr := range ranked {
+		res.Candidates = append(res.Candidates, PriceRuleCandidate{
+			Rule:   rr.rule,
+			Score:  rr.score,
+			Winner: i == 0,
+			Tied:   len(top) > 1 && i < len(top),
+		})
+	}
+	if len(ranked) > 0 {
+		w := ranked[0].rule
+		res.Winner = &w
+		res.Ambiguous = len(top) > 1
+	}
+
+	// Inactivas al final, solo informativas
+	for _, r := range rules {
+		if !r.Active && r.Matches(shipmentType, origin, dest) {
+			res.Candidates = append(res.Candidates, PriceRuleCandidate{Rule: r, Score: domain.RuleScore(r, origin, dest)})
+		}
+	}
+
+	return res, nil
+}
+
+type rankedPriceRule struct {
+	rule  domain.PriceRule
+	score int
+}
+
+// rankPriceRules devuelve las reglas activas que aplican a la ruta ordenadas como las
+// elegiría FindMatch (especificidad, luego Priority). El ID solo da un orden estable al reporte.
+func rankPriceRules(rules []domain.PriceRule, shipmentType, origin, dest string) []rankedPriceRule {
+	out := make([]rankedPriceRule, 0)
+	for _, r := range rules {
+		if !r.Active || !r.Matches(shipmentType, origin, dest) {
+			continue
+		}
+		out = append(out, rankedPriceRule{rule: r, score: domain.RuleScore(r, origin, dest)})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if domain.Outranks(out[i].rule, out[i].score, out[j].rule, out[j].score) {
+			return true
+		}
+		if domain.Outranks(out[j].rule, out[j].score, out[i].rule, out[i].score) {
+			return false
+		}
+		return out[i].rule.ID < out[j].rule.ID
+	})
+	return out
+}
+
+// topRankedPriceRules devuelve el grupo de reglas empatadas en el primer puesto.
+func topRankedPriceRules(ranked []rankedPriceRule) []rankedPriceRule {
+	if len(ranked) == 0 {
+		return nil
+	}
+	n := 1
+	for n < len(ranked) && !domain.Outranks(ranked[0].rule, ranked[0].score, ranked[n].rule, ranked[n].score) {
+		n++
+	}
+	return ranked[:n]
+}
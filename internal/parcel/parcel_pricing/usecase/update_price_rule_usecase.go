@@ -10,6 +10,7 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_pricing/domain"
 	"ms-parcel-core/internal/parcel/parcel_pricing/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type UpdatePriceRuleUseCase struct {
@@ -34,24 +35,17 @@ type UpdatePriceRuleInput struct {
 }
 
 func (u *UpdatePriceRuleUseCase) Execute(ctx context.Context, in UpdatePriceRuleInput) (*domain.PriceRule, error) {
+	ctx, span := tracing.StartUseCase(ctx, "UpdatePriceRule", tracing.TenantID(in.TenantID))
+	defer span.End()
+
 	if strings.TrimSpace(in.TenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
 	if in.ID == uuid.Nil {
 		return nil, apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"})
 	}
-	if in.Price <= 0 {
-		return nil, apperror.NewBadRequest("validation_error", "price inválido", map[string]any{"field": "price"})
-	}
-	switch strings.TrimSpace(in.Unit) {
-	case string(domain.PriceUnitPerKg), string(domain.PriceUnitPerItem):
-	default:
-		return nil, apperror.NewBadRequest("validation_error", "unit inválido", map[string]any{"field": "unit"})
-	}
-	switch strings.TrimSpace(in.Currency) {
-	case "PEN", "USD":
-	default:
-		return nil, apperror.NewBadRequest("validation_error", "currency inválido", map[string]any{"field": "currency"})
+	if err := validatePriceRuleFields(in.Price, in.Unit, in.Currency); err != nil {
+		return nil, err
 	}
 
 	r := domain.PriceRule{
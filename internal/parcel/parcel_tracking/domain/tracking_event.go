@@ -1,11 +1,40 @@
 package domain
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// TrackingSource es desde dónde se registró el evento.
+type TrackingSource string
+
+const (
+	TrackingSourceCounter    TrackingSource = "COUNTER"
+	TrackingSourceScannerApp TrackingSource = "SCANNER_APP"
+	TrackingSourceAPI        TrackingSource = "API"
+	// TrackingSourceSystem son procesos internos (workers, parcelctl); un cliente no puede declararlo
+	TrackingSourceSystem TrackingSource = "SYSTEM"
+)
+
+// ParseTrackingSource acepta el origen sin distinguir mayúsculas ("scanner_app" -> SCANNER_APP).
+func ParseTrackingSource(s string) (TrackingSource, bool) {
+	switch src := TrackingSource(strings.ToUpper(strings.TrimSpace(s))); src {
+	case TrackingSourceCounter, TrackingSourceScannerApp, TrackingSourceAPI, TrackingSourceSystem:
+		return src, true
+	default:
+		return "", false
+	}
+}
+
+// GeoPoint es la posición GPS reportada por el dispositivo que registró el evento.
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+	AccuracyM *float64
+}
+
 type TrackingEvent struct {
 	ID         uuid.UUID
 	ParcelID   string
@@ -14,4 +43,15 @@ type TrackingEvent struct {
 	UserID     string
 	UserName   string
 	Metadata   map[string]any
+	// Seq es el orden de registro, creciente en todos los tenants; lo asigna el repositorio al guardar y
+	// es el id de los eventos del stream SSE
+	Seq int64
+
+	// Dónde y desde qué ocurrió: oficina y vehículo involucrados, posición opcional, origen y el id
+	// que correlaciona el evento con el request (o proceso) que lo generó
+	OfficeID      *string
+	VehicleID     *string
+	Location      *GeoPoint
+	Source        TrackingSource
+	CorrelationID *string
 }
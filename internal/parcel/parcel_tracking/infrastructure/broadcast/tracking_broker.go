@@ -0,0 +1,57 @@
+// Package broadcast reparte en el proceso los avisos de eventos de tracking nuevos a los streams SSE.
+package broadcast
+
+import (
+	"sync"
+
+	"ms-parcel-core/internal/parcel/parcel_tracking/port"
+)
+
+// TrackingBroker es un pub/sub en memoria por tenant. Solo ve los eventos registrados en esta réplica;
+// los streams cubren el resto leyendo el repositorio en cada heartbeat.
+type TrackingBroker struct {
+	mu   sync.Mutex
+	subs map[string]map[chan struct{}]struct{}
+}
+
+var _ port.TrackingNotifier = (*TrackingBroker)(nil)
+var _ port.TrackingSubscriber = (*TrackingBroker)(nil)
+
+func NewTrackingBroker() *TrackingBroker {
+	return &TrackingBroker{subs: map[string]map[chan struct{}]struct{}{}}
+}
+
+// Notify no bloquea: si el suscriptor ya tiene un aviso pendiente, el nuevo se agrupa con ese.
+func (b *TrackingBroker) Notify(tenantID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs[tenantID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (b *TrackingBroker) Subscribe(tenantID string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	if b.subs[tenantID] == nil {
+		b.subs[tenantID] = map[chan struct{}]struct{}{}
+	}
+	b.subs[tenantID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subs[tenantID], ch)
+			if len(b.subs[tenantID]) == 0 {
+				delete(b.subs, tenantID)
+			}
+		})
+	}
+}
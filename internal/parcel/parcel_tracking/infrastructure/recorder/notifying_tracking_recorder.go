@@ -0,0 +1,31 @@
+package recorder
+
+import (
+	"context"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	trackingport "ms-parcel-core/internal/parcel/parcel_tracking/port"
+)
+
+// NotifyingTrackingRecorder decora un TrackingRecorder: cuando la unidad de trabajo se confirma avisa
+// a los streams del tenant. Si la transición se revierte no avisa, así nadie lee un evento que no quedó.
+type NotifyingTrackingRecorder struct {
+	next     coreport.TrackingRecorder
+	notifier trackingport.TrackingNotifier
+}
+
+var _ coreport.TrackingRecorder = (*NotifyingTrackingRecorder)(nil)
+
+func NewNotifyingTrackingRecorder(next coreport.TrackingRecorder, notifier trackingport.TrackingNotifier) *NotifyingTrackingRecorder {
+	return &NotifyingTrackingRecorder{next: next, notifier: notifier}
+}
+
+func (r *NotifyingTrackingRecorder) RecordEvent(ctx context.Context, tenantID string, ev coreport.TrackingEventDTO) error {
+	if err := r.next.RecordEvent(ctx, tenantID, ev); err != nil {
+		return err
+	}
+	coreport.AfterCommit(ctx, func() {
+		r.notifier.Notify(tenantID)
+	})
+	return nil
+}
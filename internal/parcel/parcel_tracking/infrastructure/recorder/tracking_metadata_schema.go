@@ -0,0 +1,165 @@
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+)
+
+type metadataKind string
+
+const (
+	kindString metadataKind = "string"
+	kindUUID   metadataKind = "uuid"
+	kindTime   metadataKind = "RFC3339"
+	kindNumber metadataKind = "number"
+)
+
+type metadataField struct {
+	kind     metadataKind
+	required bool
+}
+
+func required(kind metadataKind) metadataField { return metadataField{kind: kind, required: true} }
+func optional(kind metadataKind) metadataField { return metadataField{kind: kind} }
+
+// trackingMetadataSchemas define los campos de metadata de cada tipo de evento. Un tipo sin schema o
+// un campo que no está en el schema se rechazan: la metadata es parte del contrato de la línea de
+// tiempo y de los webhooks, no un cajón libre.
+var trackingMetadataSchemas = map[string]map[string]metadataField{
+	coreport.EventTypeParcelCreated: {
+		"shipment_type":         required(kindString),
+		"origin_office_id":      required(kindUUID),
+		"destination_office_id": required(kindUUID),
+	},
+	coreport.EventTypeParcelRegistered: {},
+	coreport.EventTypeParcelBoarded: {
+		"vehicle_id":   required(kindUUID),
+		"trip_id":      optional(kindUUID),
+		"departure_at": optional(kindTime),
+	},
+	coreport.EventTypeParcelInTransit: {
+		"departure_office_id": required(kindUUID),
+		"departed_at":         required(kindTime),
+		"departed_by_user_id": required(kindString),
+		"vehicle_id":          optional(kindUUID),
+	},
+	coreport.EventTypeParcelArrivedDestination: {
+		"destination_office_id": required(kindUUID),
+		"arrived_at":            required(kindTime),
+		"arrived_by_user_id":    required(kindString),
+	},
+	coreport.EventTypeParcelDelivered: {
+		"delivered_at":         required(kindTime),
+		"delivered_by_user_id": required(kindString),
+	},
+	coreport.EventTypeParcelItemAdded: {
+		"item_id":   required(kindUUID),
+		"quantity":  required(kindNumber),
+		"weight_kg": required(kindNumber),
+	},
+	coreport.EventTypeParcelItemRemoved: {
+		"item_id": required(kindUUID),
+	},
+	coreport.EventTypePaymentRefunded: {
+		"transaction_id":      required(kindString),
+		"amount":              required(kindNumber),
+		"currency":            required(kindString),
+		"reason":              required(kindString),
+		"approved_by_user_id": required(kindString),
+		"channel":             optional(kindString),
+		"cashbox_id":          optional(kindString),
+		"payment_status":      required(kindString),
+	},
+	coreport.EventTypePaymentVoided: {
+		"transaction_id":          required(kindString),
+		"reverses_transaction_id": required(kindString),
+		"amount":                  required(kindNumber),
+		"currency":                required(kindString),
+		"reason":                  required(kindString),
+		"approved_by_user_id":     required(kindString),
+		"payment_status":          required(kindString),
+	},
+}
+
+// normalizeMetadata pasa la metadata por JSON para validar y guardar los mismos tipos que se leen
+// después (string, float64, ...), sin importar si el caso de uso puso un *string o un tipo propio.
+func normalizeMetadata(md map[string]any) (map[string]any, error) {
+	if len(md) == 0 {
+		return map[string]any{}, nil
+	}
+	raw, err := json.Marshal(md)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// validateMetadata devuelve un error por campo inválido, faltante o desconocido (ordenados por campo).
+func validateMetadata(eventType string, md map[string]any) []string {
+	schema, ok := trackingMetadataSchemas[eventType]
+	if !ok {
+		return []string{fmt.Sprintf("tipo de evento desconocido: %s", eventType)}
+	}
+
+	var problems []string
+	for key, v := range md {
+		f, ok := schema[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: campo no permitido", key))
+			continue
+		}
+		if v == nil {
+			if f.required {
+				problems = append(problems, fmt.Sprintf("%s: requerido", key))
+			}
+			continue
+		}
+		if !f.kind.accepts(v) {
+			problems = append(problems, fmt.Sprintf("%s: se espera %s", key, f.kind))
+		}
+	}
+	for key, f := range schema {
+		if _, ok := md[key]; f.required && !ok {
+			problems = append(problems, fmt.Sprintf("%s: requerido", key))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+func (k metadataKind) accepts(v any) bool {
+	switch k {
+	case kindNumber:
+		_, ok := v.(float64)
+		return ok
+	case kindString:
+		_, ok := v.(string)
+		return ok
+	case kindUUID:
+		s, ok := v.(string)
+		if !ok {
+			return false
+		}
+		_, err := uuid.Parse(s)
+		return err == nil
+	case kindTime:
+		s, ok := v.(string)
+		if !ok {
+			return false
+		}
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	default:
+		return false
+	}
+}
@@ -2,14 +2,22 @@ package recorder
 
 import (
 	"context"
+	"math"
+	"strings"
 
 	"github.com/google/uuid"
 
 	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
 	"ms-parcel-core/internal/parcel/parcel_tracking/domain"
 	trackingport "ms-parcel-core/internal/parcel/parcel_tracking/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/requestctx"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
+// maxCorrelationIDLength coincide con el largo máximo del X-Request-ID aceptado.
+const maxCorrelationIDLength = 128
+
 type TrackingRecorderAdapter struct {
 	repo trackingport.TrackingRepository
 }
@@ -20,7 +28,32 @@ func NewTrackingRecorderAdapter(repo trackingport.TrackingRepository) *TrackingR
 	return &TrackingRecorderAdapter{repo: repo}
 }
 
+// RecordEvent valida el evento contra el schema de su tipo y completa origen, posición y correlación
+// desde el request. Un evento inválido es un error del caso de uso que lo arma: se devuelve como
+// error interno y la transición se revierte.
 func (a *TrackingRecorderAdapter) RecordEvent(ctx context.Context, tenantID string, ev coreport.TrackingEventDTO) error {
+	ctx, span := tracing.Start(ctx, "TrackingRecorderAdapter.RecordEvent", tracing.TenantID(tenantID))
+	defer span.End()
+
+	te, err := toTrackingEvent(ctx, ev)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	return a.repo.Append(ctx, tenantID, te)
+}
+
+func toTrackingEvent(ctx context.Context, ev coreport.TrackingEventDTO) (domain.TrackingEvent, error) {
+	invalid := func(problems ...string) error {
+		return apperror.NewInternal("invalid_tracking_event", "evento de tracking inválido", map[string]any{"event_type": ev.EventType, "problems": problems})
+	}
+
+	md, err := normalizeMetadata(ev.Metadata)
+	if err != nil {
+		return domain.TrackingEvent{}, invalid("metadata: " + err.Error())
+	}
+	problems := validateMetadata(ev.EventType, md)
+
 	te := domain.TrackingEvent{
 		ID:         uuid.New(),
 		ParcelID:   ev.ParcelID,
@@ -28,7 +61,73 @@ func (a *TrackingRecorderAdapter) RecordEvent(ctx context.Context, tenantID stri
 		OccurredAt: ev.OccurredAt,
 		UserID:     ev.UserID,
 		UserName:   ev.UserName,
-		Metadata:   ev.Metadata,
+		Metadata:   md,
 	}
-	return a.repo.Append(ctx, tenantID, te)
+
+	if id, ok, problem := optionalUUID("office_id", ev.OfficeID); problem != "" {
+		problems = append(problems, problem)
+	} else if ok {
+		te.OfficeID = &id
+	}
+	if id, ok, problem := optionalUUID("vehicle_id", ev.VehicleID); problem != "" {
+		problems = append(problems, problem)
+	} else if ok {
+		te.VehicleID = &id
+	}
+
+	source := ev.Source
+	if strings.TrimSpace(source) == "" {
+		source = requestctx.EventSource(ctx)
+	}
+	if strings.TrimSpace(source) == "" {
+		te.Source = domain.TrackingSourceSystem
+	} else if s, ok := domain.ParseTrackingSource(source); ok {
+		te.Source = s
+	} else {
+		problems = append(problems, "source: valor no permitido")
+	}
+
+	loc := ev.Location
+	if loc == nil {
+		if l, ok := requestctx.DeviceLocation(ctx); ok {
+			loc = &coreport.TrackingLocation{Latitude: l.Latitude, Longitude: l.Longitude, AccuracyM: l.AccuracyM}
+		}
+	}
+	if loc != nil {
+		if !validCoordinate(loc.Latitude, 90) || !validCoordinate(loc.Longitude, 180) || (loc.AccuracyM != nil && (*loc.AccuracyM < 0 || math.IsNaN(*loc.AccuracyM))) {
+			problems = append(problems, "location: coordenadas fuera de rango")
+		} else {
+			te.Location = &domain.GeoPoint{Latitude: loc.Latitude, Longitude: loc.Longitude, AccuracyM: loc.AccuracyM}
+		}
+	}
+
+	correlationID := strings.TrimSpace(ev.CorrelationID)
+	if correlationID == "" {
+		correlationID = requestctx.RequestID(ctx)
+	}
+	if len(correlationID) > maxCorrelationIDLength {
+		problems = append(problems, "correlation_id: demasiado largo")
+	} else if correlationID != "" {
+		te.CorrelationID = &correlationID
+	}
+
+	if len(problems) > 0 {
+		return domain.TrackingEvent{}, invalid(problems...)
+	}
+	return te, nil
+}
+
+func optionalUUID(field string, v string) (string, bool, string) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return "", false, ""
+	}
+	if _, err := uuid.Parse(v); err != nil {
+		return "", false, field + ": se espera uuid"
+	}
+	return v, true, ""
+}
+
+func validCoordinate(v float64, limit float64) bool {
+	return !math.IsNaN(v) && v >= -limit && v <= limit
 }
@@ -5,14 +5,17 @@ import (
 	"sort"
 	"sync"
 
+	"ms-parcel-core/internal/infrastructure/persistence/memory"
 	"ms-parcel-core/internal/parcel/parcel_tracking/domain"
 	"ms-parcel-core/internal/parcel/parcel_tracking/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type InMemoryTrackingRepository struct {
 	mu   sync.Mutex
 	data map[string]map[string][]domain.TrackingEvent // tenantID -> parcelID -> events
+	seq  int64
 }
 
 var _ port.TrackingRepository = (*InMemoryTrackingRepository)(nil)
@@ -22,7 +25,8 @@ func NewInMemoryTrackingRepository() *InMemoryTrackingRepository {
 }
 
 func (r *InMemoryTrackingRepository) Append(ctx context.Context, tenantID string, ev domain.TrackingEvent) error {
-	_ = ctx
+	_, span := tracing.Start(ctx, "TrackingRepository.Append", tracing.TenantID(tenantID))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -33,12 +37,27 @@ func (r *InMemoryTrackingRepository) Append(ctx context.Context, tenantID string
 	if _, ok := r.data[tenantID]; !ok {
 		r.data[tenantID] = map[string][]domain.TrackingEvent{}
 	}
+	r.seq++
+	ev.Seq = r.seq
 	r.data[tenantID][ev.ParcelID] = append(r.data[tenantID][ev.ParcelID], ev)
+
+	memory.OnRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		evs := r.data[tenantID][ev.ParcelID]
+		for i := len(evs) - 1; i >= 0; i-- {
+			if evs[i].ID == ev.ID {
+				r.data[tenantID][ev.ParcelID] = append(evs[:i:i], evs[i+1:]...)
+				break
+			}
+		}
+	})
 	return nil
 }
 
 func (r *InMemoryTrackingRepository) ListByParcelID(ctx context.Context, tenantID string, parcelID string) ([]domain.TrackingEvent, error) {
-	_ = ctx
+	_, span := tracing.Start(ctx, "TrackingRepository.ListByParcelID", tracing.TenantID(tenantID), tracing.ParcelID(parcelID))
+	defer span.End()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -62,3 +81,40 @@ func (r *InMemoryTrackingRepository) ListByParcelID(ctx context.Context, tenantI
 
 	return out, nil
 }
+
+func (r *InMemoryTrackingRepository) ListSince(ctx context.Context, tenantID string, afterSeq int64, limit int) ([]domain.TrackingEvent, error) {
+	_, span := tracing.Start(ctx, "TrackingRepository.ListSince", tracing.TenantID(tenantID))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.data == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio tracking no inicializado", nil)
+	}
+
+	out := []domain.TrackingEvent{}
+	for _, evs := range r.data[tenantID] {
+		for _, ev := range evs {
+			if ev.Seq > afterSeq {
+				out = append(out, ev)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Seq < out[j].Seq
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (r *InMemoryTrackingRepository) LatestSeq(ctx context.Context) (int64, error) {
+	_, span := tracing.Start(ctx, "TrackingRepository.LatestSeq")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.seq, nil
+}
@@ -0,0 +1,12 @@
+package port
+
+// TrackingNotifier avisa que el tenant tiene eventos de tracking nuevos; se llama después del commit.
+type TrackingNotifier interface {
+	Notify(tenantID string)
+}
+
+// TrackingSubscriber entrega los avisos de un tenant. El canal agrupa avisos (no trae los eventos: el
+// suscriptor los lee del repositorio desde su cursor) y cancel libera la suscripción.
+type TrackingSubscriber interface {
+	Subscribe(tenantID string) (<-chan struct{}, func())
+}
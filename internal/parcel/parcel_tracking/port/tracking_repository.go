@@ -9,4 +9,10 @@ import (
 type TrackingRepository interface {
 	Append(ctx context.Context, tenantID string, ev domain.TrackingEvent) error
 	ListByParcelID(ctx context.Context, tenantID string, parcelID string) ([]domain.TrackingEvent, error)
+	// ListSince devuelve hasta limit eventos del tenant con Seq > afterSeq, en orden de Seq. Dentro
+	// de un tenant el Seq sigue el orden de confirmación: un evento que se confirma después de leer
+	// nunca queda con Seq <= afterSeq, así el cursor no lo saltea.
+	ListSince(ctx context.Context, tenantID string, afterSeq int64, limit int) ([]domain.TrackingEvent, error)
+	// LatestSeq devuelve el último Seq asignado (0 si no hay eventos).
+	LatestSeq(ctx context.Context) (int64, error)
 }
@@ -7,6 +7,7 @@ import (
 	"ms-parcel-core/internal/parcel/parcel_tracking/domain"
 	"ms-parcel-core/internal/parcel/parcel_tracking/port"
 	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
 )
 
 type ListTrackingUseCase struct {
@@ -18,6 +19,9 @@ func NewListTrackingUseCase(repo port.TrackingRepository) *ListTrackingUseCase {
 }
 
 func (u *ListTrackingUseCase) Execute(ctx context.Context, tenantID string, parcelID string) ([]domain.TrackingEvent, error) {
+	ctx, span := tracing.StartUseCase(ctx, "ListTracking", tracing.TenantID(tenantID), tracing.ParcelID(parcelID))
+	defer span.End()
+
 	if strings.TrimSpace(tenantID) == "" {
 		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
 	}
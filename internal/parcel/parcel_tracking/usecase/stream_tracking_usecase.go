@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	coredomain "ms-parcel-core/internal/parcel/parcel_core/domain"
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_tracking/domain"
+	"ms-parcel-core/internal/parcel/parcel_tracking/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+// trackingStreamPageSize es cuántos eventos se leen del repositorio por vuelta.
+const trackingStreamPageSize = 100
+
+type StreamTrackingInput struct {
+	TenantID  string
+	ParcelID  string
+	OfficeID  string
+	VehicleID string
+	// LastEventID es el Seq del último evento recibido; vacío empieza desde el evento más reciente
+	LastEventID string
+}
+
+type StreamTrackingUseCase struct {
+	repo       port.TrackingRepository
+	parcels    coreport.ParcelReader
+	subscriber port.TrackingSubscriber
+}
+
+func NewStreamTrackingUseCase(repo port.TrackingRepository, parcels coreport.ParcelReader, subscriber port.TrackingSubscriber) *StreamTrackingUseCase {
+	return &StreamTrackingUseCase{repo: repo, parcels: parcels, subscriber: subscriber}
+}
+
+// TrackingStream es una suscripción abierta: el transporte espera Notifications (o su propio
+// heartbeat) y llama a Next hasta que no haya más eventos. Close libera la suscripción.
+type TrackingStream struct {
+	repo      port.TrackingRepository
+	parcels   coreport.ParcelReader
+	tenantID  string
+	parcelID  string
+	officeID  string
+	vehicleID string
+	cursor    int64
+	notify    <-chan struct{}
+	cancel    func()
+}
+
+func (u *StreamTrackingUseCase) Open(ctx context.Context, in StreamTrackingInput) (*TrackingStream, error) {
+	ctx, span := tracing.StartUseCase(ctx, "StreamTracking", tracing.TenantID(in.TenantID))
+	defer span.End()
+
+	tenantID := strings.TrimSpace(in.TenantID)
+	if tenantID == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+
+	s := &TrackingStream{repo: u.repo, parcels: u.parcels, tenantID: tenantID}
+	for _, f := range []struct {
+		field string
+		value string
+		dst   *string
+	}{
+		{"parcel_id", in.ParcelID, &s.parcelID},
+		{"office_id", in.OfficeID, &s.officeID},
+		{"vehicle_id", in.VehicleID, &s.vehicleID},
+	} {
+		v := strings.TrimSpace(f.value)
+		if v == "" {
+			continue
+		}
+		if _, err := uuid.Parse(v); err != nil {
+			return nil, apperror.NewBadRequest("validation_error", f.field+" inválido", map[string]any{"field": f.field})
+		}
+		*f.dst = v
+	}
+
+	resumeFrom := int64(-1)
+	if v := strings.TrimSpace(in.LastEventID); v != "" {
+		seq, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || seq < 0 {
+			return nil, apperror.NewBadRequest("validation_error", "Last-Event-ID inválido", map[string]any{"field": "last_event_id"})
+		}
+		resumeFrom = seq
+	}
+
+	// Se suscribe antes de leer el último Seq para no perder eventos registrados entre medio
+	s.notify, s.cancel = u.subscriber.Subscribe(tenantID)
+	if resumeFrom >= 0 {
+		s.cursor = resumeFrom
+		return s, nil
+	}
+	latest, err := u.repo.LatestSeq(ctx)
+	if err != nil {
+		s.cancel()
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	s.cursor = latest
+	return s, nil
+}
+
+func (s *TrackingStream) Notifications() <-chan struct{} {
+	return s.notify
+}
+
+func (s *TrackingStream) Close() {
+	s.cancel()
+}
+
+// Next lee la siguiente página desde el cursor y devuelve los eventos que pasan los filtros; more
+// indica que la página vino llena y conviene volver a llamar.
+func (s *TrackingStream) Next(ctx context.Context) ([]domain.TrackingEvent, bool, error) {
+	evs, err := s.repo.ListSince(ctx, s.tenantID, s.cursor, trackingStreamPageSize)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(evs) == 0 {
+		return nil, false, nil
+	}
+	s.cursor = evs[len(evs)-1].Seq
+
+	// Los parcels se leen una vez por página: el vehículo cambia al embarcar
+	parcels := map[string]*coredomain.Parcel{}
+	out := make([]domain.TrackingEvent, 0, len(evs))
+	for _, ev := range evs {
+		ok, err := s.matches(ctx, ev, parcels)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			out = append(out, ev)
+		}
+	}
+	return out, len(evs) == trackingStreamPageSize, nil
+}
+
+func (s *TrackingStream) matches(ctx context.Context, ev domain.TrackingEvent, parcels map[string]*coredomain.Parcel) (bool, error) {
+	if s.parcelID != "" && !strings.EqualFold(ev.ParcelID, s.parcelID) {
+		return false, nil
+	}
+	if s.officeID == "" && s.vehicleID == "" {
+		return true, nil
+	}
+
+	p, ok := parcels[ev.ParcelID]
+	if !ok {
+		id, err := uuid.Parse(ev.ParcelID)
+		if err == nil {
+			p, err = s.parcels.GetByID(ctx, s.tenantID, id)
+			if err != nil {
+				return false, err
+			}
+		}
+		parcels[ev.ParcelID] = p
+	}
+
+	if s.officeID != "" {
+		match := ev.OfficeID != nil && strings.EqualFold(*ev.OfficeID, s.officeID)
+		if p != nil && (strings.EqualFold(p.OriginOfficeID, s.officeID) || strings.EqualFold(p.DestinationOfficeID, s.officeID)) {
+			match = true
+		}
+		if !match {
+			return false, nil
+		}
+	}
+	if s.vehicleID != "" {
+		match := ev.VehicleID != nil && strings.EqualFold(*ev.VehicleID, s.vehicleID)
+		if p != nil && p.BoardedVehicleID != nil && strings.EqualFold(*p.BoardedVehicleID, s.vehicleID) {
+			match = true
+		}
+		if !match {
+			return false, nil
+		}
+	}
+	return true, nil
+}
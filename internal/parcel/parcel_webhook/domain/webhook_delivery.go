@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending    DeliveryStatus = "PENDING"
+	DeliveryStatusDelivering DeliveryStatus = "DELIVERING"
+	DeliveryStatusDelivered  DeliveryStatus = "DELIVERED"
+	// DeliveryStatusDead: se agotaron los intentos o la suscripción ya no está activa (dead-letter).
+	// Se puede reencolar a mano desde la API.
+	DeliveryStatusDead DeliveryStatus = "DEAD"
+)
+
+// WebhookDelivery es el envío de un evento a una suscripción, con su historial de intentos.
+type WebhookDelivery struct {
+	ID             string
+	TenantID       string
+	SubscriptionID string
+	// EventID identifica el evento: es el mismo en todos los intentos y en todas las suscripciones,
+	// así el receptor puede descartar duplicados.
+	EventID   string
+	EventType string
+	ParcelID  string
+	// Payload es el cuerpo JSON exacto que se envía (y se firma) en cada intento.
+	Payload        string
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int
+	LastError      *string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// WebhookEvent es el cuerpo que recibe el endpoint del tenant.
+type WebhookEvent struct {
+	ID         string             `json:"id"`
+	Type       string             `json:"type"`
+	TenantID   string             `json:"tenant_id"`
+	OccurredAt time.Time          `json:"occurred_at"`
+	Parcel     WebhookEventParcel `json:"parcel"`
+	Data       map[string]any     `json:"data"`
+}
+
+type WebhookEventParcel struct {
+	ID           string `json:"id"`
+	TrackingCode string `json:"tracking_code,omitempty"`
+	Status       string `json:"status,omitempty"`
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// WebhookSubscription es un endpoint de un tenant que recibe los eventos de tracking de sus envíos.
+type WebhookSubscription struct {
+	ID       string
+	TenantID string
+	URL      string
+	// Secret firma cada entrega (HMAC-SHA256); se muestra solo al crear o rotar.
+	Secret string
+	// EventTypes filtra por tipo de evento de tracking; vacío = todos los soportados.
+	EventTypes      []string
+	Active          bool
+	Description     *string
+	CreatedByUserID *string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Accepts indica si la suscripción recibe eventos del tipo indicado.
+func (s WebhookSubscription) Accepts(eventType string) bool {
+	if !s.Active {
+		return false
+	}
+	return len(s.EventTypes) == 0 || slices.Contains(s.EventTypes, eventType)
+}
+
+// SecretHint devuelve los últimos caracteres del secreto, para identificarlo sin exponerlo.
+func (s WebhookSubscription) SecretHint() string {
+	if len(s.Secret) <= 4 {
+		return ""
+	}
+	return "…" + s.Secret[len(s.Secret)-4:]
+}
@@ -0,0 +1,56 @@
+package dispatcher
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	// Enabled permite apagar el envío en réplicas que no deben hacerlo; las entregas se siguen encolando.
+	Enabled     bool
+	Interval    time.Duration
+	Batch       int
+	HTTPTimeout time.Duration
+	// AllowInsecureURLs (solo desarrollo) acepta URLs http y destinos locales o privados.
+	AllowInsecureURLs bool
+}
+
+// ConfigFromEnv lee WEBHOOK_DISPATCH_ENABLED (default true), WEBHOOK_DISPATCH_INTERVAL_MS,
+// WEBHOOK_DISPATCH_BATCH, WEBHOOK_HTTP_TIMEOUT_MS y WEBHOOK_ALLOW_INSECURE_URLS (default false,
+// ignorado con APP_ENV=prod|production).
+func ConfigFromEnv() Config {
+	enabled := true
+	if v, err := strconv.ParseBool(strings.TrimSpace(os.Getenv("WEBHOOK_DISPATCH_ENABLED"))); err == nil {
+		enabled = v
+	}
+	insecure, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv("WEBHOOK_ALLOW_INSECURE_URLS")))
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("APP_ENV"))) {
+	case "prod", "production":
+		insecure = false
+	}
+	return Config{
+		Enabled:           enabled,
+		Interval:          envDurationMs("WEBHOOK_DISPATCH_INTERVAL_MS", 2*time.Second),
+		Batch:             envInt("WEBHOOK_DISPATCH_BATCH", 50),
+		HTTPTimeout:       envDurationMs("WEBHOOK_HTTP_TIMEOUT_MS", 10*time.Second),
+		AllowInsecureURLs: insecure,
+	}
+}
+
+func envInt(key string, def int) int {
+	v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || v <= 0 {
+		return def
+	}
+	return v
+}
+
+func envDurationMs(key string, def time.Duration) time.Duration {
+	v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || v <= 0 {
+		return def
+	}
+	return time.Duration(v) * time.Millisecond
+}
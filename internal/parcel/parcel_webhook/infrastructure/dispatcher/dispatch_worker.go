@@ -0,0 +1,58 @@
+package dispatcher
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/usecase"
+	"ms-parcel-core/internal/pkg/util/logging"
+)
+
+// DispatchWorker ejecuta periódicamente el envío de entregas de webhooks.
+type DispatchWorker struct {
+	uc       *usecase.DispatchWebhookDeliveriesUseCase
+	interval time.Duration
+	batch    int
+	logger   *slog.Logger
+}
+
+func NewDispatchWorker(uc *usecase.DispatchWebhookDeliveriesUseCase, interval time.Duration, batch int, logger *slog.Logger) *DispatchWorker {
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	if batch <= 0 {
+		batch = 50
+	}
+	return &DispatchWorker{uc: uc, interval: interval, batch: batch, logger: logging.OrDiscard(logger)}
+}
+
+// Run bloquea hasta que ctx se cancele. Si un lote sale lleno se sigue sin esperar el próximo tick.
+func (w *DispatchWorker) Run(ctx context.Context) {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			for w.dispatch(ctx) && ctx.Err() == nil {
+			}
+		}
+	}
+}
+
+// dispatch envía un lote y devuelve true si quedó lleno y sin fallos (puede haber más pendientes).
+// Con fallos se espera al próximo tick: el endpoint de algún cliente puede estar caído.
+func (w *DispatchWorker) dispatch(ctx context.Context) bool {
+	res, err := w.uc.Execute(ctx, w.batch)
+	if err != nil {
+		w.logger.ErrorContext(ctx, "falló el envío de webhooks", "error", err)
+		return false
+	}
+	if res.Attempted > 0 {
+		w.logger.DebugContext(ctx, "envío de webhooks", "attempted", res.Attempted, "delivered", res.Delivered, "pending", res.Pending, "dead", res.Dead)
+	}
+	return res.Attempted >= w.batch && res.Pending == 0 && res.Dead == 0
+}
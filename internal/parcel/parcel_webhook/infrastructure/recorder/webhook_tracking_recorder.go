@@ -0,0 +1,128 @@
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	webhookport "ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/parcel/parcel_webhook/usecase"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+// WebhookTrackingRecorder decora un TrackingRecorder: después de guardar el evento de tracking encola
+// una entrega por cada suscripción activa del tenant que lo acepta. Usa el mismo ctx, así que dentro
+// de una unidad de trabajo las entregas se confirman (o descartan) junto con la transición.
+type WebhookTrackingRecorder struct {
+	next       coreport.TrackingRecorder
+	subs       webhookport.WebhookSubscriptionRepository
+	deliveries webhookport.WebhookDeliveryRepository
+	parcels    coreport.ParcelReader
+	logger     *slog.Logger
+}
+
+var _ coreport.TrackingRecorder = (*WebhookTrackingRecorder)(nil)
+
+func NewWebhookTrackingRecorder(next coreport.TrackingRecorder, subs webhookport.WebhookSubscriptionRepository, deliveries webhookport.WebhookDeliveryRepository, parcels coreport.ParcelReader, logger *slog.Logger) *WebhookTrackingRecorder {
+	return &WebhookTrackingRecorder{next: next, subs: subs, deliveries: deliveries, parcels: parcels, logger: logging.OrDiscard(logger)}
+}
+
+func (r *WebhookTrackingRecorder) RecordEvent(ctx context.Context, tenantID string, ev coreport.TrackingEventDTO) error {
+	if err := r.next.RecordEvent(ctx, tenantID, ev); err != nil {
+		return err
+	}
+	if !usecase.IsSupportedEventType(ev.EventType) {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "WebhookTrackingRecorder.RecordEvent", tracing.TenantID(tenantID), tracing.ParcelID(ev.ParcelID))
+	defer span.End()
+
+	subs, err := r.subs.ListActive(ctx, tenantID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	targets := make([]domain.WebhookSubscription, 0, len(subs))
+	for _, s := range subs {
+		if s.Accepts(ev.EventType) {
+			targets = append(targets, s)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	payload, eventID, err := r.buildPayload(ctx, tenantID, ev)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, s := range targets {
+		d := domain.WebhookDelivery{
+			ID:             uuid.NewString(),
+			TenantID:       tenantID,
+			SubscriptionID: s.ID,
+			EventID:        eventID,
+			EventType:      ev.EventType,
+			ParcelID:       ev.ParcelID,
+			Payload:        payload,
+			Status:         domain.DeliveryStatusPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if err := r.deliveries.Add(ctx, d); err != nil {
+			tracing.RecordError(span, err)
+			return err
+		}
+	}
+	r.logger.DebugContext(ctx, "entregas de webhook encoladas", "event_type", ev.EventType, "parcel_id", ev.ParcelID, "subscriptions", len(targets))
+	return nil
+}
+
+// buildPayload arma el cuerpo una sola vez: todas las suscripciones reciben los mismos bytes y el mismo id.
+func (r *WebhookTrackingRecorder) buildPayload(ctx context.Context, tenantID string, ev coreport.TrackingEventDTO) (string, string, error) {
+	occurredAt := ev.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+	data := ev.Metadata
+	if data == nil {
+		data = map[string]any{}
+	}
+
+	event := domain.WebhookEvent{
+		ID:         uuid.NewString(),
+		Type:       ev.EventType,
+		TenantID:   tenantID,
+		OccurredAt: occurredAt.UTC(),
+		Parcel:     domain.WebhookEventParcel{ID: ev.ParcelID},
+		Data:       data,
+	}
+	// El recorder se llama después de guardar el envío, así que el estado leído es el posterior al evento
+	if id, err := uuid.Parse(ev.ParcelID); err == nil && r.parcels != nil {
+		p, err := r.parcels.GetByID(ctx, tenantID, id)
+		if err != nil {
+			return "", "", err
+		}
+		if p != nil {
+			event.Parcel.TrackingCode = p.TrackingCode
+			event.Parcel.Status = string(p.Status)
+		}
+	}
+
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return "", "", err
+	}
+	return string(raw), event.ID, nil
+}
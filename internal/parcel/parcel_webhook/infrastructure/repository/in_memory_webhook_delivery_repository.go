@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/infrastructure/persistence/memory"
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	"ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type InMemoryWebhookDeliveryRepository struct {
+	mu         sync.Mutex
+	deliveries []domain.WebhookDelivery // orden de alta
+}
+
+var _ port.WebhookDeliveryRepository = (*InMemoryWebhookDeliveryRepository)(nil)
+
+func NewInMemoryWebhookDeliveryRepository() *InMemoryWebhookDeliveryRepository {
+	return &InMemoryWebhookDeliveryRepository{deliveries: []domain.WebhookDelivery{}}
+}
+
+func (r *InMemoryWebhookDeliveryRepository) Add(ctx context.Context, d domain.WebhookDelivery) error {
+	_, span := tracing.Start(ctx, "WebhookDeliveryRepository.Add", tracing.TenantID(d.TenantID))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.deliveries == nil {
+		return apperror.NewInternal("internal_error", "repositorio de entregas no inicializado", nil)
+	}
+	r.deliveries = append(r.deliveries, d)
+
+	memory.OnRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for i := len(r.deliveries) - 1; i >= 0; i-- {
+			if r.deliveries[i].ID == d.ID {
+				r.deliveries = append(r.deliveries[:i:i], r.deliveries[i+1:]...)
+				break
+			}
+		}
+	})
+	return nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) GetByID(ctx context.Context, tenantID string, subscriptionID uuid.UUID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	_, span := tracing.Start(ctx, "WebhookDeliveryRepository.GetByID", tracing.TenantID(tenantID))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.deliveries == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio de entregas no inicializado", nil)
+	}
+	for _, d := range r.deliveries {
+		if d.TenantID == tenantID && d.SubscriptionID == subscriptionID.String() && d.ID == id.String() {
+			cp := d
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) ListBySubscription(ctx context.Context, tenantID string, subscriptionID uuid.UUID, filters port.ListWebhookDeliveryFilters) ([]domain.WebhookDelivery, int, error) {
+	_, span := tracing.Start(ctx, "WebhookDeliveryRepository.ListBySubscription", tracing.TenantID(tenantID))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.deliveries == nil {
+		return nil, 0, apperror.NewInternal("internal_error", "repositorio de entregas no inicializado", nil)
+	}
+
+	matched := make([]domain.WebhookDelivery, 0)
+	for _, d := range r.deliveries {
+		if d.TenantID != tenantID || d.SubscriptionID != subscriptionID.String() {
+			continue
+		}
+		if filters.Status != nil && d.Status != *filters.Status {
+			continue
+		}
+		if filters.ParcelID != nil && d.ParcelID != *filters.ParcelID {
+			continue
+		}
+		matched = append(matched, d)
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].CreatedAt.After(matched[j].CreatedAt) })
+
+	total := len(matched)
+	if filters.Offset >= total {
+		return []domain.WebhookDelivery{}, total, nil
+	}
+	matched = matched[max(filters.Offset, 0):]
+	if filters.Limit > 0 && len(matched) > filters.Limit {
+		matched = matched[:filters.Limit]
+	}
+	return matched, total, nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	_, span := tracing.Start(ctx, "WebhookDeliveryRepository.ClaimDue")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.deliveries == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio de entregas no inicializado", nil)
+	}
+
+	out := make([]domain.WebhookDelivery, 0)
+	for i := range r.deliveries {
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+		if !webhookDeliveryDue(r.deliveries[i], now) {
+			continue
+		}
+		r.deliveries[i].Status = domain.DeliveryStatusDelivering
+		r.deliveries[i].Attempts++
+		r.deliveries[i].NextAttemptAt = now.Add(lease)
+		r.deliveries[i].UpdatedAt = now
+		out = append(out, r.deliveries[i])
+	}
+	return out, nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) SaveDelivery(ctx context.Context, d domain.WebhookDelivery) error {
+	_, span := tracing.Start(ctx, "WebhookDeliveryRepository.SaveDelivery", tracing.TenantID(d.TenantID))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.deliveries == nil {
+		return apperror.NewInternal("internal_error", "repositorio de entregas no inicializado", nil)
+	}
+	for i := range r.deliveries {
+		if r.deliveries[i].ID != d.ID || r.deliveries[i].TenantID != d.TenantID {
+			continue
+		}
+		if r.deliveries[i].Status != domain.DeliveryStatusDelivering || r.deliveries[i].Attempts != d.Attempts {
+			return port.ErrDeliveryChanged
+		}
+		// Solo se actualizan los campos de entrega; el evento y su payload son inmutables
+		r.deliveries[i].Status = d.Status
+		r.deliveries[i].Attempts = d.Attempts
+		r.deliveries[i].NextAttemptAt = d.NextAttemptAt
+		r.deliveries[i].LastStatusCode = d.LastStatusCode
+		r.deliveries[i].LastError = d.LastError
+		r.deliveries[i].DeliveredAt = d.DeliveredAt
+		r.deliveries[i].UpdatedAt = time.Now().UTC()
+		return nil
+	}
+	return apperror.New("not_found", "entrega no encontrada", map[string]any{"delivery_id": d.ID}, 404)
+}
+
+func (r *InMemoryWebhookDeliveryRepository) Requeue(ctx context.Context, tenantID string, id uuid.UUID, now time.Time) error {
+	_, span := tracing.Start(ctx, "WebhookDeliveryRepository.Requeue", tracing.TenantID(tenantID))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.deliveries {
+		if r.deliveries[i].ID != id.String() || r.deliveries[i].TenantID != tenantID {
+			continue
+		}
+		if r.deliveries[i].Status != domain.DeliveryStatusDead {
+			return port.ErrDeliveryChanged
+		}
+		r.deliveries[i].Status = domain.DeliveryStatusPending
+		r.deliveries[i].Attempts = 0
+		r.deliveries[i].NextAttemptAt = now
+		r.deliveries[i].UpdatedAt = now
+		return nil
+	}
+	return apperror.New("not_found", "entrega no encontrada", map[string]any{"delivery_id": id.String()}, 404)
+}
+
+func webhookDeliveryDue(d domain.WebhookDelivery, now time.Time) bool {
+	switch d.Status {
+	case domain.DeliveryStatusPending, domain.DeliveryStatusDelivering:
+		return !now.Before(d.NextAttemptAt)
+	}
+	return false
+}
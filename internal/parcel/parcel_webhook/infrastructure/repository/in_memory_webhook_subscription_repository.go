@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	"ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type InMemoryWebhookSubscriptionRepository struct {
+	mu   sync.Mutex
+	data map[string]map[uuid.UUID]domain.WebhookSubscription
+}
+
+var _ port.WebhookSubscriptionRepository = (*InMemoryWebhookSubscriptionRepository)(nil)
+
+func NewInMemoryWebhookSubscriptionRepository() *InMemoryWebhookSubscriptionRepository {
+	return &InMemoryWebhookSubscriptionRepository{data: map[string]map[uuid.UUID]domain.WebhookSubscription{}}
+}
+
+func (r *InMemoryWebhookSubscriptionRepository) Create(ctx context.Context, tenantID string, s domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	_, span := tracing.Start(ctx, "WebhookSubscriptionRepository.Create", tracing.TenantID(tenantID))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.data == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio webhooks no inicializado", nil)
+	}
+	if _, ok := r.data[tenantID]; !ok {
+		r.data[tenantID] = map[uuid.UUID]domain.WebhookSubscription{}
+	}
+
+	now := time.Now().UTC()
+	id := uuid.New()
+	s.ID = id.String()
+	s.TenantID = tenantID
+	s.EventTypes = slices.Clone(s.EventTypes)
+	s.CreatedAt = now
+	s.UpdatedAt = now
+
+	r.data[tenantID][id] = s
+	return cloneSubscription(s), nil
+}
+
+func (r *InMemoryWebhookSubscriptionRepository) Update(ctx context.Context, tenantID string, s domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	_, span := tracing.Start(ctx, "WebhookSubscriptionRepository.Update", tracing.TenantID(tenantID))
+	defer span.End()
+
+	id, err := uuid.Parse(s.ID)
+	if err != nil {
+		return nil, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.data == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio webhooks no inicializado", nil)
+	}
+	existing, ok := r.data[tenantID][id]
+	if !ok {
+		return nil, nil
+	}
+
+	s.TenantID = tenantID
+	s.EventTypes = slices.Clone(s.EventTypes)
+	s.CreatedByUserID = existing.CreatedByUserID
+	s.CreatedAt = existing.CreatedAt
+	s.UpdatedAt = time.Now().UTC()
+
+	r.data[tenantID][id] = s
+	return cloneSubscription(s), nil
+}
+
+func (r *InMemoryWebhookSubscriptionRepository) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	_, span := tracing.Start(ctx, "WebhookSubscriptionRepository.GetByID", tracing.TenantID(tenantID))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.data == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio webhooks no inicializado", nil)
+	}
+	s, ok := r.data[tenantID][id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneSubscription(s), nil
+}
+
+func (r *InMemoryWebhookSubscriptionRepository) List(ctx context.Context, tenantID string) ([]domain.WebhookSubscription, error) {
+	_, span := tracing.Start(ctx, "WebhookSubscriptionRepository.List", tracing.TenantID(tenantID))
+	defer span.End()
+
+	return r.list(tenantID, false)
+}
+
+func (r *InMemoryWebhookSubscriptionRepository) ListActive(ctx context.Context, tenantID string) ([]domain.WebhookSubscription, error) {
+	_, span := tracing.Start(ctx, "WebhookSubscriptionRepository.ListActive", tracing.TenantID(tenantID))
+	defer span.End()
+
+	return r.list(tenantID, true)
+}
+
+func (r *InMemoryWebhookSubscriptionRepository) Delete(ctx context.Context, tenantID string, id uuid.UUID) (bool, error) {
+	_, span := tracing.Start(ctx, "WebhookSubscriptionRepository.Delete", tracing.TenantID(tenantID))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.data == nil {
+		return false, apperror.NewInternal("internal_error", "repositorio webhooks no inicializado", nil)
+	}
+	if _, ok := r.data[tenantID][id]; !ok {
+		return false, nil
+	}
+	delete(r.data[tenantID], id)
+	return true, nil
+}
+
+func (r *InMemoryWebhookSubscriptionRepository) list(tenantID string, onlyActive bool) ([]domain.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.data == nil {
+		return nil, apperror.NewInternal("internal_error", "repositorio webhooks no inicializado", nil)
+	}
+	out := make([]domain.WebhookSubscription, 0, len(r.data[tenantID]))
+	for _, s := range r.data[tenantID] {
+		if onlyActive && !s.Active {
+			continue
+		}
+		out = append(out, *cloneSubscription(s))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+func cloneSubscription(s domain.WebhookSubscription) *domain.WebhookSubscription {
+	s.EventTypes = slices.Clone(s.EventTypes)
+	return &s
+}
@@ -0,0 +1,114 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	"ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/pkg/util/netguard"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+// SignatureHeader lleva la firma de la entrega: "t=<unix>,v1=<hex>", donde v1 es
+// HMAC-SHA256(secret, "<unix>.<body>"). El receptor recalcula la firma con su secreto y rechaza
+// timestamps viejos para evitar reenvíos.
+const SignatureHeader = "X-Webhook-Signature"
+
+// HTTPWebhookSender hace POST del payload de la entrega a la URL de la suscripción. Cualquier 2xx
+// confirma la entrega; no reintenta (de eso se ocupa el dispatcher con backoff).
+type HTTPWebhookSender struct {
+	http          *http.Client
+	allowInsecure bool
+}
+
+var _ port.WebhookSender = (*HTTPWebhookSender)(nil)
+
+// NewHTTPWebhookSender crea el sender. httpClient es opcional; si es nil se crea uno con timeout,
+// transporte instrumentado con OpenTelemetry y sin seguir redirecciones (un 3xx es una entrega
+// fallida). Salvo allowInsecure (solo desarrollo) exige https y no conecta a direcciones locales ni
+// privadas: la IP se valida al conectar, después de resolver el nombre.
+func NewHTTPWebhookSender(timeout time.Duration, httpClient *http.Client, allowInsecure bool) *HTTPWebhookSender {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	if httpClient == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if !allowInsecure {
+			// Sin proxy: el control de la IP tiene que ver el destino real, no el del proxy
+			transport.Proxy = nil
+			transport.DialContext = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: netguard.DialControl}).DialContext
+		}
+		httpClient = &http.Client{
+			Timeout:   timeout,
+			Transport: otelhttp.NewTransport(transport),
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+	return &HTTPWebhookSender{http: httpClient, allowInsecure: allowInsecure}
+}
+
+func (s *HTTPWebhookSender) Send(ctx context.Context, sub domain.WebhookSubscription, d domain.WebhookDelivery) (int, error) {
+	ctx, span := tracing.Start(ctx, "HTTPWebhookSender.Send", tracing.TenantID(d.TenantID), tracing.ParcelID(d.ParcelID))
+	defer span.End()
+
+	// Las suscripciones creadas antes de exigir https no se envían por texto plano
+	if u, err := url.Parse(sub.URL); err != nil || (u.Scheme != "https" && !s.allowInsecure) {
+		err := fmt.Errorf("url de webhook no permitida: se requiere https")
+		tracing.RecordError(span, err)
+		return 0, err
+	}
+
+	body := []byte(d.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ms-parcel-core-webhooks/1")
+	// X-Webhook-Id es el id del evento: igual en todos los reintentos, sirve para descartar duplicados
+	req.Header.Set("X-Webhook-Id", d.EventID)
+	req.Header.Set("X-Webhook-Event", d.EventType)
+	req.Header.Set("X-Webhook-Delivery", d.ID)
+	req.Header.Set("X-Webhook-Attempt", strconv.Itoa(d.Attempts))
+	req.Header.Set("X-Webhook-Timestamp", ts)
+	req.Header.Set(SignatureHeader, "t="+ts+",v1="+Sign(sub.Secret, ts, body))
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return 0, err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := fmt.Errorf("entrega rechazada: status %d", resp.StatusCode)
+		tracing.RecordError(span, err)
+		return resp.StatusCode, err
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign calcula la firma v1 de un cuerpo para el timestamp dado.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,40 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+)
+
+type ListWebhookDeliveryFilters struct {
+	Status   *domain.DeliveryStatus
+	ParcelID *string
+	Limit    int
+	Offset   int
+}
+
+// ErrDeliveryChanged indica que la entrega ya no está en el estado esperado: otro dispatcher la
+// retomó al vencer el lease, o ya no está DEAD al reencolarla.
+var ErrDeliveryChanged = errors.New("la entrega de webhook cambió de estado")
+
+type WebhookDeliveryRepository interface {
+	// Add guarda la entrega PENDING; con el ctx de una unidad de trabajo se confirma junto con ella.
+	Add(ctx context.Context, d domain.WebhookDelivery) error
+	GetByID(ctx context.Context, tenantID string, subscriptionID uuid.UUID, id uuid.UUID) (*domain.WebhookDelivery, error)
+	// ListBySubscription devuelve la página pedida (más recientes primero) y el total con los filtros.
+	ListBySubscription(ctx context.Context, tenantID string, subscriptionID uuid.UUID, filters ListWebhookDeliveryFilters) ([]domain.WebhookDelivery, int, error)
+	// ClaimDue toma (todos los tenants) hasta limit entregas listas para enviar: PENDING con
+	// NextAttemptAt vencido, o DELIVERING con lease vencido. Las deja DELIVERING hasta now+lease y
+	// suma un intento.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error)
+	// SaveDelivery guarda el resultado de un intento solo si la entrega sigue DELIVERING con
+	// d.Attempts, es decir, con el reclamo de este intento; si no, devuelve ErrDeliveryChanged.
+	SaveDelivery(ctx context.Context, d domain.WebhookDelivery) error
+	// Requeue vuelve una entrega DEAD a PENDING con los intentos en cero para enviarla en now; si ya
+	// no está DEAD devuelve ErrDeliveryChanged.
+	Requeue(ctx context.Context, tenantID string, id uuid.UUID, now time.Time) error
+}
@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+)
+
+// WebhookSender hace un intento de entrega firmado con el secreto de la suscripción. Devuelve el status
+// HTTP (0 si no hubo respuesta) y error si la entrega no se confirmó con un 2xx.
+type WebhookSender interface {
+	Send(ctx context.Context, sub domain.WebhookSubscription, d domain.WebhookDelivery) (int, error)
+}
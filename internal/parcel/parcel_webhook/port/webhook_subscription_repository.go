@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+)
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, tenantID string, s domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	// Update devuelve nil si la suscripción no existe.
+	Update(ctx context.Context, tenantID string, s domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.WebhookSubscription, error)
+	List(ctx context.Context, tenantID string) ([]domain.WebhookSubscription, error)
+	// ListActive lista las suscripciones activas del tenant (se consulta en cada evento de tracking).
+	ListActive(ctx context.Context, tenantID string) ([]domain.WebhookSubscription, error)
+	// Delete devuelve false si la suscripción no existe.
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) (bool, error)
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	"ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type CreateWebhookSubscriptionUseCase struct {
+	repo      port.WebhookSubscriptionRepository
+	urlPolicy WebhookURLPolicy
+}
+
+func NewCreateWebhookSubscriptionUseCase(repo port.WebhookSubscriptionRepository, urlPolicy WebhookURLPolicy) *CreateWebhookSubscriptionUseCase {
+	return &CreateWebhookSubscriptionUseCase{repo: repo, urlPolicy: urlPolicy}
+}
+
+type CreateWebhookSubscriptionInput struct {
+	TenantID    string
+	UserID      string
+	URL         string
+	EventTypes  []string
+	Active      bool
+	Description *string
+}
+
+// Execute crea la suscripción con un secreto nuevo; es la única respuesta (junto con la rotación)
+// que incluye el secreto completo.
+func (u *CreateWebhookSubscriptionUseCase) Execute(ctx context.Context, in CreateWebhookSubscriptionInput) (*domain.WebhookSubscription, error) {
+	ctx, span := tracing.StartUseCase(ctx, "CreateWebhookSubscription", tracing.TenantID(in.TenantID))
+	defer span.End()
+
+	if strings.TrimSpace(in.TenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	url, err := validateWebhookURL(in.URL, u.urlPolicy)
+	if err != nil {
+		return nil, err
+	}
+	types, err := normalizeEventTypes(in.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	s := domain.WebhookSubscription{
+		URL:         url,
+		Secret:      secret,
+		EventTypes:  types,
+		Active:      in.Active,
+		Description: trimmedOrNil(in.Description),
+	}
+	if uid := strings.TrimSpace(in.UserID); uid != "" {
+		s.CreatedByUserID = &uid
+	}
+
+	created, err := u.repo.Create(ctx, in.TenantID, s)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return created, nil
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type DeleteWebhookSubscriptionUseCase struct {
+	repo port.WebhookSubscriptionRepository
+}
+
+func NewDeleteWebhookSubscriptionUseCase(repo port.WebhookSubscriptionRepository) *DeleteWebhookSubscriptionUseCase {
+	return &DeleteWebhookSubscriptionUseCase{repo: repo}
+}
+
+// Execute borra la suscripción. Sus entregas pendientes pasan a DEAD en el próximo intento del dispatcher.
+func (u *DeleteWebhookSubscriptionUseCase) Execute(ctx context.Context, tenantID string, id uuid.UUID) error {
+	ctx, span := tracing.StartUseCase(ctx, "DeleteWebhookSubscription", tracing.TenantID(tenantID))
+	defer span.End()
+
+	if strings.TrimSpace(tenantID) == "" {
+		return apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	ok, err := u.repo.Delete(ctx, tenantID, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	if !ok {
+		return apperror.New("not_found", "suscripción no encontrada", map[string]any{"id": id.String()}, 404)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	"ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/pkg/util/logging"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+const (
+	// El lote se reserva mientras se envía (ver webhookLeaseFor); si el proceso muere, pasado el
+	// lease otro dispatcher lo vuelve a tomar (el receptor descarta duplicados por X-Webhook-Id).
+	webhookMinLease       = 2 * time.Minute
+	webhookLeaseMargin    = 30 * time.Second
+	webhookDefaultTimeout = 10 * time.Second
+	webhookMaxAttempts    = 10
+	webhookBaseBackoff    = 30 * time.Second
+	webhookMaxBackoff     = time.Hour
+)
+
+var errSubscriptionGone = errors.New("suscripción eliminada o inactiva")
+
+type DispatchWebhookDeliveriesResult struct {
+	Attempted int
+	Delivered int
+	Pending   int
+	Dead      int
+}
+
+// DispatchWebhookDeliveriesUseCase envía las entregas de webhooks pendientes.
+type DispatchWebhookDeliveriesUseCase struct {
+	subs       port.WebhookSubscriptionRepository
+	deliveries port.WebhookDeliveryRepository
+	sender     port.WebhookSender
+	// sendTimeout acota cada envío; con él se dimensiona el lease del lote
+	sendTimeout time.Duration
+	logger      *slog.Logger
+}
+
+func NewDispatchWebhookDeliveriesUseCase(subs port.WebhookSubscriptionRepository, deliveries port.WebhookDeliveryRepository, sender port.WebhookSender, sendTimeout time.Duration, logger *slog.Logger) *DispatchWebhookDeliveriesUseCase {
+	if sendTimeout <= 0 {
+		sendTimeout = webhookDefaultTimeout
+	}
+	return &DispatchWebhookDeliveriesUseCase{subs: subs, deliveries: deliveries, sender: sender, sendTimeout: sendTimeout, logger: logging.OrDiscard(logger)}
+}
+
+func (u *DispatchWebhookDeliveriesUseCase) Execute(ctx context.Context, limit int) (*DispatchWebhookDeliveriesResult, error) {
+	ctx, span := tracing.StartUseCase(ctx, "DispatchWebhookDeliveries")
+	defer span.End()
+
+	if limit <= 0 {
+		limit = 100
+	}
+
+	claimed, err := u.deliveries.ClaimDue(ctx, time.Now().UTC(), webhookLeaseFor(limit, u.sendTimeout), limit)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	res := &DispatchWebhookDeliveriesResult{}
+	for i := range claimed {
+		if ctx.Err() != nil {
+			// las no intentadas quedan DELIVERING y se retoman al vencer el lease
+			break
+		}
+		res.Attempted++
+		switch u.deliver(ctx, claimed[i]).Status {
+		case domain.DeliveryStatusDelivered:
+			res.Delivered++
+		case domain.DeliveryStatusDead:
+			res.Dead++
+		default:
+			res.Pending++
+		}
+	}
+	return res, nil
+}
+
+// deliver envía una entrega ya reclamada y guarda el resultado del intento.
+func (u *DispatchWebhookDeliveriesUseCase) deliver(ctx context.Context, d domain.WebhookDelivery) domain.WebhookDelivery {
+	var (
+		code int
+		err  error
+		sub  *domain.WebhookSubscription
+	)
+	subID, perr := uuid.Parse(d.SubscriptionID)
+	if perr == nil {
+		sub, err = u.subs.GetByID(ctx, d.TenantID, subID)
+	}
+	switch {
+	case err != nil:
+		// error al leer la suscripción: se reintenta como cualquier fallo transitorio
+	case perr != nil || sub == nil || !sub.Active:
+		err = errSubscriptionGone
+	default:
+		sctx, cancel := context.WithTimeout(ctx, u.sendTimeout)
+		code, err = u.sender.Send(sctx, *sub, d)
+		cancel()
+	}
+
+	now := time.Now().UTC()
+	if code != 0 {
+		d.LastStatusCode = &code
+	}
+	if err != nil {
+		msg := err.Error()
+		d.LastError = &msg
+		if errors.Is(err, errSubscriptionGone) || d.Attempts >= webhookMaxAttempts {
+			d.Status = domain.DeliveryStatusDead
+			u.logger.WarnContext(ctx, "entrega de webhook descartada", "delivery_id", d.ID, "subscription_id", d.SubscriptionID, "event_type", d.EventType, "attempts", d.Attempts, "error", err)
+		} else {
+			d.Status = domain.DeliveryStatusPending
+			d.NextAttemptAt = now.Add(webhookBackoff(d.Attempts))
+			u.logger.InfoContext(ctx, "entrega de webhook fallida, queda pendiente", "delivery_id", d.ID, "subscription_id", d.SubscriptionID, "event_type", d.EventType, "attempts", d.Attempts, "status_code", code, "next_attempt_at", d.NextAttemptAt, "error", err)
+		}
+	} else {
+		d.Status = domain.DeliveryStatusDelivered
+		d.LastError = nil
+		d.DeliveredAt = &now
+	}
+
+	if err := u.deliveries.SaveDelivery(ctx, d); err != nil {
+		if errors.Is(err, port.ErrDeliveryChanged) {
+			// otro dispatcher la retomó: su intento es el que cuenta
+			u.logger.WarnContext(ctx, "entrega de webhook retomada por otro dispatcher, se descarta este intento", "delivery_id", d.ID, "attempts", d.Attempts)
+			return d
+		}
+		// el lease vence y se vuelve a enviar; el receptor descarta el duplicado por X-Webhook-Id
+		u.logger.ErrorContext(ctx, "no se pudo guardar el estado de la entrega de webhook", "delivery_id", d.ID, "error", err)
+	}
+	return d
+}
+
+// webhookLeaseFor reserva el lote el tiempo que puede tardar enviarlo entero, un envío tras otro y
+// cada uno acotado a sendTimeout, más un margen: así un endpoint lento no hace vencer el lease a
+// mitad del lote y otro dispatcher no retoma (ni vuelve a contar) entregas que este todavía envía.
+func webhookLeaseFor(limit int, sendTimeout time.Duration) time.Duration {
+	return max(time.Duration(limit)*sendTimeout+webhookLeaseMargin, webhookMinLease)
+}
+
+func webhookBackoff(attempts int) time.Duration {
+	d := webhookBaseBackoff
+	for i := 1; i < attempts && d < webhookMaxBackoff; i++ {
+		d *= 2
+	}
+	if d > webhookMaxBackoff {
+		d = webhookMaxBackoff
+	}
+	return d
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	"ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type GetWebhookSubscriptionUseCase struct {
+	repo port.WebhookSubscriptionRepository
+}
+
+func NewGetWebhookSubscriptionUseCase(repo port.WebhookSubscriptionRepository) *GetWebhookSubscriptionUseCase {
+	return &GetWebhookSubscriptionUseCase{repo: repo}
+}
+
+func (u *GetWebhookSubscriptionUseCase) Execute(ctx context.Context, tenantID string, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	ctx, span := tracing.StartUseCase(ctx, "GetWebhookSubscription", tracing.TenantID(tenantID))
+	defer span.End()
+
+	if strings.TrimSpace(tenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	s, err := u.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if s == nil {
+		return nil, apperror.New("not_found", "suscripción no encontrada", map[string]any{"id": id.String()}, 404)
+	}
+	return s, nil
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	"ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type ListWebhookDeliveriesUseCase struct {
+	subs       port.WebhookSubscriptionRepository
+	deliveries port.WebhookDeliveryRepository
+}
+
+func NewListWebhookDeliveriesUseCase(subs port.WebhookSubscriptionRepository, deliveries port.WebhookDeliveryRepository) *ListWebhookDeliveriesUseCase {
+	return &ListWebhookDeliveriesUseCase{subs: subs, deliveries: deliveries}
+}
+
+type ListWebhookDeliveriesInput struct {
+	TenantID       string
+	SubscriptionID uuid.UUID
+	Filters        port.ListWebhookDeliveryFilters
+}
+
+type ListWebhookDeliveriesOutput struct {
+	Items []domain.WebhookDelivery
+	Count int
+}
+
+func (u *ListWebhookDeliveriesUseCase) Execute(ctx context.Context, in ListWebhookDeliveriesInput) (*ListWebhookDeliveriesOutput, error) {
+	ctx, span := tracing.StartUseCase(ctx, "ListWebhookDeliveries", tracing.TenantID(in.TenantID))
+	defer span.End()
+
+	if strings.TrimSpace(in.TenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	sub, err := u.subs.GetByID(ctx, in.TenantID, in.SubscriptionID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if sub == nil {
+		return nil, apperror.New("not_found", "suscripción no encontrada", map[string]any{"id": in.SubscriptionID.String()}, 404)
+	}
+	if s := in.Filters.Status; s != nil {
+		switch *s {
+		case domain.DeliveryStatusPending, domain.DeliveryStatusDelivering, domain.DeliveryStatusDelivered, domain.DeliveryStatusDead:
+		default:
+			return nil, apperror.NewBadRequest("validation_error", "status inválido", map[string]any{"field": "status"})
+		}
+	}
+
+	items, count, err := u.deliveries.ListBySubscription(ctx, in.TenantID, in.SubscriptionID, in.Filters)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return &ListWebhookDeliveriesOutput{Items: items, Count: count}, nil
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	"ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type ListWebhookSubscriptionsUseCase struct {
+	repo port.WebhookSubscriptionRepository
+}
+
+func NewListWebhookSubscriptionsUseCase(repo port.WebhookSubscriptionRepository) *ListWebhookSubscriptionsUseCase {
+	return &ListWebhookSubscriptionsUseCase{repo: repo}
+}
+
+func (u *ListWebhookSubscriptionsUseCase) Execute(ctx context.Context, tenantID string) ([]domain.WebhookSubscription, error) {
+	ctx, span := tracing.StartUseCase(ctx, "ListWebhookSubscriptions", tracing.TenantID(tenantID))
+	defer span.End()
+
+	if strings.TrimSpace(tenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	return u.repo.List(ctx, tenantID)
+}
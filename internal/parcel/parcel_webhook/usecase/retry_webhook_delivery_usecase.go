@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	"ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type RetryWebhookDeliveryUseCase struct {
+	subs       port.WebhookSubscriptionRepository
+	deliveries port.WebhookDeliveryRepository
+}
+
+func NewRetryWebhookDeliveryUseCase(subs port.WebhookSubscriptionRepository, deliveries port.WebhookDeliveryRepository) *RetryWebhookDeliveryUseCase {
+	return &RetryWebhookDeliveryUseCase{subs: subs, deliveries: deliveries}
+}
+
+// Execute reencola una entrega DEAD (p.ej. después de que el cliente arregló su endpoint). Se reinicia
+// el contador de intentos, así que vuelve a tener el ciclo completo de reintentos.
+func (u *RetryWebhookDeliveryUseCase) Execute(ctx context.Context, tenantID string, subscriptionID, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	ctx, span := tracing.StartUseCase(ctx, "RetryWebhookDelivery", tracing.TenantID(tenantID))
+	defer span.End()
+
+	if strings.TrimSpace(tenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	sub, err := u.subs.GetByID(ctx, tenantID, subscriptionID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if sub == nil {
+		return nil, apperror.New("not_found", "suscripción no encontrada", map[string]any{"id": subscriptionID.String()}, 404)
+	}
+	if !sub.Active {
+		return nil, apperror.New("invalid_state", "la suscripción está inactiva", map[string]any{"id": subscriptionID.String()}, 409)
+	}
+
+	d, err := u.deliveries.GetByID(ctx, tenantID, subscriptionID, deliveryID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if d == nil {
+		return nil, apperror.New("not_found", "entrega no encontrada", map[string]any{"delivery_id": deliveryID.String()}, 404)
+	}
+	if d.Status != domain.DeliveryStatusDead {
+		return nil, apperror.New("invalid_state", "solo se pueden reintentar entregas en estado DEAD", map[string]any{"delivery_id": deliveryID.String(), "status": string(d.Status)}, 409)
+	}
+
+	now := time.Now().UTC()
+	if err := u.deliveries.Requeue(ctx, tenantID, deliveryID, now); err != nil {
+		if errors.Is(err, port.ErrDeliveryChanged) {
+			return nil, apperror.New("invalid_state", "solo se pueden reintentar entregas en estado DEAD", map[string]any{"delivery_id": deliveryID.String()}, 409)
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	d.Status = domain.DeliveryStatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.UpdatedAt = now
+	return d, nil
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"ms-parcel-core/internal/parcel/parcel_webhook/domain"
+	"ms-parcel-core/internal/parcel/parcel_webhook/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/tracing"
+)
+
+type UpdateWebhookSubscriptionUseCase struct {
+	repo      port.WebhookSubscriptionRepository
+	urlPolicy WebhookURLPolicy
+}
+
+func NewUpdateWebhookSubscriptionUseCase(repo port.WebhookSubscriptionRepository, urlPolicy WebhookURLPolicy) *UpdateWebhookSubscriptionUseCase {
+	return &UpdateWebhookSubscriptionUseCase{repo: repo, urlPolicy: urlPolicy}
+}
+
+// UpdateWebhookSubscriptionInput es una actualización parcial: los campos nil no se tocan.
+type UpdateWebhookSubscriptionInput struct {
+	TenantID     string
+	ID           uuid.UUID
+	URL          *string
+	EventTypes   *[]string
+	Active       *bool
+	Description  *string
+	RotateSecret bool
+}
+
+func (u *UpdateWebhookSubscriptionUseCase) Execute(ctx context.Context, in UpdateWebhookSubscriptionInput) (*domain.WebhookSubscription, error) {
+	ctx, span := tracing.StartUseCase(ctx, "UpdateWebhookSubscription", tracing.TenantID(in.TenantID))
+	defer span.End()
+
+	if strings.TrimSpace(in.TenantID) == "" {
+		return nil, apperror.NewUnauthorized("unauthorized", "credenciales inválidas", nil)
+	}
+	if in.ID == uuid.Nil {
+		return nil, apperror.NewBadRequest("validation_error", "id inválido", map[string]any{"field": "id"})
+	}
+
+	s, err := u.repo.GetByID(ctx, in.TenantID, in.ID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if s == nil {
+		return nil, apperror.New("not_found", "suscripción no encontrada", map[string]any{"id": in.ID.String()}, 404)
+	}
+
+	if in.URL != nil {
+		url, err := validateWebhookURL(*in.URL, u.urlPolicy)
+		if err != nil {
+			return nil, err
+		}
+		s.URL = url
+	}
+	if in.EventTypes != nil {
+		types, err := normalizeEventTypes(*in.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		s.EventTypes = types
+	}
+	if in.Active != nil {
+		s.Active = *in.Active
+	}
+	if in.Description != nil {
+		s.Description = trimmedOrNil(in.Description)
+	}
+	if in.RotateSecret {
+		// Las entregas pendientes se firman con el secreto vigente al momento del intento
+		secret, err := newWebhookSecret()
+		if err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		s.Secret = secret
+	}
+
+	updated, err := u.repo.Update(ctx, in.TenantID, *s)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if updated == nil {
+		return nil, apperror.New("not_found", "suscripción no encontrada", map[string]any{"id": in.ID.String()}, 404)
+	}
+	return updated, nil
+}
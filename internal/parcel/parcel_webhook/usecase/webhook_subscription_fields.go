@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"slices"
+	"strings"
+
+	coreport "ms-parcel-core/internal/parcel/parcel_core/port"
+	"ms-parcel-core/internal/pkg/util/apperror"
+	"ms-parcel-core/internal/pkg/util/netguard"
+)
+
+// SupportedEventTypes son los eventos de tracking que se pueden suscribir. Los eventos de caja
+// (PAYMENT_*) quedan fuera: no son movimientos del envío.
+var SupportedEventTypes = []string{
+	coreport.EventTypeParcelCreated,
+	coreport.EventTypeParcelRegistered,
+	coreport.EventTypeParcelBoarded,
+	coreport.EventTypeParcelInTransit,
+	coreport.EventTypeParcelArrivedDestination,
+	coreport.EventTypeParcelDelivered,
+	coreport.EventTypeParcelItemAdded,
+	coreport.EventTypeParcelItemRemoved,
+}
+
+func IsSupportedEventType(eventType string) bool {
+	return slices.Contains(SupportedEventTypes, eventType)
+}
+
+const webhookSecretPrefix = "whsec_"
+
+// WebhookURLPolicy define qué URLs se aceptan. AllowInsecure (solo desarrollo) permite http y
+// destinos locales o privados; si no, la URL debe ser https hacia un host público.
+type WebhookURLPolicy struct {
+	AllowInsecure bool
+}
+
+// validateWebhookURL rechaza de antemano los destinos locales conocidos; los nombres que resuelven a
+// una IP privada los frena el sender al conectar.
+func validateWebhookURL(raw string, policy WebhookURLPolicy) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", apperror.NewBadRequest("validation_error", "url inválida: debe ser http(s) absoluta", map[string]any{"field": "url"})
+	}
+	if len(raw) > 500 {
+		return "", apperror.NewBadRequest("validation_error", "url demasiado larga", map[string]any{"field": "url", "max": 500})
+	}
+	if policy.AllowInsecure {
+		return raw, nil
+	}
+	if u.Scheme != "https" {
+		return "", apperror.NewBadRequest("validation_error", "url inválida: debe ser https", map[string]any{"field": "url"})
+	}
+	if u.User != nil {
+		return "", apperror.NewBadRequest("validation_error", "url inválida: no debe incluir credenciales", map[string]any{"field": "url"})
+	}
+	if err := netguard.CheckHost(u.Hostname()); err != nil {
+		return "", apperror.NewBadRequest("validation_error", "url inválida: el destino debe ser un host público", map[string]any{"field": "url"})
+	}
+	return raw, nil
+}
+
+// normalizeEventTypes valida los tipos, los pasa a mayúsculas y quita duplicados (vacío = todos).
+func normalizeEventTypes(types []string) ([]string, error) {
+	out := make([]string, 0, len(types))
+	for _, t := range types {
+		t = strings.ToUpper(strings.TrimSpace(t))
+		if !IsSupportedEventType(t) {
+			return nil, apperror.NewBadRequest("validation_error", "tipo de evento no soportado", map[string]any{"field": "event_types", "value": t, "supported": SupportedEventTypes})
+		}
+		if !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", apperror.NewInternal("internal_error", "no se pudo generar el secreto", nil)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(b), nil
+}
+
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -1,13 +1,18 @@
 package apperror
 
-import "net/http"
+import (
+	"errors"
+	"net/http"
+)
 
 // AppError represents an error with an HTTP status code.
 type AppError struct {
 	Code    string      `json:"code"`
 	Message string      `json:"message"`
 	Details interface{} `json:"details,omitempty"`
-	Status  int         `json:"-"`
+	// RequestID correlates the response with server logs; set when the error is rendered.
+	RequestID string `json:"request_id,omitempty"`
+	Status    int    `json:"-"`
 }
 
 // Error makes AppError implement the built-in error interface.
@@ -21,6 +26,16 @@ func (e *AppError) Error() string {
 	return e.Code
 }
 
+// WithRequestID returns a copy of the error tagged with the given request ID.
+func (e *AppError) WithRequestID(requestID string) *AppError {
+	if e == nil {
+		return nil
+	}
+	cp := *e
+	cp.RequestID = requestID
+	return &cp
+}
+
 // New creates a new AppError instance.
 func New(code string, message string, details interface{}, status int) *AppError {
 	return &AppError{
@@ -45,3 +60,12 @@ func NewUnauthorized(code string, message string, details any) *AppError {
 func NewInternal(code string, message string, details any) *AppError {
 	return New(code, message, details, http.StatusInternalServerError)
 }
+
+// CodeOf returns the AppError code wrapped in err, or "internal_error" for any other error.
+func CodeOf(err error) string {
+	var appErr *AppError
+	if errors.As(err, &appErr) && appErr != nil && appErr.Code != "" {
+		return appErr.Code
+	}
+	return "internal_error"
+}
@@ -0,0 +1,101 @@
+// Package logging arma el logger estructurado (log/slog) de la aplicación.
+// Los registros emitidos con las variantes *Context incluyen request_id, tenant_id, user_id
+// y, si hay un span activo, trace_id.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"ms-parcel-core/internal/pkg/util/requestctx"
+)
+
+// New crea el logger: JSON en producción (APP_ENV=prod|production) y texto en el resto.
+func New(w io.Writer, env string, level slog.Level) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: redactSensitive}
+	var h slog.Handler
+	switch strings.ToLower(strings.TrimSpace(env)) {
+	case "prod", "production":
+		h = slog.NewJSONHandler(w, opts)
+	default:
+		h = slog.NewTextHandler(w, opts)
+	}
+	return slog.New(contextHandler{Handler: h})
+}
+
+// NewFromEnv crea el logger a stdout según APP_ENV y LOG_LEVEL (debug, info, warn, error; default info).
+func NewFromEnv() *slog.Logger {
+	return New(os.Stdout, os.Getenv("APP_ENV"), LevelFromEnv())
+}
+
+// LevelFromEnv lee LOG_LEVEL; default info.
+func LevelFromEnv() slog.Level {
+	level := slog.LevelInfo
+	if v := strings.TrimSpace(os.Getenv("LOG_LEVEL")); v != "" {
+		_ = level.UnmarshalText([]byte(v))
+	}
+	return level
+}
+
+// OrDiscard devuelve l o, si es nil, un logger que descarta todo.
+func OrDiscard(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return l
+}
+
+// sensitiveKeys nunca se escriben en los logs aunque se pasen por error como atributo.
+var sensitiveKeys = map[string]bool{
+	"package_key":         true,
+	"package_key_confirm": true,
+	"authorization":       true,
+	"token":               true,
+	"password":            true,
+}
+
+func redactSensitive(groups []string, a slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, "[REDACTED]")
+	}
+	return a
+}
+
+// contextHandler agrega los datos del request guardados en el context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	// los atributos explícitos del registro tienen prioridad sobre los del context
+	present := map[string]bool{}
+	r.Attrs(func(a slog.Attr) bool {
+		present[a.Key] = true
+		return true
+	})
+	add := func(key string, v string) {
+		if v != "" && !present[key] {
+			r.AddAttrs(slog.String(key, v))
+		}
+	}
+	add("request_id", requestctx.RequestID(ctx))
+	add("tenant_id", requestctx.TenantID(ctx))
+	add("user_id", requestctx.UserID(ctx))
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		add("trace_id", sc.TraceID().String())
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,58 @@
+// Package netguard evita que las llamadas salientes a URLs elegidas por un tenant (webhooks) lleguen
+// a la red interna: loopback, redes privadas, link-local (metadata de la nube) y similares.
+package netguard
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+// ErrForbiddenDestination indica que el destino no es una dirección pública.
+var ErrForbiddenDestination = errors.New("destino no permitido: dirección local o privada")
+
+// cgnat (100.64.0.0/10) no entra en netip.Addr.IsPrivate pero tampoco es enrutable desde afuera.
+var cgnat = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPublic indica si addr es una dirección unicast pública.
+func IsPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsUnspecified() &&
+		!cgnat.Contains(addr)
+}
+
+// CheckHost rechaza de antemano los hosts que ya se sabe que son locales (localhost o una IP literal
+// no pública). Los nombres se resuelven recién al conectar: ahí valida DialControl.
+func CheckHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenDestination
+	}
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil && !IsPublic(addr) {
+		return ErrForbiddenDestination
+	}
+	return nil
+}
+
+// DialControl se usa como net.Dialer.Control: corre con la IP ya resuelta, justo antes de conectar,
+// así que un DNS que cambia de respuesta (rebinding) no la puede saltar.
+func DialControl(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !IsPublic(addr) {
+		return ErrForbiddenDestination
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Package ratelimit implementa un límite de ventana fija por clave (IP, tracking code, ...) en memoria.
+// Cada réplica cuenta por separado: el límite efectivo es limit × réplicas, suficiente para frenar
+// abuso y fuerza bruta sin depender de un store compartido.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepEvery es cada cuántas operaciones se descartan las ventanas vencidas.
+const sweepEvery = 1024
+
+type bucket struct {
+	start time.Time
+	count int
+}
+
+type Limiter struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mu   sync.Mutex
+	keys map[string]*bucket
+	ops  int
+}
+
+// New crea un limitador de limit operaciones por clave cada per. Con limit <= 0 no limita.
+func New(limit int, per time.Duration) *Limiter {
+	if per <= 0 {
+		per = time.Minute
+	}
+	return &Limiter{limit: limit, window: per, now: time.Now, keys: map[string]*bucket{}}
+}
+
+// Allow consume una operación de key. Si se superó el límite devuelve false y cuánto falta para
+// que se libere la ventana.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if l == nil || l.limit <= 0 {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w := l.current(key, true)
+	if w.count >= l.limit {
+		return false, l.retryAfter(w)
+	}
+	w.count++
+	return true, 0
+}
+
+// Exceeded indica, sin consumir, si key ya agotó su límite (p.ej. intentos fallidos de verificación).
+func (l *Limiter) Exceeded(key string) (bool, time.Duration) {
+	if l == nil || l.limit <= 0 {
+		return false, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w := l.current(key, false)
+	if w == nil || w.count < l.limit {
+		return false, 0
+	}
+	return true, l.retryAfter(w)
+}
+
+// current devuelve la ventana vigente de key; con create la abre si no hay una.
+func (l *Limiter) current(key string, create bool) *bucket {
+	now := l.now()
+	l.ops++
+	if l.ops%sweepEvery == 0 {
+		for k, w := range l.keys {
+			if now.Sub(w.start) >= l.window {
+				delete(l.keys, k)
+			}
+		}
+	}
+
+	w, ok := l.keys[key]
+	if ok && now.Sub(w.start) < l.window {
+		return w
+	}
+	if !create {
+		return nil
+	}
+	w = &bucket{start: now}
+	l.keys[key] = w
+	return w
+}
+
+func (l *Limiter) retryAfter(w *bucket) time.Duration {
+	d := l.window - l.now().Sub(w.start)
+	if d < time.Second {
+		d = time.Second
+	}
+	return d
+}
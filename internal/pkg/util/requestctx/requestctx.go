@@ -0,0 +1,92 @@
+// Package requestctx transporta datos del request entrante (token, request ID, tenant, usuario,
+// origen y posición del dispositivo) por el context para que los clientes hacia otros servicios, el
+// logger y el registro de tracking los puedan usar.
+package requestctx
+
+import "context"
+
+type ctxKey int
+
+const (
+	authTokenKey ctxKey = iota
+	requestIDKey
+	tenantIDKey
+	userIDKey
+	eventSourceKey
+	locationKey
+)
+
+// WithAuthToken guarda el token del caller (sin el prefijo "Bearer ").
+func WithAuthToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, authTokenKey, token)
+}
+
+// AuthToken devuelve el token del caller o "" si no hay.
+func AuthToken(ctx context.Context) string {
+	return stringValue(ctx, authTokenKey)
+}
+
+// WithRequestID guarda el identificador del request para correlacionar logs y respuestas.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID devuelve el identificador del request o "" si no hay.
+func RequestID(ctx context.Context) string {
+	return stringValue(ctx, requestIDKey)
+}
+
+// WithIdentity guarda tenant y usuario autenticados.
+func WithIdentity(ctx context.Context, tenantID string, userID string) context.Context {
+	ctx = context.WithValue(ctx, tenantIDKey, tenantID)
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// TenantID devuelve el tenant del caller o "" si no hay.
+func TenantID(ctx context.Context) string {
+	return stringValue(ctx, tenantIDKey)
+}
+
+// UserID devuelve el usuario del caller o "" si no hay.
+func UserID(ctx context.Context) string {
+	return stringValue(ctx, userIDKey)
+}
+
+// WithEventSource guarda el origen declarado por el cliente (COUNTER, SCANNER_APP, API).
+func WithEventSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, eventSourceKey, source)
+}
+
+// EventSource devuelve el origen del request o "" si no hay (procesos internos).
+func EventSource(ctx context.Context) string {
+	return stringValue(ctx, eventSourceKey)
+}
+
+// Location es la posición GPS que reporta el dispositivo del caller.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+	AccuracyM *float64
+}
+
+// WithLocation guarda la posición del dispositivo del caller.
+func WithLocation(ctx context.Context, loc Location) context.Context {
+	return context.WithValue(ctx, locationKey, loc)
+}
+
+// DeviceLocation devuelve la posición del dispositivo del caller, si la informó.
+func DeviceLocation(ctx context.Context) (Location, bool) {
+	if ctx == nil {
+		return Location{}, false
+	}
+	loc, ok := ctx.Value(locationKey).(Location)
+	return loc, ok
+}
+
+func stringValue(ctx context.Context, key ctxKey) string {
+	if ctx == nil {
+		return ""
+	}
+	s, _ := ctx.Value(key).(string)
+	return s
+}
@@ -0,0 +1,68 @@
+// Package tracing centraliza los spans de OpenTelemetry del servicio: nombre del tracer y
+// atributos comunes (tenant, parcel, caso de uso). Usa el TracerProvider global, que es no-op
+// salvo que main configure un exporter.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"ms-parcel-core/internal/pkg/util/requestctx"
+)
+
+// InstrumentationName identifica al tracer del servicio.
+const InstrumentationName = "ms-parcel-core"
+
+const (
+	AttrTenantID = attribute.Key("tenant.id")
+	AttrParcelID = attribute.Key("parcel.id")
+	AttrUseCase  = attribute.Key("usecase.name")
+)
+
+// Start abre un span hijo del que venga en ctx. Si no se pasa tenant.id se toma el del request.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !hasKey(attrs, AttrTenantID) {
+		if tenantID := requestctx.TenantID(ctx); tenantID != "" {
+			attrs = append(attrs, AttrTenantID.String(tenantID))
+		}
+	}
+	return otel.Tracer(InstrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartUseCase abre el span de un Execute; el nombre del span es "usecase.<name>".
+func StartUseCase(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, AttrUseCase.String(name))
+	return Start(ctx, "usecase."+name, attrs...)
+}
+
+// TenantID arma el atributo tenant.id.
+func TenantID(tenantID string) attribute.KeyValue {
+	return AttrTenantID.String(tenantID)
+}
+
+// ParcelID arma el atributo parcel.id.
+func ParcelID(parcelID string) attribute.KeyValue {
+	return AttrParcelID.String(parcelID)
+}
+
+// RecordError marca el span como fallido; no hace nada si err es nil.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func hasKey(attrs []attribute.KeyValue, key attribute.Key) bool {
+	for _, a := range attrs {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}